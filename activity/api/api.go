@@ -0,0 +1,61 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/ThingsIXFoundation/data-aggregator/activity"
+	"github.com/go-chi/chi/v5"
+)
+
+// Options configure an ActivityAPI.
+type Options struct {
+	Feed *activity.Feed
+}
+
+type ActivityAPI struct {
+	feed *activity.Feed
+}
+
+// New creates an ActivityAPI with the given options.
+func New(opts Options) *ActivityAPI {
+	return &ActivityAPI{
+		feed: opts.Feed,
+	}
+}
+
+// NewActivityAPI creates an ActivityAPI from the config.
+func NewActivityAPI() (*ActivityAPI, error) {
+	feed, err := activity.NewFeed()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Feed: feed,
+	}), nil
+}
+
+func (aapi *ActivityAPI) Bind(root *chi.Mux) error {
+	root.Route("/activity", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Get("/", aapi.NetworkActivity)
+			r.Get("/owners/{owner:(?i)(0x)?[0-9a-f]{40}}", aapi.OwnerActivity)
+		})
+	})
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/activity"
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+)
+
+func (aapi *ActivityAPI) NetworkActivity(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		cursor      = r.URL.Query().Get("cursor")
+		pageSize    = utils.PageSizeFromRequest(r)
+	)
+	defer cancel()
+
+	filter, err := typesFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	activities, cursor, err := aapi.feed.Network(ctx, filter, pageSize, cursor)
+	if errors.Is(err, activity.ErrInvalidCursor) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.WithError(err).Error("unable to retrieve network activity")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.ActivityResponse{
+		Cursor:     cursor,
+		Activities: activities,
+	})
+}
+
+func (aapi *ActivityAPI) OwnerActivity(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		cursor      = r.URL.Query().Get("cursor")
+		pageSize    = utils.PageSizeFromRequest(r)
+		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
+	)
+	defer cancel()
+
+	filter, err := typesFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	activities, cursor, err := aapi.feed.Owner(ctx, owner, filter, pageSize, cursor)
+	if errors.Is(err, activity.ErrInvalidCursor) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.WithError(err).WithField("owner", owner).Error("unable to retrieve owner activity")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.ActivityResponse{
+		Cursor:     cursor,
+		Activities: activities,
+	})
+}
+
+// typesFromRequest returns the activity types of the repeatable type query
+// parameter.
+func typesFromRequest(r *http.Request) ([]activity.Type, error) {
+	var filter []activity.Type
+	for _, s := range r.URL.Query()["type"] {
+		t, err := activity.ParseType(s)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, t)
+	}
+	return filter, nil
+}
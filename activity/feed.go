@@ -0,0 +1,424 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package activity merges the gateway, mapper and router events and the
+// reward dates into a single feed, for an owner or for the whole network.
+//
+// Every source of a feed is paged with its own datastore cursor, the cursor
+// of the feed holds the cursors of all sources so a page continues exactly
+// where the previous page stopped.
+package activity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	rewardStore "github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Type is the kind of activity.
+type Type string
+
+const (
+	TypeGateway Type = "gateway"
+	TypeMapper  Type = "mapper"
+	TypeRouter  Type = "router"
+	TypeRewards Type = "rewards"
+)
+
+// Types returns all activity types.
+func Types() []Type {
+	return []Type{TypeGateway, TypeMapper, TypeRouter, TypeRewards}
+}
+
+// ParseType parses an activity type.
+func ParseType(s string) (Type, error) {
+	for _, t := range Types() {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid activity type: %s", s)
+}
+
+// ErrInvalidCursor is returned when a feed cursor can't be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// entry is an activity of a feed with the key that orders activities with
+// the same time the way the datastore does and identifies events that are
+// returned by more than one source.
+type entry struct {
+	*apitypes.Activity
+	key string
+}
+
+// before reports if a comes before b in a feed, newest first.
+func (a *entry) before(b *entry) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.After(b.Time)
+	}
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.Type < b.Type
+}
+
+func (a *entry) same(b *entry) bool {
+	return a.Type == b.Type && a.key == b.key
+}
+
+func eventKey(blockNumber uint64, transactionIndex, logIndex uint) string {
+	return fmt.Sprintf("%016x.%016x.%016x", blockNumber, transactionIndex, logIndex)
+}
+
+// source pages through one kind of activity from newest to oldest and
+// returns the cursor after each activity.
+type source struct {
+	name  string
+	typ   Type
+	fetch func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error)
+}
+
+// Options configure a Feed.
+type Options struct {
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	RewardStore  rewardStore.Store
+}
+
+type Feed struct {
+	gatewayStore gatewayStore.Store
+	mapperStore  mapperStore.Store
+	routerStore  routerStore.Store
+	rewardStore  rewardStore.Store
+}
+
+// New creates a Feed with the given options.
+func New(opts Options) *Feed {
+	return &Feed{
+		gatewayStore: opts.GatewayStore,
+		mapperStore:  opts.MapperStore,
+		routerStore:  opts.RouterStore,
+		rewardStore:  opts.RewardStore,
+	}
+}
+
+// NewFeed creates a Feed from the config.
+func NewFeed() (*Feed, error) {
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	rewardStore, err := rewardStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		GatewayStore: gatewayStore,
+		MapperStore:  mapperStore,
+		RouterStore:  routerStore,
+		RewardStore:  rewardStore,
+	}), nil
+}
+
+// Network returns up to limit activities of the whole network with one of
+// the types in filter, or all types when filter is empty, newest first. The
+// returned cursor is empty when there are no more activities.
+func (f *Feed) Network(ctx context.Context, filter []Type, limit int, cursor string) ([]*apitypes.Activity, string, error) {
+	return page(ctx, filterSources(filter, []source{
+		{name: "gateways", typ: TypeGateway, fetch: gatewayEvents(f.gatewayStore.GetLatestEvents)},
+		{name: "mappers", typ: TypeMapper, fetch: mapperEvents(f.mapperStore.GetLatestEvents)},
+		{name: "routers", typ: TypeRouter, fetch: routerEvents(f.routerStore.GetLatestEvents)},
+		{name: "rewards", typ: TypeRewards, fetch: f.networkRewards},
+	}), limit, cursor)
+}
+
+// Owner returns up to limit activities of the devices the owner owns or has
+// owned and the rewards of the owner with one of the types in filter, or all
+// types when filter is empty, newest first. The returned cursor is empty when
+// there are no more activities.
+func (f *Feed) Owner(ctx context.Context, owner common.Address, filter []Type, limit int, cursor string) ([]*apitypes.Activity, string, error) {
+	gatewaysByOwner := func(oldOwner bool) func(ctx context.Context, limit int, cursor string) ([]*types.GatewayEvent, []string, error) {
+		return func(ctx context.Context, limit int, cursor string) ([]*types.GatewayEvent, []string, error) {
+			return f.gatewayStore.GetEventsByOwner(ctx, owner, oldOwner, limit, cursor)
+		}
+	}
+	mappersByOwner := func(oldOwner bool) func(ctx context.Context, limit int, cursor string) ([]*types.MapperEvent, []string, error) {
+		return func(ctx context.Context, limit int, cursor string) ([]*types.MapperEvent, []string, error) {
+			return f.mapperStore.GetEventsByOwner(ctx, owner, oldOwner, limit, cursor)
+		}
+	}
+
+	// events that have the owner as old and new owner are returned by both
+	// owner sources, page drops the duplicate
+	return page(ctx, filterSources(filter, []source{
+		{name: "gateways", typ: TypeGateway, fetch: gatewayEvents(gatewaysByOwner(false))},
+		{name: "gatewaysOld", typ: TypeGateway, fetch: gatewayEvents(gatewaysByOwner(true))},
+		{name: "mappers", typ: TypeMapper, fetch: mapperEvents(mappersByOwner(false))},
+		{name: "mappersOld", typ: TypeMapper, fetch: mapperEvents(mappersByOwner(true))},
+		{name: "routers", typ: TypeRouter, fetch: routerEvents(func(ctx context.Context, limit int, cursor string) ([]*types.RouterEvent, []string, error) {
+			return f.routerStore.GetEventsByOwner(ctx, owner, limit, cursor)
+		})},
+		{name: "rewards", typ: TypeRewards, fetch: func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+			return f.accountRewards(ctx, owner, limit, cursor)
+		}},
+	}), limit, cursor)
+}
+
+func gatewayEvents(fetch func(ctx context.Context, limit int, cursor string) ([]*types.GatewayEvent, []string, error)) func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+	return func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+		events, cursors, err := fetch(ctx, limit, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		activities := make([]*entry, len(events))
+		for i, event := range events {
+			activities[i] = &entry{
+				Activity: &apitypes.Activity{
+					Type:         string(TypeGateway),
+					Time:         event.Time,
+					GatewayEvent: event,
+				},
+				key: eventKey(event.BlockNumber, event.TransactionIndex, event.LogIndex),
+			}
+		}
+		return activities, cursors, nil
+	}
+}
+
+func mapperEvents(fetch func(ctx context.Context, limit int, cursor string) ([]*types.MapperEvent, []string, error)) func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+	return func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+		events, cursors, err := fetch(ctx, limit, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		activities := make([]*entry, len(events))
+		for i, event := range events {
+			activities[i] = &entry{
+				Activity: &apitypes.Activity{
+					Type:        string(TypeMapper),
+					Time:        event.Time,
+					MapperEvent: event,
+				},
+				key: eventKey(event.BlockNumber, event.TransactionIndex, event.LogIndex),
+			}
+		}
+		return activities, cursors, nil
+	}
+}
+
+func routerEvents(fetch func(ctx context.Context, limit int, cursor string) ([]*types.RouterEvent, []string, error)) func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+	return func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+		events, cursors, err := fetch(ctx, limit, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		activities := make([]*entry, len(events))
+		for i, event := range events {
+			activities[i] = &entry{
+				Activity: &apitypes.Activity{
+					Type:        string(TypeRouter),
+					Time:        event.Time,
+					RouterEvent: event,
+				},
+				key: eventKey(event.BlockNumber, event.TransactionIndex, event.LogIndex),
+			}
+		}
+		return activities, cursors, nil
+	}
+}
+
+func (f *Feed) networkRewards(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+	histories, cursors, err := f.rewardStore.GetLatestRewardHistories(ctx, limit, cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	activities := make([]*entry, len(histories))
+	for i, rh := range histories {
+		activities[i] = &entry{
+			Activity: &apitypes.Activity{
+				Type: string(TypeRewards),
+				Time: rh.Date,
+				NetworkRewards: &apitypes.NetworkRewards{
+					Date:                           rh.Date,
+					TotalAssumedCoverageShareUnits: rh.TotalAssumedCoverageShareUnits,
+					TotalMappingUnits:              rh.TotalMappingUnits,
+					TotalRewards:                   rh.TotalRewards,
+				},
+			},
+			key: rh.Date.String(),
+		}
+	}
+	return activities, cursors, nil
+}
+
+func (f *Feed) accountRewards(ctx context.Context, owner common.Address, limit int, cursor string) ([]*entry, []string, error) {
+	rewards, cursors, err := f.rewardStore.GetLatestAccountRewards(ctx, owner, limit, cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	activities := make([]*entry, len(rewards))
+	for i, reward := range rewards {
+		activities[i] = &entry{
+			Activity: &apitypes.Activity{
+				Type:           string(TypeRewards),
+				Time:           reward.Date,
+				AccountRewards: reward,
+			},
+			key: reward.Date.String(),
+		}
+	}
+	return activities, cursors, nil
+}
+
+func filterSources(filter []Type, sources []source) []source {
+	if len(filter) == 0 {
+		return sources
+	}
+	var filtered []source
+	for _, src := range sources {
+		for _, t := range filter {
+			if src.typ == t {
+				filtered = append(filtered, src)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// page fetches a page from every source concurrently and merges them into a
+// single page of at most limit activities.
+func page(ctx context.Context, sources []source, limit int, cursor string) ([]*apitypes.Activity, string, error) {
+	cursors, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		fetchErr   error
+		activities = make([][]*entry, len(sources))
+		after      = make([][]string, len(sources))
+	)
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src source) {
+			defer wg.Done()
+			// one more than the limit shows if there is a next page
+			a, c, err := src.fetch(ctx, limit+1, cursors[src.name])
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if fetchErr == nil {
+					fetchErr = fmt.Errorf("unable to fetch %s activity: %w", src.name, err)
+				}
+				return
+			}
+			activities[i], after[i] = a, c
+		}(i, src)
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, "", fetchErr
+	}
+
+	var (
+		merged = []*apitypes.Activity{}
+		heads  = make([]int, len(sources))
+	)
+	for len(merged) < limit {
+		next := -1
+		for i := range sources {
+			if heads[i] < len(activities[i]) && (next == -1 || activities[i][heads[i]].before(activities[next][heads[next]])) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+
+		activity := activities[next][heads[next]]
+		merged = append(merged, activity.Activity)
+		for i, src := range sources {
+			if heads[i] < len(activities[i]) && activities[i][heads[i]].same(activity) {
+				cursors[src.name] = after[i][heads[i]]
+				heads[i]++
+			}
+		}
+	}
+
+	more := false
+	for i := range sources {
+		if heads[i] < len(activities[i]) {
+			more = true
+		}
+	}
+	if !more {
+		return merged, "", nil
+	}
+
+	next, err := encodeCursor(cursors)
+	if err != nil {
+		return nil, "", err
+	}
+	return merged, next, nil
+}
+
+func decodeCursor(cursor string) (map[string]string, error) {
+	cursors := make(map[string]string)
+	if cursor == "" {
+		return cursors, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &cursors); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursors, nil
+}
+
+func encodeCursor(cursors map[string]string) (string, error) {
+	raw, err := json.Marshal(cursors)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package activity
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+)
+
+var feedStart = time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+// activity returns an entry of the type at the minute before the start of
+// the feed, the key names the event.
+func activity(typ Type, minute int, key string) *entry {
+	return &entry{
+		Activity: &apitypes.Activity{Type: string(typ), Time: feedStart.Add(-time.Duration(minute) * time.Minute)},
+		key:      key,
+	}
+}
+
+// sliceSource pages through the entries, which are newest first, the cursor
+// is the index of the next entry.
+func sliceSource(name string, typ Type, entries ...*entry) source {
+	return source{name: name, typ: typ, fetch: func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+		start := 0
+		if cursor != "" {
+			var err error
+			if start, err = strconv.Atoi(cursor); err != nil {
+				return nil, nil, err
+			}
+		}
+		end := start + limit
+		if end > len(entries) {
+			end = len(entries)
+		}
+		cursors := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			cursors = append(cursors, strconv.Itoa(i+1))
+		}
+		return entries[start:end], cursors, nil
+	}}
+}
+
+// keys returns the keys of the activities of the sources in the order of the
+// activities.
+func keys(sources []source, activities []*apitypes.Activity) []string {
+	names := make(map[*apitypes.Activity]string)
+	for _, src := range sources {
+		entries, _, _ := src.fetch(context.Background(), 1000, "")
+		for _, e := range entries {
+			names[e.Activity] = e.key
+		}
+	}
+	keys := make([]string, len(activities))
+	for i, a := range activities {
+		keys[i] = names[a]
+	}
+	return keys
+}
+
+func TestPage(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sources []source
+		limit   int
+		// pages are the keys of the pages until there is no next cursor
+		pages [][]string
+	}{
+		{
+			name:    "no activity",
+			sources: []source{sliceSource("gateways", TypeGateway), sliceSource("mappers", TypeMapper)},
+			limit:   3,
+			pages:   [][]string{{}},
+		},
+		{
+			name: "merged newest first",
+			sources: []source{
+				sliceSource("gateways", TypeGateway, activity(TypeGateway, 1, "g1"), activity(TypeGateway, 4, "g4"), activity(TypeGateway, 5, "g5")),
+				sliceSource("mappers", TypeMapper, activity(TypeMapper, 2, "m2"), activity(TypeMapper, 3, "m3")),
+				sliceSource("rewards", TypeRewards, activity(TypeRewards, 6, "r6")),
+			},
+			limit: 10,
+			pages: [][]string{{"g1", "m2", "m3", "g4", "g5", "r6"}},
+		},
+		{
+			name: "same time ordered by key",
+			sources: []source{
+				sliceSource("gateways", TypeGateway, activity(TypeGateway, 1, "b"), activity(TypeGateway, 2, "d")),
+				sliceSource("mappers", TypeMapper, activity(TypeMapper, 1, "a"), activity(TypeMapper, 1, "c")),
+			},
+			limit: 10,
+			pages: [][]string{{"a", "b", "c", "d"}},
+		},
+		{
+			name: "event of old and new owner once",
+			sources: []source{
+				sliceSource("gateways", TypeGateway, activity(TypeGateway, 1, "g1"), activity(TypeGateway, 3, "transfer"), activity(TypeGateway, 5, "g5")),
+				sliceSource("gatewaysOld", TypeGateway, activity(TypeGateway, 2, "o2"), activity(TypeGateway, 3, "transfer"), activity(TypeGateway, 4, "o4")),
+			},
+			limit: 10,
+			pages: [][]string{{"g1", "o2", "transfer", "o4", "g5"}},
+		},
+		{
+			name: "pages resume every source",
+			sources: []source{
+				sliceSource("gateways", TypeGateway, activity(TypeGateway, 1, "g1"), activity(TypeGateway, 3, "transfer"), activity(TypeGateway, 6, "g6")),
+				sliceSource("gatewaysOld", TypeGateway, activity(TypeGateway, 2, "o2"), activity(TypeGateway, 3, "transfer"), activity(TypeGateway, 7, "o7")),
+				sliceSource("routers", TypeRouter, activity(TypeRouter, 4, "r4"), activity(TypeRouter, 5, "r5")),
+			},
+			limit: 2,
+			pages: [][]string{{"g1", "o2"}, {"transfer", "r4"}, {"r5", "g6"}, {"o7"}},
+		},
+		{
+			name: "last page full",
+			sources: []source{
+				sliceSource("gateways", TypeGateway, activity(TypeGateway, 1, "g1"), activity(TypeGateway, 3, "g3")),
+				sliceSource("mappers", TypeMapper, activity(TypeMapper, 2, "m2"), activity(TypeMapper, 4, "m4")),
+			},
+			limit: 2,
+			pages: [][]string{{"g1", "m2"}, {"g3", "m4"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				cursor string
+				pages  [][]string
+			)
+			for {
+				activities, next, err := page(context.Background(), tc.sources, tc.limit, cursor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pages = append(pages, keys(tc.sources, activities))
+				if next == "" {
+					break
+				}
+				if len(pages) > len(tc.pages) {
+					t.Fatalf("pages %v, want %v", pages, tc.pages)
+				}
+				cursor = next
+			}
+
+			if !reflect.DeepEqual(pages, tc.pages) {
+				t.Errorf("pages %v, want %v", pages, tc.pages)
+			}
+		})
+	}
+}
+
+func TestPageErrors(t *testing.T) {
+	failing := source{name: "mappers", typ: TypeMapper, fetch: func(ctx context.Context, limit int, cursor string) ([]*entry, []string, error) {
+		return nil, nil, errors.New("datastore unavailable")
+	}}
+
+	for _, tc := range []struct {
+		name    string
+		sources []source
+		cursor  string
+		want    error
+	}{
+		{"invalid base64", []source{sliceSource("gateways", TypeGateway)}, "!!", ErrInvalidCursor},
+		{"invalid json", []source{sliceSource("gateways", TypeGateway)}, "bm90IGpzb24", ErrInvalidCursor},
+		{"source fails", []source{sliceSource("gateways", TypeGateway), failing}, "", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := page(context.Background(), tc.sources, 10, tc.cursor)
+			if err == nil {
+				t.Fatalf("page returned no error")
+			}
+			if tc.want != nil && !errors.Is(err, tc.want) {
+				t.Errorf("error %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
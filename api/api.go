@@ -22,12 +22,25 @@ import (
 	"net/http"
 	"time"
 
+	activityapi "github.com/ThingsIXFoundation/data-aggregator/activity/api"
+	"github.com/ThingsIXFoundation/data-aggregator/api/graphql"
+	"github.com/ThingsIXFoundation/data-aggregator/api/openapi"
+	"github.com/ThingsIXFoundation/data-aggregator/api/ratelimit"
+	"github.com/ThingsIXFoundation/data-aggregator/api/status"
+	"github.com/ThingsIXFoundation/data-aggregator/api/stream"
+	"github.com/ThingsIXFoundation/data-aggregator/api/tiles"
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	gatewayapi "github.com/ThingsIXFoundation/data-aggregator/gateway/api"
 	mapperapi "github.com/ThingsIXFoundation/data-aggregator/mapper/api"
 	mappingapi "github.com/ThingsIXFoundation/data-aggregator/mapping/api"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	ownerapi "github.com/ThingsIXFoundation/data-aggregator/owner/api"
 	rewardapi "github.com/ThingsIXFoundation/data-aggregator/rewards/api"
 	routerapi "github.com/ThingsIXFoundation/data-aggregator/router/api"
+	statsapi "github.com/ThingsIXFoundation/data-aggregator/stats/api"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	webhookapi "github.com/ThingsIXFoundation/data-aggregator/webhook/api"
 	httputils "github.com/ThingsIXFoundation/http-utils"
 	"github.com/ThingsIXFoundation/http-utils/cache"
 	"github.com/go-chi/chi/v5"
@@ -36,16 +49,88 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Options configure an API. Registry APIs that are nil aren't served.
+type Options struct {
+	// ListenAddress is the address the HTTP service listens on.
+	ListenAddress string
+
+	GatewayAPI  *gatewayapi.GatewayAPI
+	RouterAPI   *routerapi.RouterAPI
+	MapperAPI   *mapperapi.MapperAPI
+	MappingAPI  *mappingapi.MappingAPI
+	RewardsAPI  *rewardapi.RewardsAPI
+	StatsAPI    *statsapi.StatsAPI
+	OwnerAPI    *ownerapi.OwnerAPI
+	ActivityAPI *activityapi.ActivityAPI
+	Tiles       *tiles.Tiles
+	GraphQL     *graphql.GraphQL
+	Stream      *stream.Stream
+	WebhookAPI  *webhookapi.WebhookAPI
+	// OpenAPI serves the OpenAPI document and validates against it.
+	OpenAPI *openapi.OpenAPI
+	// RateLimit limits the requests, when nil requests aren't limited.
+	RateLimit *ratelimit.RateLimit
+	// Status serves the readiness probe and the sync status.
+	Status *status.Status
+
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type API struct {
-	gatewayAPI *gatewayapi.GatewayAPI
-	routerAPI  *routerapi.RouterAPI
-	mapperAPI  *mapperapi.MapperAPI
-	mappingAPI *mappingapi.MappingAPI
-	rewardAPI  *rewardapi.RewardsAPI
+	listenAddress string
+	log           logrus.FieldLogger
+
+	gatewayAPI  *gatewayapi.GatewayAPI
+	routerAPI   *routerapi.RouterAPI
+	mapperAPI   *mapperapi.MapperAPI
+	mappingAPI  *mappingapi.MappingAPI
+	rewardAPI   *rewardapi.RewardsAPI
+	statsAPI    *statsapi.StatsAPI
+	ownerAPI    *ownerapi.OwnerAPI
+	activityAPI *activityapi.ActivityAPI
+	tiles       *tiles.Tiles
+	graphqlAPI  *graphql.GraphQL
+	streamAPI   *stream.Stream
+	webhookAPI  *webhookapi.WebhookAPI
+	openAPI     *openapi.OpenAPI
+	rateLimit   *ratelimit.RateLimit
+	status      *status.Status
 }
 
-func NewAPI() (*API, error) {
-	api := &API{}
+// New creates an API with the given options.
+func New(opts Options) *API {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &API{
+		listenAddress: opts.ListenAddress,
+		log:           opts.Logger,
+		gatewayAPI:    opts.GatewayAPI,
+		routerAPI:     opts.RouterAPI,
+		mapperAPI:     opts.MapperAPI,
+		mappingAPI:    opts.MappingAPI,
+		rewardAPI:     opts.RewardsAPI,
+		statsAPI:      opts.StatsAPI,
+		ownerAPI:      opts.OwnerAPI,
+		activityAPI:   opts.ActivityAPI,
+		tiles:         opts.Tiles,
+		graphqlAPI:    opts.GraphQL,
+		streamAPI:     opts.Stream,
+		webhookAPI:    opts.WebhookAPI,
+		openAPI:       opts.OpenAPI,
+		rateLimit:     opts.RateLimit,
+		status:        opts.Status,
+	}
+}
+
+// NewAPI creates an API from the config with the enabled registry APIs, the
+// status endpoint reports the components run by the given supervisor.
+func NewAPI(sup *supervisor.Supervisor) (*API, error) {
+	opts := Options{
+		ListenAddress: viper.GetString(config.CONFIG_API_HTTP_LISTEN_ADDRESS),
+	}
 
 	if viper.GetBool(config.CONFIG_GATEWAY_API_ENABLED) {
 		gatewayAPI, err := gatewayapi.NewGatewayAPI()
@@ -53,7 +138,7 @@ func NewAPI() (*API, error) {
 			return nil, err
 		}
 
-		api.gatewayAPI = gatewayAPI
+		opts.GatewayAPI = gatewayAPI
 	}
 
 	if viper.GetBool(config.CONFIG_MAPPER_API_ENABLED) {
@@ -62,7 +147,7 @@ func NewAPI() (*API, error) {
 			return nil, err
 		}
 
-		api.mapperAPI = mapperAPI
+		opts.MapperAPI = mapperAPI
 	}
 
 	if viper.GetBool(config.CONFIG_ROUTER_API_ENABLED) {
@@ -71,7 +156,7 @@ func NewAPI() (*API, error) {
 			return nil, err
 		}
 
-		api.routerAPI = routerAPI
+		opts.RouterAPI = routerAPI
 	}
 
 	if viper.GetBool(config.CONFIG_MAPPING_API_ENABLED) {
@@ -80,7 +165,7 @@ func NewAPI() (*API, error) {
 			return nil, err
 		}
 
-		api.mappingAPI = mappingAPI
+		opts.MappingAPI = mappingAPI
 	}
 
 	if viper.GetBool(config.CONFIG_REWARDS_API_ENABLED) {
@@ -89,36 +174,140 @@ func NewAPI() (*API, error) {
 			return nil, err
 		}
 
-		api.rewardAPI = rewardAPI
+		opts.RewardsAPI = rewardAPI
+	}
+
+	if viper.GetBool(config.CONFIG_STATS_API_ENABLED) {
+		statsAPI, err := statsapi.NewStatsAPI()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.StatsAPI = statsAPI
+	}
+
+	if viper.GetBool(config.CONFIG_OWNER_API_ENABLED) {
+		ownerAPI, err := ownerapi.NewOwnerAPI()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.OwnerAPI = ownerAPI
+	}
+
+	if viper.GetBool(config.CONFIG_ACTIVITY_API_ENABLED) {
+		activityAPI, err := activityapi.NewActivityAPI()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.ActivityAPI = activityAPI
 	}
 
-	return api, nil
+	if viper.GetBool(config.CONFIG_TILES_API_ENABLED) {
+		tiles, err := tiles.NewTiles()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Tiles = tiles
+	}
+
+	if viper.GetBool(config.CONFIG_GRAPHQL_API_ENABLED) {
+		graphqlAPI, err := graphql.NewGraphQL()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.GraphQL = graphqlAPI
+	}
+
+	if viper.GetBool(config.CONFIG_EVENTS_API_ENABLED) {
+		streamAPI, err := stream.NewStream()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Stream = streamAPI
+	}
+
+	if viper.GetBool(config.CONFIG_WEBHOOK_ENABLED) {
+		webhookAPI, err := webhookapi.NewWebhookAPI()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.WebhookAPI = webhookAPI
+	}
+
+	openAPI, err := openapi.NewOpenAPI()
+	if err != nil {
+		return nil, err
+	}
+	opts.OpenAPI = openAPI
+
+	if viper.GetBool(config.CONFIG_API_RATELIMIT_ENABLED) {
+		rateLimit, err := ratelimit.NewRateLimit()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.RateLimit = rateLimit
+	}
+
+	statusAPI, err := status.NewStatus(sup)
+	if err != nil {
+		return nil, err
+	}
+	opts.Status = statusAPI
+
+	return New(opts), nil
 }
 
-func (a *API) Serve(ctx context.Context) chan error {
+// routes creates the router with the middleware and the routes of the
+// enabled APIs.
+func (a *API) routes() *chi.Mux {
 	root := chi.NewRouter()
 
+	// the rate limiter needs the address of the peer before the standard
+	// RealIP middleware replaces it with the forwarded address
+	root.Use(ratelimit.PeerAddr)
 	httputils.BindStandardMiddleware(root)
 	root.Use(cache.DisableCacheOnGetRequests)
 
 	root.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "traceparent", "tracestate", ratelimit.APIKeyHeader, webhookapi.TimestampHeader, webhookapi.SignatureHeader},
+		ExposedHeaders:   []string{"ETag", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
 
-	srv := http.Server{
-		Handler:      root,
-		Addr:         viper.GetString(config.CONFIG_API_HTTP_LISTEN_ADDRESS),
-		WriteTimeout: 15 * time.Second,
-		ReadTimeout:  15 * time.Second,
+	// middleware must be registered before the routes, readiness probes are
+	// answered before they are rate limited
+	if a.status != nil {
+		root.Use(a.status.Ready)
+	}
+
+	root.Use(tracing.Middleware)
+	root.Use(metrics.Middleware)
+
+	if a.rateLimit != nil {
+		root.Use(a.rateLimit.Middleware)
+	}
+
+	if a.openAPI != nil {
+		root.Use(a.openAPI.Middleware)
+		a.openAPI.Bind(root)
+	}
+
+	if a.status != nil {
+		a.status.Bind(root)
 	}
 
 	if a.gatewayAPI != nil {
 		a.gatewayAPI.Bind(root)
-		go a.gatewayAPI.Run(ctx)
 	}
 
 	if a.routerAPI != nil {
@@ -137,16 +326,69 @@ func (a *API) Serve(ctx context.Context) chan error {
 		a.rewardAPI.Bind(root)
 	}
 
-	stopped := make(chan error)
+	if a.statsAPI != nil {
+		a.statsAPI.Bind(root)
+	}
+
+	if a.ownerAPI != nil {
+		a.ownerAPI.Bind(root)
+	}
+
+	if a.activityAPI != nil {
+		a.activityAPI.Bind(root)
+	}
+
+	if a.tiles != nil {
+		a.tiles.Bind(root)
+	}
+
+	if a.graphqlAPI != nil {
+		a.graphqlAPI.Bind(root)
+	}
+
+	if a.streamAPI != nil {
+		a.streamAPI.Bind(root)
+	}
+
+	if a.webhookAPI != nil {
+		a.webhookAPI.Bind(root)
+	}
+
+	return root
+}
+
+func (a *API) Serve(ctx context.Context) chan error {
+	srv := http.Server{
+		Handler:      a.routes(),
+		Addr:         a.listenAddress,
+		WriteTimeout: 15 * time.Second,
+		ReadTimeout:  15 * time.Second,
+	}
+
+	if a.streamAPI != nil {
+		go a.streamAPI.Run(ctx)
+	}
+
+	// buffered so neither goroutine blocks when the other already reported
+	stopped := make(chan error, 2)
+	// closed when ListenAndServe returns so the shutdown goroutine doesn't
+	// outlive a crashed server
+	served := make(chan struct{})
 	go func() {
-		logrus.WithField("addr", srv.Addr).Info("start HTTP API service")
+		defer close(served)
+		a.log.WithField("addr", srv.Addr).Info("start HTTP API service")
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logrus.WithError(err).Fatal("HTTP service crashed")
+			a.log.WithError(err).Error("HTTP service crashed")
+			stopped <- err
 		}
 	}()
 
 	go func() {
-		<-ctx.Done()
+		select {
+		case <-ctx.Done():
+		case <-served:
+			return
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 		stopped <- srv.Shutdown(ctx)
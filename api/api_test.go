@@ -0,0 +1,167 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	activityapi "github.com/ThingsIXFoundation/data-aggregator/activity/api"
+	"github.com/ThingsIXFoundation/data-aggregator/api/graphql"
+	"github.com/ThingsIXFoundation/data-aggregator/api/openapi"
+	"github.com/ThingsIXFoundation/data-aggregator/api/status"
+	"github.com/ThingsIXFoundation/data-aggregator/api/stream"
+	"github.com/ThingsIXFoundation/data-aggregator/api/tiles"
+	gatewayapi "github.com/ThingsIXFoundation/data-aggregator/gateway/api"
+	mapperapi "github.com/ThingsIXFoundation/data-aggregator/mapper/api"
+	mappingapi "github.com/ThingsIXFoundation/data-aggregator/mapping/api"
+	ownerapi "github.com/ThingsIXFoundation/data-aggregator/owner/api"
+	rewardapi "github.com/ThingsIXFoundation/data-aggregator/rewards/api"
+	routerapi "github.com/ThingsIXFoundation/data-aggregator/router/api"
+	statsapi "github.com/ThingsIXFoundation/data-aggregator/stats/api"
+	webhookapi "github.com/ThingsIXFoundation/data-aggregator/webhook/api"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	// undocumented are the routes that are deliberately left out of the
+	// OpenAPI document, they describe themselves or aren't JSON endpoints.
+	undocumented = map[string]bool{
+		"GET /openapi.json":     true,
+		"POST /graphql/v1":      true,
+		"GET /events/v1/stream": true,
+		"GET /events/v1/ws":     true,
+	}
+
+	// unrouted are the documented operations that are answered by middleware
+	// instead of a route.
+	unrouted = map[string]bool{
+		"GET /readyz": true,
+	}
+)
+
+// TestRoutesMatchOpenAPI fails when a route is bound without an operation in
+// the OpenAPI document or when an operation has no route.
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	openAPI, err := openapi.New(openapi.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := New(Options{
+		GatewayAPI:  &gatewayapi.GatewayAPI{},
+		RouterAPI:   &routerapi.RouterAPI{},
+		MapperAPI:   &mapperapi.MapperAPI{},
+		MappingAPI:  &mappingapi.MappingAPI{},
+		RewardsAPI:  &rewardapi.RewardsAPI{},
+		StatsAPI:    &statsapi.StatsAPI{},
+		OwnerAPI:    &ownerapi.OwnerAPI{},
+		ActivityAPI: &activityapi.ActivityAPI{},
+		Tiles:       &tiles.Tiles{},
+		GraphQL:     &graphql.GraphQL{},
+		Stream:      &stream.Stream{},
+		WebhookAPI:  &webhookapi.WebhookAPI{},
+		OpenAPI:     openAPI,
+		Status:      &status.Status{},
+	})
+
+	routes := make(map[string]bool)
+	err = chi.Walk(api.routes(), func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes[method+" "+routePath(route)] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openapi.Document()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operations := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			operations[method+" "+path] = true
+		}
+	}
+
+	for route := range routes {
+		if !operations[route] && !undocumented[route] {
+			t.Errorf("route %s has no OpenAPI operation", route)
+		}
+	}
+	for operation := range operations {
+		if !routes[operation] && !unrouted[operation] {
+			t.Errorf("OpenAPI operation %s has no route", operation)
+		}
+	}
+}
+
+func TestRoutePath(t *testing.T) {
+	for _, tc := range []struct {
+		route string
+		want  string
+	}{
+		{"/status", "/status"},
+		{"/webhooks/v1/", "/webhooks/v1"},
+		{"/owners/v1/{owner:(?i)(0x)?[0-9a-f]{40}}", "/owners/v1/{owner}"},
+		{"/coverage/v1/map/{date:^[0-9]{4}-[0-9]{2}-[0-9]{2}$}/{hex:(?i)[0-9a-f]{15}}/assumed", "/coverage/v1/map/{date}/{hex}/assumed"},
+		{"/tiles/{layer}/{z:[0-9]+}/{x:[0-9]+}/{y:[0-9]+}.mvt", "/tiles/{layer}/{z}/{x}/{y}.mvt"},
+	} {
+		t.Run(tc.route, func(t *testing.T) {
+			if got := routePath(tc.route); got != tc.want {
+				t.Errorf("routePath %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// routePath strips the regular expressions from the parameters of a chi route
+// and the trailing slash of sub router roots so it reads as an OpenAPI path.
+func routePath(route string) string {
+	var (
+		path  strings.Builder
+		depth int
+		skip  bool
+	)
+	for _, c := range route {
+		switch {
+		case c == '{':
+			depth++
+			if depth == 1 {
+				path.WriteRune(c)
+			}
+		case c == '}':
+			depth--
+			if depth == 0 {
+				skip = false
+				path.WriteRune(c)
+			}
+		case c == ':' && depth == 1:
+			skip = true
+		case depth == 0 || (depth == 1 && !skip):
+			path.WriteRune(c)
+		}
+	}
+
+	if p := path.String(); len(p) > 1 {
+		return strings.TrimSuffix(p, "/")
+	}
+	return path.String()
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ThingsIXFoundation/types"
+)
+
+// NetworkRewards are the rewards issued to the network on a date.
+type NetworkRewards struct {
+	Date                           time.Time `json:"date"`
+	TotalAssumedCoverageShareUnits *big.Int  `json:"totalAssumedCoverageShareUnits"`
+	TotalMappingUnits              *big.Int  `json:"totalMappingUnits"`
+	TotalRewards                   *big.Int  `json:"totalRewards"`
+}
+
+// Activity is a single entry of a feed, only the field of its type, which is
+// gateway, mapper, router or rewards, is set. Rewards in an owner feed are
+// the rewards of the owner, in the network feed they are the rewards of the
+// network.
+type Activity struct {
+	Type           string                      `json:"type"`
+	Time           time.Time                   `json:"time"`
+	GatewayEvent   *types.GatewayEvent         `json:"gatewayEvent,omitempty"`
+	MapperEvent    *types.MapperEvent          `json:"mapperEvent,omitempty"`
+	RouterEvent    *types.RouterEvent          `json:"routerEvent,omitempty"`
+	AccountRewards *types.AccountRewardHistory `json:"accountRewards,omitempty"`
+	NetworkRewards *NetworkRewards             `json:"networkRewards,omitempty"`
+}
+
+// ActivityResponse is a page of activities, newest first. The cursor is only
+// set when there are more activities to fetch.
+type ActivityResponse struct {
+	Cursor     string      `json:"cursor,omitempty"`
+	Activities []*Activity `json:"activities"`
+}
@@ -14,12 +14,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package api
-
-import "github.com/ThingsIXFoundation/types"
-
-type PendingMapperEventsResponse struct {
-	Confirmations uint64               `json:"confirmations"`
-	SyncedTo      uint64               `json:"syncedTo"`
-	Events        []*types.MapperEvent `json:"events"`
-}
+// Package apitypes holds the request and response bodies of the HTTP API.
+// The API handlers and the client both use these types so they can't drift
+// apart, the package only depends on the ThingsIX types and go-ethereum.
+package apitypes
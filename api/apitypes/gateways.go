@@ -0,0 +1,115 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	"time"
+
+	"github.com/ThingsIXFoundation/types"
+)
+
+// GatewaysResponse is a page of gateways, the cursor is only set when there
+// are more gateways to fetch.
+type GatewaysResponse struct {
+	Cursor   string           `json:"cursor,omitempty"`
+	Gateways []*types.Gateway `json:"gateways"`
+}
+
+// GatewayEventsResponse is a page of gateway events, the cursor is only set
+// when there are more events to fetch.
+type GatewayEventsResponse struct {
+	Cursor string                `json:"cursor,omitempty"`
+	Events []*types.GatewayEvent `json:"events"`
+}
+
+// GatewayOnboard is a signed message of an owner to onboard a gateway.
+type GatewayOnboard struct {
+	GatewayID string    `json:"gatewayId"`
+	Owner     string    `json:"owner"`
+	Signature string    `json:"signature"`
+	Version   int       `json:"version"`
+	LocalID   string    `json:"localId"`
+	Onboarder string    `json:"onboarder"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GatewayOnboardsResponse is a page of gateway onboards, the cursor is only
+// set when there are more onboards to fetch.
+type GatewayOnboardsResponse struct {
+	Cursor   string            `json:"cursor,omitempty"`
+	Onboards []*GatewayOnboard `json:"onboards"`
+}
+
+// NearbyGateway is a gateway with its distance to the searched location.
+type NearbyGateway struct {
+	Gateway *types.Gateway `json:"gateway"`
+	// Distance is the great-circle distance in meters from the searched
+	// location to the centre of the cell the gateway is located in.
+	Distance float64 `json:"distance"`
+}
+
+// NearbyGatewaysResponse are the gateways near a location, nearest first.
+type NearbyGatewaysResponse struct {
+	Gateways []*NearbyGateway `json:"gateways"`
+}
+
+// CreateGatewayOnboardRequest is the message to onboard a gateway.
+type CreateGatewayOnboardRequest struct {
+	GatewayID types.ID `json:"gatewayId"`
+	Signature string   `json:"gatewayOnboardSignature"`
+	Version   uint8    `json:"version"`
+	LocalID   string   `json:"localId"`
+}
+
+// GatewayHexInfo counts the gateways in a cell, the gateways are only
+// included at the detail resolution.
+type GatewayHexInfo struct {
+	Count    int             `json:"count"`
+	Gateways []types.Gateway `json:"gateways,omitempty"`
+}
+
+// GatewayHex contains the gateways per cell, keyed by cell index.
+type GatewayHex struct {
+	Hexes map[string]GatewayHexInfo `json:"hexes,omitempty"`
+}
+
+// Res0GatewayHex contains the gateway hexes per resolution 0 cell.
+type Res0GatewayHex struct {
+	Hexes map[string]GatewayHex `json:"hexes,omitempty"`
+}
+
+// PendingGatewayEventsResponse are the gateway events of an owner that
+// aren't confirmed yet.
+type PendingGatewayEventsResponse struct {
+	Confirmations uint64                `json:"confirmations"`
+	SyncedTo      uint64                `json:"syncedTo"`
+	Events        []*types.GatewayEvent `json:"events"`
+}
+
+// ValidFrequencyPlansForLocation are the frequency plans that are valid at a
+// location.
+type ValidFrequencyPlansForLocation struct {
+	Plans           []string `json:"plans"`
+	BlockchainPlans []uint   `json:"blockchainPlans"`
+}
+
+// GatewaysBatchResponse holds the gateways found in a batch lookup and the ids
+// of the gateways that don't exist.
+type GatewaysBatchResponse struct {
+	Gateways []*types.Gateway `json:"gateways"`
+	Missing  []types.ID       `json:"missing"`
+}
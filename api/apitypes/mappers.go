@@ -0,0 +1,48 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import "github.com/ThingsIXFoundation/types"
+
+// MappersResponse is a page of mappers, the cursor is only set when there
+// are more mappers to fetch.
+type MappersResponse struct {
+	Cursor  string          `json:"cursor,omitempty"`
+	Mappers []*types.Mapper `json:"mappers"`
+}
+
+// MapperEventsResponse is a page of mapper events, the cursor is only set
+// when there are more events to fetch.
+type MapperEventsResponse struct {
+	Cursor string               `json:"cursor,omitempty"`
+	Events []*types.MapperEvent `json:"events"`
+}
+
+// PendingMapperEventsResponse are the mapper events of an owner that aren't
+// confirmed yet.
+type PendingMapperEventsResponse struct {
+	Confirmations uint64               `json:"confirmations"`
+	SyncedTo      uint64               `json:"syncedTo"`
+	Events        []*types.MapperEvent `json:"events"`
+}
+
+// MappersBatchResponse holds the mappers found in a batch lookup and the ids
+// of the mappers that don't exist.
+type MappersBatchResponse struct {
+	Mappers []*types.Mapper `json:"mappers"`
+	Missing []types.ID      `json:"missing"`
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MappingsResponse is a page of mapping records, the cursor is only set when
+// there are more records to fetch.
+type MappingsResponse struct {
+	Cursor   string                 `json:"cursor,omitempty"`
+	Mappings []*types.MappingRecord `json:"mappings"`
+}
+
+type AssumedCoverageHexContainer struct {
+	Hexes []h3light.Cell `json:"hexes,omitempty"`
+}
+
+type CoverageHexContainer struct {
+	Hexes []*types.CoverageHistory `json:"hexes,omitempty"`
+}
+
+type UnverifiedCoverageHexContainer struct {
+	Hexes []*types.UnverifiedMappingRecord `json:"hexes,omitempty"`
+}
+
+type MinMaxCoverageDates struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+}
+
+// ChallengeRequest asks for a challenge for the owner to sign.
+type ChallengeRequest struct {
+	Owner common.Address `json:"owner"`
+}
+
+// ChallengeResponse is the challenge an owner signs to get a mapping code.
+type ChallengeResponse struct {
+	Owner     common.Address `json:"owner"`
+	Challenge string         `json:"challenge"`
+}
+
+// SignatureRequest exchanges a signed challenge for a mapping code.
+type SignatureRequest struct {
+	Owner     common.Address `json:"owner"`
+	Challenge string         `json:"challenge"`
+	Signature string         `json:"signature"`
+}
+
+// SignatureResponse holds the mapping code that grants the owner access to
+// the live mappings of its mappers.
+type SignatureResponse struct {
+	Owner common.Address `json:"owner"`
+	Code  string         `json:"code"`
+}
+
+// CodeCheckRequest checks a mapping code for a mapper.
+type CodeCheckRequest struct {
+	MapperID types.ID `json:"mapperId"`
+	Code     string   `json:"code"`
+}
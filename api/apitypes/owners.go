@@ -0,0 +1,56 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	"math/big"
+
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OwnerGateway is a gateway of an owner together with its latest rewards and
+// the number of hexes it covers.
+type OwnerGateway struct {
+	Gateway       *types.Gateway              `json:"gateway"`
+	LatestRewards *types.GatewayRewardHistory `json:"latestRewards"`
+	CoverageSize  int                         `json:"coverageSize"`
+}
+
+// OwnerTotals summarizes the portfolio of an owner.
+type OwnerTotals struct {
+	Gateways             int      `json:"gateways"`
+	Mappers              int      `json:"mappers"`
+	PendingGatewayEvents int      `json:"pendingGatewayEvents"`
+	PendingMapperEvents  int      `json:"pendingMapperEvents"`
+	GatewayRewards       *big.Int `json:"gatewayRewards"`
+	CoverageSize         int      `json:"coverageSize"`
+}
+
+// OwnerResponse is the portfolio of an owner. Parts that could not be
+// fetched in time are left empty and listed in Unavailable.
+type OwnerResponse struct {
+	Owner                common.Address                `json:"owner"`
+	Gateways             []*OwnerGateway               `json:"gateways"`
+	Mappers              []*types.Mapper               `json:"mappers"`
+	PendingGatewayEvents *PendingGatewayEventsResponse `json:"pendingGatewayEvents"`
+	PendingMapperEvents  *PendingMapperEventsResponse  `json:"pendingMapperEvents"`
+	LatestRewards        *types.AccountRewardHistory   `json:"latestRewards"`
+	Cheque               *RewardCheque                 `json:"cheque"`
+	Totals               OwnerTotals                   `json:"totals"`
+	Unavailable          []string                      `json:"unavailable,omitempty"`
+}
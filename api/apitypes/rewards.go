@@ -0,0 +1,43 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RewardCheque is the latest signed rewards cheque of an account.
+type RewardCheque struct {
+	Beneficiary common.Address `json:"beneficiary"`
+	Processor   common.Address `json:"processor"`
+	TotalAmount hexutil.Bytes  `json:"totalAmount"`
+	Signature   hexutil.Bytes  `json:"signature"`
+}
+
+type AccountRewardsHistoryResponse struct {
+	Rewards []*types.AccountRewardHistory `json:"rewards"`
+}
+
+type GatewayRewardsHistoryResponse struct {
+	Rewards []*types.GatewayRewardHistory `json:"rewards"`
+}
+
+type MapperRewardsHistoryResponse struct {
+	Rewards []*types.MapperRewardHistory `json:"rewards"`
+}
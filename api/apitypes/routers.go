@@ -14,30 +14,21 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package api
+package apitypes
 
 import "github.com/ThingsIXFoundation/types"
 
-type GatewayHexInfo struct {
-	Count    int             `json:"count"`
-	Gateways []types.Gateway `json:"gateways,omitempty"`
+// RouterSnapshotResponse contains all registered routers as known at the
+// synced block.
+type RouterSnapshotResponse struct {
+	BlockNumber uint64          `json:"blockNumber"`
+	ChainID     uint64          `json:"chainId"`
+	Routers     []*types.Router `json:"routers"`
 }
 
-type GatewayHex struct {
-	Hexes map[string]GatewayHexInfo `json:"hexes,omitempty"`
-}
-
-type Res0GatewayHex struct {
-	Hexes map[string]GatewayHex `json:"hexes,omitempty"`
-}
-
-type PendingGatewayEventsResponse struct {
-	Confirmations uint64                `json:"confirmations"`
-	SyncedTo      uint64                `json:"syncedTo"`
-	Events        []*types.GatewayEvent `json:"events"`
-}
-
-type ValidFrequencyPlansForLocation struct {
-	Plans           []string `json:"plans"`
-	BlockchainPlans []uint   `json:"blockchainPlans"`
+// RoutersBatchResponse holds the routers found in a batch lookup and the ids
+// of the routers that don't exist.
+type RoutersBatchResponse struct {
+	Routers []*types.Router `json:"routers"`
+	Missing []types.ID      `json:"missing"`
 }
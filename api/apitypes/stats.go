@@ -0,0 +1,55 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import "time"
+
+// NetworkStats are the network KPIs of a period that starts at Date. The
+// counts of events are totals over the period, the other counts are taken at
+// the end of the period.
+type NetworkStats struct {
+	Date time.Time `json:"date"`
+
+	GatewaysOnboarded  int `json:"gatewaysOnboarded"`
+	GatewaysOffboarded int `json:"gatewaysOffboarded"`
+	// GatewaysMoved counts the location changes of gateways that already had
+	// a location.
+	GatewaysMoved int `json:"gatewaysMoved"`
+	Gateways      int `json:"gateways"`
+	// ActiveGateways are the gateways with a location and a frequency plan,
+	// they are broken down by frequency plan and by the resolution 0 cell
+	// they are located in.
+	ActiveGateways          int            `json:"activeGateways"`
+	GatewaysByFrequencyPlan map[string]int `json:"gatewaysByFrequencyPlan"`
+	GatewaysByRegion        map[string]int `json:"gatewaysByRegion"`
+
+	MappersRegistered int `json:"mappersRegistered"`
+	MappersRemoved    int `json:"mappersRemoved"`
+	Mappers           int `json:"mappers"`
+	ActiveMappers     int `json:"activeMappers"`
+
+	RoutersRegistered int `json:"routersRegistered"`
+	RoutersRemoved    int `json:"routersRemoved"`
+	Routers           int `json:"routers"`
+}
+
+// NetworkStatsResponse is a time series of network stats with periods of
+// day, week or month.
+type NetworkStatsResponse struct {
+	Granularity string          `json:"granularity"`
+	Stats       []*NetworkStats `json:"stats"`
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package apitypes
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ReadinessResponse struct {
+	Ready bool `json:"ready"`
+	// Checks holds "ok" or the error per check.
+	Checks map[string]string `json:"checks"`
+}
+
+type StatusResponse struct {
+	ChainID uint64 `json:"chainId"`
+	// Head is nil when the RPC node isn't configured or unreachable.
+	Head *ChainHead `json:"head,omitempty"`
+	// Error is set when the chain head could not be determined.
+	Error      string                     `json:"error,omitempty"`
+	Registries map[string]*RegistryStatus `json:"registries"`
+	// Components are the components supervised by the API process.
+	Components []*ComponentStatus `json:"components,omitempty"`
+}
+
+// ChainHead is the latest block of the chain.
+type ChainHead struct {
+	Block uint64    `json:"block"`
+	Time  time.Time `json:"time"`
+}
+
+type RegistryStatus struct {
+	Contract      common.Address `json:"contract"`
+	Confirmations uint64         `json:"confirmations"`
+	Ingestor      *ProcessStatus `json:"ingestor"`
+	Aggregator    *ProcessStatus `json:"aggregator"`
+	// Cacher is only set for registries that are cached in Redis.
+	Cacher *CacherStatus `json:"cacher,omitempty"`
+}
+
+type ProcessStatus struct {
+	Block      uint64     `json:"block"`
+	BlockTime  *time.Time `json:"blockTime,omitempty"`
+	LagBlocks  *uint64    `json:"lagBlocks,omitempty"`
+	LagSeconds *float64   `json:"lagSeconds,omitempty"`
+	LastPoll   *time.Time `json:"lastPoll,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+type CacherStatus struct {
+	LastRefresh *time.Time `json:"lastRefresh,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// ComponentStatus is the state of a supervised component.
+type ComponentStatus struct {
+	Name      string    `json:"name"`
+	Registry  string    `json:"registry,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	State     string    `json:"state"`
+	Restarts  int       `json:"restarts"`
+	LastError string    `json:"lastError,omitempty"`
+	Since     time.Time `json:"since"`
+}
@@ -0,0 +1,319 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"text/scanner"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace/tracer"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+const (
+	// unboundedListSize is the assumed length of list fields that aren't
+	// paged, e.g. the list of all routers.
+	unboundedListSize = 100
+	// maxEstimatedCost caps the estimate to prevent overflows.
+	maxEstimatedCost = math.MaxInt32
+)
+
+// estimateCost estimates the number of fields a query resolves before it is
+// executed. Every field counts once, multiplied by the number of times its
+// parent is expected to be resolved. Paged fields multiply their children by
+// the page size they resolve with, see pageArgs.limit, and lists that aren't
+// paged by unboundedListSize.
+//
+// An error is returned when the cost can't be estimated, e.g. because the
+// query can't be parsed, refers to unknown fields or fragments or doesn't
+// select a single operation.
+func estimateCost(schema *types.Schema, query, operationName string, variables map[string]interface{}) (int, error) {
+	doc, err := parseQuery(query)
+	if err != nil {
+		return 0, err
+	}
+
+	op, err := selectOperation(doc, operationName)
+	if err != nil {
+		return 0, err
+	}
+
+	root, ok := schema.EntryPoints[string(op.Type)].(*types.ObjectTypeDefinition)
+	if !ok {
+		return 0, fmt.Errorf("unsupported operation type %q", op.Type)
+	}
+
+	e := &costEstimator{
+		schema:    schema,
+		fragments: doc.Fragments,
+		variables: make(map[string]interface{}, len(variables)),
+		visiting:  make(map[string]bool),
+	}
+	for name, value := range variables {
+		e.variables[name] = value
+	}
+	// like the schema, fill in the defaults for variables that aren't given
+	for _, v := range op.Vars {
+		if _, ok := e.variables[v.Name.Name]; !ok && v.Default != nil {
+			e.variables[v.Name.Name] = v.Default
+		}
+	}
+
+	return e.selectionsCost(op.Selections, root, 1, false)
+}
+
+// selectOperation returns the operation the schema executes for the given
+// operation name.
+func selectOperation(doc *types.ExecutableDefinition, operationName string) (*types.OperationDefinition, error) {
+	if operationName == "" {
+		switch len(doc.Operations) {
+		case 0:
+			return nil, fmt.Errorf("no operations in query document")
+		case 1:
+			return doc.Operations[0], nil
+		default:
+			return nil, fmt.Errorf("more than one operation in query document and no operation name given")
+		}
+	}
+
+	op := doc.Operations.Get(operationName)
+	if op == nil {
+		return nil, fmt.Errorf("unknown operation %q", operationName)
+	}
+	return op, nil
+}
+
+type costEstimator struct {
+	schema    *types.Schema
+	fragments types.FragmentList
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+// selectionsCost returns the cost of resolving selections on an object of
+// type parent multiplier times. Paged is true when parent is a page, its list
+// is already accounted for by the page size.
+func (e *costEstimator) selectionsCost(selections types.SelectionSet, parent *types.ObjectTypeDefinition, multiplier int, paged bool) (int, error) {
+	cost := 0
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *types.Field:
+			if strings.HasPrefix(sel.Name.Name, "__") {
+				continue
+			}
+
+			def := parent.Fields.Get(sel.Name.Name)
+			if def == nil {
+				return 0, fmt.Errorf("unknown field %q on %s", sel.Name.Name, parent.Name)
+			}
+
+			typ, list := unwrapType(def.Type)
+			obj, ok := typ.(*types.ObjectTypeDefinition)
+			if !ok {
+				cost = saturatingAdd(cost, multiplier)
+				continue
+			}
+
+			childMultiplier, childPaged := multiplier, false
+			if def.Arguments.Get("first") != nil {
+				first, _ := sel.Arguments.Get("first")
+				childMultiplier = saturatingMul(multiplier, e.pageSize(first))
+				childPaged = true
+			} else if list && !paged {
+				childMultiplier = saturatingMul(multiplier, unboundedListSize)
+			}
+
+			c, err := e.selectionsCost(sel.SelectionSet, obj, childMultiplier, childPaged)
+			if err != nil {
+				return 0, err
+			}
+			cost = saturatingAdd(cost, saturatingAdd(multiplier, c))
+
+		case *types.FragmentSpread:
+			frag := e.fragments.Get(sel.Name.Name)
+			if frag == nil {
+				return 0, fmt.Errorf("unknown fragment %q", sel.Name.Name)
+			}
+			if e.visiting[sel.Name.Name] {
+				return 0, fmt.Errorf("fragment %q spreads itself", sel.Name.Name)
+			}
+
+			obj, ok := e.schema.Types[frag.On.Name].(*types.ObjectTypeDefinition)
+			if !ok {
+				return 0, fmt.Errorf("unknown type %q", frag.On.Name)
+			}
+
+			e.visiting[sel.Name.Name] = true
+			c, err := e.selectionsCost(frag.Selections, obj, multiplier, paged)
+			delete(e.visiting, sel.Name.Name)
+			if err != nil {
+				return 0, err
+			}
+			cost = saturatingAdd(cost, c)
+
+		case *types.InlineFragment:
+			obj := parent
+			if sel.On.Name != "" {
+				if obj, _ = e.schema.Types[sel.On.Name].(*types.ObjectTypeDefinition); obj == nil {
+					return 0, fmt.Errorf("unknown type %q", sel.On.Name)
+				}
+			}
+
+			c, err := e.selectionsCost(sel.Selections, obj, multiplier, paged)
+			if err != nil {
+				return 0, err
+			}
+			cost = saturatingAdd(cost, c)
+		}
+	}
+
+	return cost, nil
+}
+
+// pageSize returns the page size a paged field resolves with the given first
+// argument.
+func (e *costEstimator) pageSize(first types.Value) int {
+	value := interface{}(first)
+	if v, ok := first.(*types.Variable); ok {
+		value = e.variables[v.Name]
+	}
+
+	var n int64
+	switch v := value.(type) {
+	case *types.PrimitiveValue:
+		if v.Type == scanner.Int {
+			var err error
+			if n, err = strconv.ParseInt(v.Text, 10, 64); err != nil {
+				n = math.MaxInt64
+			}
+		}
+	case float64:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case int:
+		n = int64(v)
+	}
+
+	var args pageArgs
+	if n > math.MaxInt32 {
+		n = math.MaxInt32
+	}
+	if n > 0 {
+		first := int32(n)
+		args.First = &first
+	}
+	return args.limit()
+}
+
+// unwrapType returns the named type of typ and if it is a list.
+func unwrapType(typ types.Type) (types.Type, bool) {
+	list := false
+	for {
+		switch t := typ.(type) {
+		case *types.NonNull:
+			typ = t.OfType
+		case *types.List:
+			typ, list = t.OfType, true
+		default:
+			return typ, list
+		}
+	}
+}
+
+func saturatingAdd(a, b int) int {
+	if a > maxEstimatedCost-b {
+		return maxEstimatedCost
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if b != 0 && a > maxEstimatedCost/b {
+		return maxEstimatedCost
+	}
+	return a * b
+}
+
+type costBudgetKey struct{}
+
+// costBudget is the number of fields a query may still resolve. The fields
+// are charged while graphql-go executes the query, it backs the estimate up
+// for lists that are longer than the estimate assumes.
+type costBudget struct {
+	max       int64
+	remaining int64
+}
+
+// withCostBudget returns a context in which a query resolves at most max
+// fields.
+func withCostBudget(ctx context.Context, max int) context.Context {
+	return context.WithValue(ctx, costBudgetKey{}, &costBudget{max: int64(max), remaining: int64(max)})
+}
+
+// costTracer charges every field graphql-go resolves to the cost budget of
+// the query. Once the budget is spent the fields get a context that is done,
+// graphql-go doesn't run their resolvers and reports them as failed. Queries
+// are rejected on their estimate first, so this only stops queries that
+// resolve more than estimated.
+type costTracer struct{}
+
+var _ tracer.Tracer = costTracer{}
+
+func (costTracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, tracer.QueryFinishFunc) {
+	return ctx, func([]*errors.QueryError) {}
+}
+
+func (costTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, tracer.FieldFinishFunc) {
+	finish := func(*errors.QueryError) {}
+
+	budget, ok := ctx.Value(costBudgetKey{}).(*costBudget)
+	if !ok || atomic.AddInt64(&budget.remaining, -1) >= 0 {
+		return ctx, finish
+	}
+	return exceededContext{ctx, fmt.Errorf("query cost exceeds the maximum of %d fields", budget.max)}, finish
+}
+
+// closed is the done channel of an exceededContext.
+var closed = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// exceededContext is done with the error that the cost budget is spent.
+type exceededContext struct {
+	context.Context
+	err error
+}
+
+func (exceededContext) Done() <-chan struct{} {
+	return closed
+}
+
+func (c exceededContext) Err() error {
+	return c.err
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+func TestEstimateCost(t *testing.T) {
+	g, err := New(Options{MaxDepth: 10, MaxCost: 1000, MaxQueryLength: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]interface{}
+		cost      int
+	}{
+		{
+			// owner + page + 2 * (gateway + id + owner) + 2 * cursor
+			name:  "paged",
+			query: `{ owner(address: "0x1") { gateways(first: 2) { gateways { id owner } cursor } } }`,
+			cost:  10,
+		},
+		{
+			name:  "default page size",
+			query: `{ owner(address: "0x1") { gateways { gateways { id } } } }`,
+			cost:  2 + 2*defaultPageSize,
+		},
+		{
+			name:  "page size bounded",
+			query: `{ owner(address: "0x1") { gateways(first: 100000) { gateways { id } } } }`,
+			cost:  2 + 2*maxPageSize,
+		},
+		{
+			name:      "page size from variable",
+			query:     `query($n: Int) { owner(address: "0x1") { gateways(first: $n) { gateways { id } } } }`,
+			variables: map[string]interface{}{"n": float64(3)},
+			cost:      2 + 2*3,
+		},
+		{
+			name:  "page size from variable default",
+			query: `query($n: Int = 4) { owner(address: "0x1") { gateways(first: $n) { gateways { id } } } }`,
+			cost:  2 + 2*4,
+		},
+		{
+			name:  "nested pages multiply",
+			query: `{ owner(address: "0x1") { gateways(first: 10) { gateways { events(first: 10) { events { type } } } } } }`,
+			cost:  2 + 10 + 10*(1+10+10),
+		},
+		{
+			name:  "unpaged list",
+			query: `{ routers { id } }`,
+			cost:  1 + unboundedListSize,
+		},
+		{
+			name:  "fragments",
+			query: `{ owner(address: "0x1") { ...gateways } } fragment gateways on Owner { gateways(first: 2) { gateways { ... on Gateway { id } } } }`,
+			cost:  2 + 2*2,
+		},
+		{
+			name:      "selected operation",
+			query:     `query a { routers { id } } query b { latestRewardsDate }`,
+			operation: "b",
+			cost:      1,
+		},
+		{
+			name:  "introspection is free",
+			query: `{ __typename latestRewardsDate }`,
+			cost:  1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cost, err := estimateCost(g.schema.ASTSchema(), tc.query, tc.operation, tc.variables)
+			if err != nil {
+				t.Fatalf("unable to estimate cost: %v", err)
+			}
+			if cost != tc.cost {
+				t.Errorf("cost %d, want %d", cost, tc.cost)
+			}
+		})
+	}
+}
+
+func TestEstimateCostErrors(t *testing.T) {
+	g, err := New(Options{MaxDepth: 10, MaxCost: 1000, MaxQueryLength: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		query     string
+		operation string
+	}{
+		{"syntax error", `{ routers { id }`, ""},
+		{"unknown field", `{ routers { unknown } }`, ""},
+		{"unknown fragment", `{ ...missing }`, ""},
+		{"recursive fragment", `{ owner(address: "0x1") { ...a } } fragment a on Owner { ...a }`, ""},
+		{"ambiguous operation", `query a { routers { id } } query b { routers { id } }`, ""},
+		{"unknown operation", `query a { routers { id } }`, "b"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := estimateCost(g.schema.ASTSchema(), tc.query, tc.operation, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestQueryRejectsExpensiveQueries(t *testing.T) {
+	// the stores are nil, executing the query would panic
+	g, err := New(Options{MaxDepth: 10, MaxCost: 1000, MaxQueryLength: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := `{ owner(address: "0x1") { gateways(first: 100) { gateways { id events(first: 100) { events { type } } rewards(first: 100) { rewards { date } } } } } }`
+	body := strings.NewReader(`{"query": ` + strconv.Quote(query) + `}`)
+
+	w := httptest.NewRecorder()
+	g.Query(w, httptest.NewRequest(http.MethodPost, "/graphql/v1/", body))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), "exceeds the maximum of 1000") {
+		t.Errorf("body %s, want the cost error", w.Body.String())
+	}
+}
+
+type budgetItem struct{}
+
+func (*budgetItem) ID() graphqlgo.ID { return "id" }
+
+type budgetQuery struct {
+	n int
+}
+
+func (q *budgetQuery) Items() []*budgetItem {
+	items := make([]*budgetItem, q.n)
+	for i := range items {
+		items[i] = &budgetItem{}
+	}
+	return items
+}
+
+func TestCostTracerStopsQueriesBeyondBudget(t *testing.T) {
+	schema := graphqlgo.MustParseSchema(`
+		schema { query: Query }
+		type Query { items: [Item!]! }
+		type Item { id: ID! }`,
+		&budgetQuery{n: 10}, graphqlgo.Tracer(costTracer{}))
+
+	for _, tc := range []struct {
+		name   string
+		budget int
+		errors bool
+	}{
+		{"within budget", 11, false},
+		{"beyond budget", 5, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := schema.Exec(withCostBudget(context.Background(), tc.budget), `{ items { id } }`, "", nil)
+			if got := len(resp.Errors) > 0; got != tc.errors {
+				t.Errorf("errors %v, want errors %v", resp.Errors, tc.errors)
+			}
+		})
+	}
+}
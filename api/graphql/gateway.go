@@ -0,0 +1,191 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/types"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type gatewayResolver struct {
+	g  *GraphQL
+	gw *types.Gateway
+}
+
+func loadGateway(ctx context.Context, g *GraphQL, id types.ID) (*gatewayResolver, error) {
+	gw, err := loadersFrom(ctx).gateways.Load(ctx, id)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting gateway details")
+	}
+	if gw == nil {
+		return nil, nil
+	}
+	return &gatewayResolver{g: g, gw: gw}, nil
+}
+
+func gatewayResolvers(g *GraphQL, gateways []*types.Gateway) []*gatewayResolver {
+	resolvers := make([]*gatewayResolver, len(gateways))
+	for i, gw := range gateways {
+		resolvers[i] = &gatewayResolver{g: g, gw: gw}
+	}
+	return resolvers
+}
+
+func (r *gatewayResolver) ID() graphqlgo.ID       { return toID(r.gw.ID) }
+func (r *gatewayResolver) Contract() string       { return r.gw.ContractAddress.String() }
+func (r *gatewayResolver) Version() int32         { return int32(r.gw.Version) }
+func (r *gatewayResolver) Owner() string          { return r.gw.Owner.String() }
+func (r *gatewayResolver) AntennaGain() *float64  { return float32PtrToFloat64Ptr(r.gw.AntennaGain) }
+func (r *gatewayResolver) FrequencyPlan() *string { return bandPtrToStringPtr(r.gw.FrequencyPlan) }
+func (r *gatewayResolver) Location() *string      { return cellPtrToStringPtr(r.gw.Location) }
+func (r *gatewayResolver) Altitude() *int32       { return uintPtrToInt32Ptr(r.gw.Altitude) }
+
+func (r *gatewayResolver) Events(ctx context.Context, args pageArgs) (*gatewayEventPageResolver, error) {
+	p, err := loadersFrom(ctx).gatewayEvents.Load(ctx, pageKey{id: r.gw.ID, limit: args.limit(), cursor: args.cursor()})
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve gateway events from DB")
+	}
+	return &gatewayEventPageResolver{g: r.g, page: p}, nil
+}
+
+func (r *gatewayResolver) HistoryAt(ctx context.Context, args struct{ Time graphqlgo.Time }) (*gatewayHistoryResolver, error) {
+	history, err := loadersFrom(ctx).gatewayHistory.Load(ctx, idAt{id: r.gw.ID, at: args.Time.Time})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting gateway history")
+	}
+	if history == nil {
+		return nil, nil
+	}
+	return &gatewayHistoryResolver{h: history}, nil
+}
+
+func (r *gatewayResolver) Coverage(ctx context.Context, args struct{ Date graphqlgo.Time }) ([]*coverageResolver, error) {
+	if err := r.g.checkRewardsDate(ctx, args.Date.Time); err != nil {
+		return nil, err
+	}
+	coverage, err := loadersFrom(ctx).gatewayCoverage.Load(ctx, idAt{id: r.gw.ID, at: args.Date.Time})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting gateway coverage")
+	}
+	return coverageResolvers(r.g, coverage), nil
+}
+
+func (r *gatewayResolver) AssumedCoverage(ctx context.Context, args struct{ Date graphqlgo.Time }) ([]string, error) {
+	if err := r.g.checkRewardsDate(ctx, args.Date.Time); err != nil {
+		return nil, err
+	}
+	locations, err := loadersFrom(ctx).gatewayAssumedCoverage.Load(ctx, idAt{id: r.gw.ID, at: args.Date.Time})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting gateway coverage")
+	}
+	return locations, nil
+}
+
+func (r *gatewayResolver) Rewards(ctx context.Context, args pageArgs) (*gatewayRewardPageResolver, error) {
+	p, err := loadersFrom(ctx).gatewayRewards.Load(ctx, pageKey{id: r.gw.ID, limit: args.limit(), cursor: args.cursor()})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting gateway rewards")
+	}
+	return &gatewayRewardPageResolver{g: r.g, page: p}, nil
+}
+
+type gatewayEventResolver struct {
+	g  *GraphQL
+	ev *types.GatewayEvent
+}
+
+func (r *gatewayEventResolver) Contract() string        { return r.ev.ContractAddress.String() }
+func (r *gatewayEventResolver) Block() string           { return r.ev.Block.String() }
+func (r *gatewayEventResolver) BlockNumber() Uint64     { return Uint64(r.ev.BlockNumber) }
+func (r *gatewayEventResolver) Transaction() string     { return r.ev.Transaction.String() }
+func (r *gatewayEventResolver) TransactionIndex() int32 { return int32(r.ev.TransactionIndex) }
+func (r *gatewayEventResolver) LogIndex() int32         { return int32(r.ev.LogIndex) }
+func (r *gatewayEventResolver) Type() string            { return string(r.ev.Type) }
+func (r *gatewayEventResolver) GatewayID() graphqlgo.ID { return toID(r.ev.ID) }
+func (r *gatewayEventResolver) Version() int32          { return int32(r.ev.Version) }
+func (r *gatewayEventResolver) NewOwner() *string       { return addressPtrToStringPtr(r.ev.NewOwner) }
+func (r *gatewayEventResolver) OldOwner() *string       { return addressPtrToStringPtr(r.ev.OldOwner) }
+func (r *gatewayEventResolver) NewAntennaGain() *float64 {
+	return float32PtrToFloat64Ptr(r.ev.NewAntennaGain)
+}
+func (r *gatewayEventResolver) OldAntennaGain() *float64 {
+	return float32PtrToFloat64Ptr(r.ev.OldAntennaGain)
+}
+func (r *gatewayEventResolver) NewFrequencyPlan() *string {
+	return bandPtrToStringPtr(r.ev.NewFrequencyPlan)
+}
+func (r *gatewayEventResolver) OldFrequencyPlan() *string {
+	return bandPtrToStringPtr(r.ev.OldFrequencyPlan)
+}
+func (r *gatewayEventResolver) NewLocation() *string { return cellPtrToStringPtr(r.ev.NewLocation) }
+func (r *gatewayEventResolver) OldLocation() *string { return cellPtrToStringPtr(r.ev.OldLocation) }
+func (r *gatewayEventResolver) NewAltitude() *int32  { return uintPtrToInt32Ptr(r.ev.NewAltitude) }
+func (r *gatewayEventResolver) OldAltitude() *int32  { return uintPtrToInt32Ptr(r.ev.OldAltitude) }
+func (r *gatewayEventResolver) Time() graphqlgo.Time { return toTime(r.ev.Time) }
+
+func (r *gatewayEventResolver) Gateway(ctx context.Context) (*gatewayResolver, error) {
+	return loadGateway(ctx, r.g, r.ev.ID)
+}
+
+type gatewayHistoryResolver struct {
+	h *types.GatewayHistory
+}
+
+func (r *gatewayHistoryResolver) ID() graphqlgo.ID { return toID(r.h.ID) }
+func (r *gatewayHistoryResolver) Contract() string { return r.h.ContractAddress.String() }
+func (r *gatewayHistoryResolver) Version() int32   { return int32(r.h.Version) }
+func (r *gatewayHistoryResolver) Owner() *string   { return addressPtrToStringPtr(r.h.Owner) }
+func (r *gatewayHistoryResolver) AntennaGain() *float64 {
+	return float32PtrToFloat64Ptr(r.h.AntennaGain)
+}
+func (r *gatewayHistoryResolver) FrequencyPlan() *string {
+	return bandPtrToStringPtr(r.h.FrequencyPlan)
+}
+func (r *gatewayHistoryResolver) Location() *string    { return cellPtrToStringPtr(r.h.Location) }
+func (r *gatewayHistoryResolver) Altitude() *int32     { return uintPtrToInt32Ptr(r.h.Altitude) }
+func (r *gatewayHistoryResolver) Time() graphqlgo.Time { return toTime(r.h.Time) }
+func (r *gatewayHistoryResolver) BlockNumber() Uint64  { return Uint64(r.h.BlockNumber) }
+func (r *gatewayHistoryResolver) Block() string        { return r.h.Block.String() }
+func (r *gatewayHistoryResolver) Transaction() string  { return r.h.Transaction.String() }
+
+type gatewayPageResolver struct {
+	g    *GraphQL
+	page page[*types.Gateway]
+}
+
+func (r *gatewayPageResolver) Gateways() []*gatewayResolver {
+	return gatewayResolvers(r.g, r.page.items)
+}
+
+func (r *gatewayPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type gatewayEventPageResolver struct {
+	g    *GraphQL
+	page page[*types.GatewayEvent]
+}
+
+func (r *gatewayEventPageResolver) Events() []*gatewayEventResolver {
+	resolvers := make([]*gatewayEventResolver, len(r.page.items))
+	for i, ev := range r.page.items {
+		resolvers[i] = &gatewayEventResolver{g: r.g, ev: ev}
+	}
+	return resolvers
+}
+
+func (r *gatewayEventPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
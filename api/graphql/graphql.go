@@ -0,0 +1,219 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	mappingStore "github.com/ThingsIXFoundation/data-aggregator/mapping/store"
+	rewardStore "github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/go-chi/chi/v5"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/spf13/viper"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// defaultPageSize is the number of items returned by paged fields when
+	// first isn't given.
+	defaultPageSize = 15
+	// maxPageSize is the maximum number of items returned by paged fields.
+	maxPageSize = 100
+)
+
+// Options configure a GraphQL API.
+type Options struct {
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	MappingStore mappingStore.Store
+	RewardStore  rewardStore.Store
+
+	// MaxDepth is the maximum nesting depth of a query.
+	MaxDepth int
+	// MaxCost is the maximum estimated number of fields a query resolves,
+	// see estimateCost.
+	MaxCost int
+	// MaxQueryLength is the maximum length of a query in bytes.
+	MaxQueryLength int
+}
+
+// GraphQL serves a GraphQL API over the gateway, mapper, router, mapping and
+// rewards stores.
+type GraphQL struct {
+	gatewayStore gatewayStore.Store
+	mapperStore  mapperStore.Store
+	routerStore  routerStore.Store
+	mappingStore mappingStore.Store
+	rewardStore  rewardStore.Store
+
+	schema         *graphqlgo.Schema
+	maxCost        int
+	maxQueryLength int
+}
+
+// New creates a GraphQL API with the given options.
+func New(opts Options) (*GraphQL, error) {
+	g := &GraphQL{
+		gatewayStore:   opts.GatewayStore,
+		mapperStore:    opts.MapperStore,
+		routerStore:    opts.RouterStore,
+		mappingStore:   opts.MappingStore,
+		rewardStore:    opts.RewardStore,
+		maxCost:        opts.MaxCost,
+		maxQueryLength: opts.MaxQueryLength,
+	}
+
+	var err error
+	g.schema, err = graphqlgo.ParseSchema(schema, &resolver{g: g},
+		graphqlgo.MaxDepth(opts.MaxDepth),
+		graphqlgo.UseStringDescriptions(),
+		graphqlgo.Tracer(costTracer{}),
+		graphqlgo.Logger(panicLogger{}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse GraphQL schema: %w", err)
+	}
+
+	return g, nil
+}
+
+// NewGraphQL creates a GraphQL API from the config.
+func NewGraphQL() (*GraphQL, error) {
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mappingStore, err := mappingStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	rewardStore, err := rewardStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		GatewayStore:   gatewayStore,
+		MapperStore:    mapperStore,
+		RouterStore:    routerStore,
+		MappingStore:   mappingStore,
+		RewardStore:    rewardStore,
+		MaxDepth:       viper.GetInt(config.CONFIG_GRAPHQL_API_MAX_DEPTH),
+		MaxCost:        viper.GetInt(config.CONFIG_GRAPHQL_API_MAX_COST),
+		MaxQueryLength: viper.GetInt(config.CONFIG_GRAPHQL_API_MAX_QUERY_LENGTH),
+	})
+}
+
+func (g *GraphQL) Bind(root *chi.Mux) error {
+	root.Route("/graphql", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Post("/", g.Query)
+		})
+	})
+
+	return nil
+}
+
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query executes a GraphQL query. Queries that are too long or too expensive
+// are rejected before they are executed, the fields a query resolves beyond
+// its cost budget fail.
+func (g *GraphQL) Query(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		req         queryRequest
+	)
+	defer cancel()
+
+	if err := encoding.DecodeHTTPJSONBody(w, r, &req); err != nil {
+		log.WithError(err).Error("unable to decode GraphQL request")
+		http.Error(w, err.Msg, err.Status)
+		return
+	}
+
+	if len(req.Query) > g.maxQueryLength {
+		replyErrors(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("query is longer than %d bytes", g.maxQueryLength))
+		return
+	}
+
+	cost, err := estimateCost(g.schema.ASTSchema(), req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		// the schema reports invalid queries with better messages, a valid
+		// query must not run without a cost estimate
+		if errs := g.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+			encoding.ReplyJSON(w, r, http.StatusOK, &graphqlgo.Response{Errors: errs})
+			return
+		}
+		log.WithError(err).Warn("unable to estimate GraphQL query cost")
+		replyErrors(w, r, http.StatusBadRequest, fmt.Sprintf("unable to estimate query cost: %s", err))
+		return
+	}
+	if cost > g.maxCost {
+		replyErrors(w, r, http.StatusBadRequest, fmt.Sprintf("query cost %d exceeds the maximum of %d", cost, g.maxCost))
+		return
+	}
+
+	ctx = withCostBudget(withLoaders(ctx, g), g.maxCost)
+	resp := g.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	encoding.ReplyJSON(w, r, http.StatusOK, resp)
+}
+
+func replyErrors(w http.ResponseWriter, r *http.Request, status int, message string) {
+	encoding.ReplyJSON(w, r, status, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
+
+// panicLogger logs panics in resolvers with the request logger.
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value interface{}) {
+	logging.WithContext(ctx).WithField("panic", value).Error("GraphQL resolver panicked")
+}
+
+// internalError logs err and returns an error that doesn't leak details to
+// the client.
+func internalError(ctx context.Context, err error, msg string) error {
+	logging.WithContext(ctx).WithError(err).Error(msg)
+	return fmt.Errorf("internal error")
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait is the time a loader collects keys before it fetches them.
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch is the maximum number of keys fetched in one batch.
+	loaderMaxBatch = 100
+	// loaderParallelism is the maximum number of concurrent store lookups of
+	// a batch that is fetched key by key.
+	loaderParallelism = 10
+)
+
+// fetchFunc fetches the values for the given keys, the returned values and
+// errors are in the same order as the keys.
+type fetchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+type loaderResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// loader batches and caches the lookups of a single request. Keys that are
+// loaded while resolving the same level of a query end up in the same batch,
+// and every key is fetched at most once. This prevents a lookup for every
+// item of a list.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch fetchFunc[K, V]
+
+	mu      sync.Mutex
+	results map[K]*loaderResult[V]
+	pending []K
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch fetchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		results: make(map[K]*loaderResult[V]),
+	}
+}
+
+// Load returns the value for key, it waits until the batch the key is part
+// of is fetched.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.results[key]
+	if !ok {
+		res = &loaderResult[V]{done: make(chan struct{})}
+		l.results[key] = res
+		l.pending = append(l.pending, key)
+		if len(l.pending) == 1 {
+			time.AfterFunc(loaderWait, l.dispatch)
+		} else if len(l.pending) >= loaderMaxBatch {
+			l.dispatchLocked()
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) dispatch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dispatchLocked()
+}
+
+// dispatchLocked fetches the pending keys, l.mu must be held.
+func (l *loader[K, V]) dispatchLocked() {
+	if len(l.pending) == 0 {
+		return
+	}
+
+	keys := l.pending
+	results := make([]*loaderResult[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.results[key]
+	}
+	l.pending = nil
+
+	go func() {
+		values, errs := l.fetch(l.ctx, keys)
+		for i, res := range results {
+			res.value, res.err = values[i], errs[i]
+			close(res.done)
+		}
+	}()
+}
+
+// fetchMulti returns a fetchFunc that looks up all keys of a batch at once
+// with getMulti, which returns the values in the same order as the keys.
+func fetchMulti[K comparable, V any](getMulti func(ctx context.Context, keys []K) ([]V, error)) fetchFunc[K, V] {
+	return func(ctx context.Context, keys []K) ([]V, []error) {
+		errs := make([]error, len(keys))
+		values, err := getMulti(ctx, keys)
+		if err != nil {
+			for i := range errs {
+				errs[i] = err
+			}
+			return make([]V, len(keys)), errs
+		}
+		return values, errs
+	}
+}
+
+// fetchEach returns a fetchFunc that looks up the keys of a batch
+// concurrently with get, for stores that can't fetch multiple keys at once.
+func fetchEach[K comparable, V any](get func(ctx context.Context, key K) (V, error)) fetchFunc[K, V] {
+	return func(ctx context.Context, keys []K) ([]V, []error) {
+		var (
+			values = make([]V, len(keys))
+			errs   = make([]error, len(keys))
+			sem    = make(chan struct{}, loaderParallelism)
+			wg     sync.WaitGroup
+		)
+
+		for i, key := range keys {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, key K) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				values[i], errs[i] = get(ctx, key)
+			}(i, key)
+		}
+		wg.Wait()
+
+		return values, errs
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThingsIXFoundation/types"
+)
+
+// idAt identifies the state of an entity at a point in time.
+type idAt struct {
+	id types.ID
+	at time.Time
+}
+
+// pageKey identifies a page of items that belong to an entity.
+type pageKey struct {
+	id     types.ID
+	limit  int
+	cursor string
+}
+
+// page is a page of items and the cursor to the next page.
+type page[T any] struct {
+	items  []T
+	cursor string
+}
+
+// loaders are the per-request loaders, they are attached to the request
+// context so that all resolvers of a query share them.
+type loaders struct {
+	gateways *loader[types.ID, *types.Gateway]
+	mappers  *loader[types.ID, *types.Mapper]
+	routers  *loader[types.ID, *types.Router]
+	mappings *loader[types.ID, *types.MappingRecord]
+
+	gatewayHistory *loader[idAt, *types.GatewayHistory]
+	mapperHistory  *loader[idAt, *types.MapperHistory]
+	routerHistory  *loader[idAt, *types.RouterHistory]
+
+	gatewayEvents *loader[pageKey, page[*types.GatewayEvent]]
+	mapperEvents  *loader[pageKey, page[*types.MapperEvent]]
+	routerEvents  *loader[pageKey, page[*types.RouterEvent]]
+
+	gatewayRewards *loader[pageKey, page[*types.GatewayRewardHistory]]
+	mapperRewards  *loader[pageKey, page[*types.MapperRewardHistory]]
+
+	gatewayCoverage        *loader[idAt, []*types.CoverageHistory]
+	gatewayAssumedCoverage *loader[idAt, []string]
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a copy of ctx with a fresh set of loaders.
+func withLoaders(ctx context.Context, g *GraphQL) context.Context {
+	l := &loaders{
+		// GetMulti returns nil for entities that don't exist
+		gateways: newLoader(ctx, fetchMulti(g.gatewayStore.GetMulti)),
+		mappers:  newLoader(ctx, fetchMulti(g.mapperStore.GetMulti)),
+		routers:  newLoader(ctx, fetchMulti(g.routerStore.GetMulti)),
+		mappings: newLoader(ctx, fetchEach(g.mappingStore.GetMapping)),
+
+		gatewayHistory: newLoader(ctx, fetchEach(func(ctx context.Context, key idAt) (*types.GatewayHistory, error) {
+			return g.gatewayStore.GetHistoryAt(ctx, key.id, key.at)
+		})),
+		mapperHistory: newLoader(ctx, fetchEach(func(ctx context.Context, key idAt) (*types.MapperHistory, error) {
+			return g.mapperStore.GetHistoryAt(ctx, key.id, key.at)
+		})),
+		routerHistory: newLoader(ctx, fetchEach(func(ctx context.Context, key idAt) (*types.RouterHistory, error) {
+			return g.routerStore.GetHistoryAt(ctx, key.id, key.at)
+		})),
+
+		gatewayEvents:  newLoader(ctx, fetchEach(pager(g.gatewayStore.GetEvents))),
+		mapperEvents:   newLoader(ctx, fetchEach(pager(g.mapperStore.GetEvents))),
+		routerEvents:   newLoader(ctx, fetchEach(pager(g.routerStore.GetEvents))),
+		gatewayRewards: newLoader(ctx, fetchEach(pager(g.rewardStore.GetGatewayRewards))),
+		mapperRewards:  newLoader(ctx, fetchEach(pager(g.rewardStore.GetMapperRewards))),
+
+		gatewayCoverage: newLoader(ctx, fetchEach(func(ctx context.Context, key idAt) ([]*types.CoverageHistory, error) {
+			return g.mappingStore.GetCoverageForGatewayAt(ctx, key.id, key.at)
+		})),
+		gatewayAssumedCoverage: newLoader(ctx, fetchEach(func(ctx context.Context, key idAt) ([]string, error) {
+			cells, err := g.mappingStore.GetAssumedCoverageLocationsForGateway(ctx, key.id, key.at)
+			if err != nil {
+				return nil, err
+			}
+			locations := make([]string, len(cells))
+			for i, cell := range cells {
+				locations[i] = cell.String()
+			}
+			return locations, nil
+		})),
+	}
+
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the loaders that withLoaders attached to ctx.
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// pager adapts a paged store lookup to a lookup by pageKey.
+func pager[T any](get func(ctx context.Context, id types.ID, limit int, cursor string) ([]T, string, error)) func(ctx context.Context, key pageKey) (page[T], error) {
+	return func(ctx context.Context, key pageKey) (page[T], error) {
+		items, cursor, err := get(ctx, key.id, key.limit, key.cursor)
+		if err != nil {
+			return page[T]{}, err
+		}
+		return newPage(items, cursor, key.limit), nil
+	}
+}
+
+// newPage returns the first limit items and the cursor to the next page, if
+// the store returned more items than limit.
+func newPage[T any](items []T, cursor string, limit int) page[T] {
+	if len(items) <= limit {
+		cursor = ""
+	} else {
+		items = items[:limit]
+	}
+	return page[T]{items: items, cursor: cursor}
+}
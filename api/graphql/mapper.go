@@ -0,0 +1,164 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/types"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type mapperResolver struct {
+	g *GraphQL
+	m *types.Mapper
+}
+
+func loadMapper(ctx context.Context, g *GraphQL, id types.ID) (*mapperResolver, error) {
+	m, err := loadersFrom(ctx).mappers.Load(ctx, id)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting mapper details")
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return &mapperResolver{g: g, m: m}, nil
+}
+
+func mapperResolvers(g *GraphQL, mappers []*types.Mapper) []*mapperResolver {
+	resolvers := make([]*mapperResolver, len(mappers))
+	for i, m := range mappers {
+		resolvers[i] = &mapperResolver{g: g, m: m}
+	}
+	return resolvers
+}
+
+func (r *mapperResolver) ID() graphqlgo.ID      { return toID(r.m.ID) }
+func (r *mapperResolver) Contract() string      { return r.m.ContractAddress.String() }
+func (r *mapperResolver) Revision() int32       { return int32(r.m.Revision) }
+func (r *mapperResolver) FrequencyPlan() string { return string(r.m.FrequencyPlan) }
+func (r *mapperResolver) Owner() *string        { return addressPtrToStringPtr(r.m.Owner) }
+func (r *mapperResolver) Active() bool          { return r.m.Active }
+
+func (r *mapperResolver) Events(ctx context.Context, args pageArgs) (*mapperEventPageResolver, error) {
+	p, err := loadersFrom(ctx).mapperEvents.Load(ctx, pageKey{id: r.m.ID, limit: args.limit(), cursor: args.cursor()})
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve mapper events from DB")
+	}
+	return &mapperEventPageResolver{g: r.g, page: p}, nil
+}
+
+func (r *mapperResolver) HistoryAt(ctx context.Context, args struct{ Time graphqlgo.Time }) (*mapperHistoryResolver, error) {
+	history, err := loadersFrom(ctx).mapperHistory.Load(ctx, idAt{id: r.m.ID, at: args.Time.Time})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting mapper history")
+	}
+	if history == nil {
+		return nil, nil
+	}
+	return &mapperHistoryResolver{h: history}, nil
+}
+
+func (r *mapperResolver) Mappings(ctx context.Context, args struct {
+	Start graphqlgo.Time
+	End   graphqlgo.Time
+	pageArgs
+}) (*mappingPageResolver, error) {
+	if args.End.Before(args.Start.Time) {
+		return nil, fmt.Errorf("end is before start")
+	}
+
+	limit := args.limit()
+	mappings, cursor, err := r.g.mappingStore.GetMappingsForMapperInPeriod(ctx, r.m.ID, args.Start.Time, args.End.Time, limit, args.cursor())
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting mappings for mapper")
+	}
+	return &mappingPageResolver{g: r.g, page: newPage(mappings, cursor, limit)}, nil
+}
+
+func (r *mapperResolver) Rewards(ctx context.Context, args pageArgs) (*mapperRewardPageResolver, error) {
+	p, err := loadersFrom(ctx).mapperRewards.Load(ctx, pageKey{id: r.m.ID, limit: args.limit(), cursor: args.cursor()})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting mapper rewards")
+	}
+	return &mapperRewardPageResolver{g: r.g, page: p}, nil
+}
+
+type mapperEventResolver struct {
+	g  *GraphQL
+	ev *types.MapperEvent
+}
+
+func (r *mapperEventResolver) Contract() string        { return r.ev.ContractAddress.String() }
+func (r *mapperEventResolver) Block() string           { return r.ev.Block.String() }
+func (r *mapperEventResolver) BlockNumber() Uint64     { return Uint64(r.ev.BlockNumber) }
+func (r *mapperEventResolver) Transaction() string     { return r.ev.Transaction.String() }
+func (r *mapperEventResolver) TransactionIndex() int32 { return int32(r.ev.TransactionIndex) }
+func (r *mapperEventResolver) LogIndex() int32         { return int32(r.ev.LogIndex) }
+func (r *mapperEventResolver) Type() string            { return string(r.ev.Type) }
+func (r *mapperEventResolver) MapperID() graphqlgo.ID  { return toID(r.ev.ID) }
+func (r *mapperEventResolver) Revision() int32         { return int32(r.ev.Revision) }
+func (r *mapperEventResolver) FrequencyPlan() string   { return string(r.ev.FrequencyPlan) }
+func (r *mapperEventResolver) NewOwner() *string       { return addressPtrToStringPtr(r.ev.NewOwner) }
+func (r *mapperEventResolver) OldOwner() *string       { return addressPtrToStringPtr(r.ev.OldOwner) }
+func (r *mapperEventResolver) Time() graphqlgo.Time    { return toTime(r.ev.Time) }
+
+func (r *mapperEventResolver) Mapper(ctx context.Context) (*mapperResolver, error) {
+	return loadMapper(ctx, r.g, r.ev.ID)
+}
+
+type mapperHistoryResolver struct {
+	h *types.MapperHistory
+}
+
+func (r *mapperHistoryResolver) ID() graphqlgo.ID      { return toID(r.h.ID) }
+func (r *mapperHistoryResolver) Contract() string      { return r.h.ContractAddress.String() }
+func (r *mapperHistoryResolver) Revision() int32       { return int32(r.h.Revision) }
+func (r *mapperHistoryResolver) Owner() *string        { return addressPtrToStringPtr(r.h.Owner) }
+func (r *mapperHistoryResolver) FrequencyPlan() string { return string(r.h.FrequencyPlan) }
+func (r *mapperHistoryResolver) Active() bool          { return r.h.Active }
+func (r *mapperHistoryResolver) Time() graphqlgo.Time  { return toTime(r.h.Time) }
+func (r *mapperHistoryResolver) BlockNumber() Uint64   { return Uint64(r.h.BlockNumber) }
+func (r *mapperHistoryResolver) Block() string         { return r.h.Block.String() }
+func (r *mapperHistoryResolver) Transaction() string   { return r.h.Transaction.String() }
+
+type mapperPageResolver struct {
+	g    *GraphQL
+	page page[*types.Mapper]
+}
+
+func (r *mapperPageResolver) Mappers() []*mapperResolver {
+	return mapperResolvers(r.g, r.page.items)
+}
+
+func (r *mapperPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type mapperEventPageResolver struct {
+	g    *GraphQL
+	page page[*types.MapperEvent]
+}
+
+func (r *mapperEventPageResolver) Events() []*mapperEventResolver {
+	resolvers := make([]*mapperEventResolver, len(r.page.items))
+	for i, ev := range r.page.items {
+		resolvers[i] = &mapperEventResolver{g: r.g, ev: ev}
+	}
+	return resolvers
+}
+
+func (r *mapperEventPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
@@ -0,0 +1,149 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/types"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type mappingResolver struct {
+	g  *GraphQL
+	mr *types.MappingRecord
+}
+
+func loadMapping(ctx context.Context, g *GraphQL, id types.ID) (*mappingResolver, error) {
+	mr, err := loadersFrom(ctx).mappings.Load(ctx, id)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting mapping")
+	}
+	if mr == nil {
+		return nil, nil
+	}
+	return &mappingResolver{g: g, mr: mr}, nil
+}
+
+func (r *mappingResolver) ID() graphqlgo.ID       { return toID(r.mr.ID) }
+func (r *mappingResolver) FrequencyPlan() string  { return string(r.mr.FrequencyPlan) }
+func (r *mappingResolver) MapperID() graphqlgo.ID { return toID(r.mr.MapperID) }
+func (r *mappingResolver) MapperLocation() string { return r.mr.MapperLocation.String() }
+func (r *mappingResolver) MapperLat() float64     { return r.mr.MapperLat }
+func (r *mappingResolver) MapperLon() float64     { return r.mr.MapperLon }
+func (r *mappingResolver) MapperHeight() float64  { return r.mr.MapperHeight }
+func (r *mappingResolver) ReceivedTime() graphqlgo.Time {
+	return toTime(r.mr.ReceivedTime)
+}
+func (r *mappingResolver) ServiceValidation() string { return string(r.mr.ServiceValidation) }
+
+func (r *mappingResolver) MeasuredRssi() *int32 {
+	if r.mr.MeasuredRssi == nil {
+		return nil
+	}
+	rssi := int32(*r.mr.MeasuredRssi)
+	return &rssi
+}
+
+func (r *mappingResolver) MeasuredSnr() *int32 {
+	if r.mr.MeasuredSnr == nil {
+		return nil
+	}
+	snr := int32(*r.mr.MeasuredSnr)
+	return &snr
+}
+
+func (r *mappingResolver) ChallengedGatewayID() *graphqlgo.ID {
+	if r.mr.ChallengedGatewayID == nil {
+		return nil
+	}
+	id := toID(*r.mr.ChallengedGatewayID)
+	return &id
+}
+
+func (r *mappingResolver) ChallengedGateway(ctx context.Context) (*gatewayResolver, error) {
+	if r.mr.ChallengedGatewayID == nil {
+		return nil, nil
+	}
+	return loadGateway(ctx, r.g, *r.mr.ChallengedGatewayID)
+}
+
+func (r *mappingResolver) ChallengedGatewayLocation() *string {
+	return cellPtrToStringPtr(r.mr.ChallengedGatewayLocation)
+}
+
+func (r *mappingResolver) ChallengedTime() *graphqlgo.Time {
+	if r.mr.ChallengedTime == nil {
+		return nil
+	}
+	t := toTime(*r.mr.ChallengedTime)
+	return &t
+}
+
+func (r *mappingResolver) Mapper(ctx context.Context) (*mapperResolver, error) {
+	return loadMapper(ctx, r.g, r.mr.MapperID)
+}
+
+type mappingPageResolver struct {
+	g    *GraphQL
+	page page[*types.MappingRecord]
+}
+
+func (r *mappingPageResolver) Mappings() []*mappingResolver {
+	resolvers := make([]*mappingResolver, len(r.page.items))
+	for i, mr := range r.page.items {
+		resolvers[i] = &mappingResolver{g: r.g, mr: mr}
+	}
+	return resolvers
+}
+
+func (r *mappingPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type coverageResolver struct {
+	g  *GraphQL
+	ch *types.CoverageHistory
+}
+
+func coverageResolvers(g *GraphQL, coverage []*types.CoverageHistory) []*coverageResolver {
+	resolvers := make([]*coverageResolver, len(coverage))
+	for i, ch := range coverage {
+		resolvers[i] = &coverageResolver{g: g, ch: ch}
+	}
+	return resolvers
+}
+
+func (r *coverageResolver) Location() string            { return r.ch.Location.String() }
+func (r *coverageResolver) Date() graphqlgo.Time        { return toTime(r.ch.Date) }
+func (r *coverageResolver) GatewayID() graphqlgo.ID     { return toID(r.ch.GatewayID) }
+func (r *coverageResolver) GatewayLocation() string     { return r.ch.GatewayLocation.String() }
+func (r *coverageResolver) FrequencyPlan() string       { return string(r.ch.FrequencyPlan) }
+func (r *coverageResolver) MapperID() graphqlgo.ID      { return toID(r.ch.MapperID) }
+func (r *coverageResolver) MappingID() graphqlgo.ID     { return toID(r.ch.MappingID) }
+func (r *coverageResolver) MappingTime() graphqlgo.Time { return toTime(r.ch.MappingTime) }
+func (r *coverageResolver) Rssi() int32                 { return int32(r.ch.RSSI) }
+
+func (r *coverageResolver) Gateway(ctx context.Context) (*gatewayResolver, error) {
+	return loadGateway(ctx, r.g, r.ch.GatewayID)
+}
+
+func (r *coverageResolver) Mapper(ctx context.Context) (*mapperResolver, error) {
+	return loadMapper(ctx, r.g, r.ch.MapperID)
+}
+
+func (r *coverageResolver) Mapping(ctx context.Context) (*mappingResolver, error) {
+	return loadMapping(ctx, r.g, r.ch.MappingID)
+}
@@ -0,0 +1,295 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"text/scanner"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// syntaxError aborts parsing a query document.
+type syntaxError string
+
+// parseQuery parses a query document in the AST of graphql-go. Its parser is
+// internal, so this follows its grammar and scanner setup to make sure the
+// cost is estimated for every document the schema executes.
+func parseQuery(query string) (doc *types.ExecutableDefinition, err error) {
+	p := &queryParser{}
+	// graphql-go scans with the Go defaults, Init sets them
+	p.sc.Init(strings.NewReader(query))
+	p.sc.Error = func(_ *scanner.Scanner, msg string) {
+		panic(syntaxError(msg))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			msg, ok := r.(syntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, fmt.Errorf("syntax error at %d:%d: %s", p.sc.Line, p.sc.Column, msg)
+		}
+	}()
+
+	p.consumeWhitespace()
+	return p.executableDefinition(), nil
+}
+
+type queryParser struct {
+	sc   scanner.Scanner
+	next rune
+}
+
+// consumeWhitespace advances to the next token, skipping commas and comments.
+func (p *queryParser) consumeWhitespace() {
+	for {
+		p.next = p.sc.Scan()
+		switch p.next {
+		case ',':
+			continue
+		case '#':
+			for next := p.sc.Next(); next != '\r' && next != '\n' && next != scanner.EOF; next = p.sc.Next() {
+			}
+			continue
+		}
+		return
+	}
+}
+
+func (p *queryParser) consumeToken(expected rune) {
+	if p.next != expected {
+		panic(syntaxError(fmt.Sprintf("unexpected %q, expecting %s", p.sc.TokenText(), scanner.TokenString(expected))))
+	}
+	p.consumeWhitespace()
+}
+
+func (p *queryParser) consumeIdent() types.Ident {
+	ident := types.Ident{Name: p.sc.TokenText()}
+	p.consumeToken(scanner.Ident)
+	return ident
+}
+
+func (p *queryParser) executableDefinition() *types.ExecutableDefinition {
+	doc := &types.ExecutableDefinition{}
+	for p.next != scanner.EOF {
+		if p.next == '{' {
+			doc.Operations = append(doc.Operations, &types.OperationDefinition{
+				Type:       "query",
+				Selections: p.selectionSet(),
+			})
+			continue
+		}
+
+		switch keyword := p.consumeIdent().Name; keyword {
+		case "query", "mutation", "subscription":
+			doc.Operations = append(doc.Operations, p.operation(types.OperationType(keyword)))
+		case "fragment":
+			doc.Fragments = append(doc.Fragments, p.fragmentDefinition())
+		default:
+			panic(syntaxError(fmt.Sprintf("unexpected %q, expecting \"fragment\"", keyword)))
+		}
+	}
+	return doc
+}
+
+func (p *queryParser) operation(typ types.OperationType) *types.OperationDefinition {
+	op := &types.OperationDefinition{Type: typ}
+	if p.next == scanner.Ident {
+		op.Name = p.consumeIdent()
+	}
+	op.Directives = p.directives()
+	if p.next == '(' {
+		p.consumeToken('(')
+		for p.next != ')' {
+			p.consumeToken('$')
+			op.Vars = append(op.Vars, p.variableDefinition())
+		}
+		p.consumeToken(')')
+	}
+	op.Selections = p.selectionSet()
+	return op
+}
+
+func (p *queryParser) variableDefinition() *types.InputValueDefinition {
+	v := &types.InputValueDefinition{Name: p.consumeIdent()}
+	p.consumeToken(':')
+	v.Type = p.typ()
+	if p.next == '=' {
+		p.consumeToken('=')
+		v.Default = p.literal(true)
+	}
+	v.Directives = p.directives()
+	return v
+}
+
+func (p *queryParser) typ() types.Type {
+	var t types.Type
+	if p.next == '[' {
+		p.consumeToken('[')
+		t = &types.List{OfType: p.typ()}
+		p.consumeToken(']')
+	} else {
+		t = &types.TypeName{Ident: p.consumeIdent()}
+	}
+
+	if p.next == '!' {
+		p.consumeToken('!')
+		return &types.NonNull{OfType: t}
+	}
+	return t
+}
+
+func (p *queryParser) fragmentDefinition() *types.FragmentDefinition {
+	frag := &types.FragmentDefinition{Name: p.consumeIdent()}
+	if on := p.consumeIdent(); on.Name != "on" {
+		panic(syntaxError(fmt.Sprintf("unexpected %q, expecting \"on\"", on.Name)))
+	}
+	frag.On = types.TypeName{Ident: p.consumeIdent()}
+	frag.Directives = p.directives()
+	frag.Selections = p.selectionSet()
+	return frag
+}
+
+func (p *queryParser) selectionSet() types.SelectionSet {
+	var selections types.SelectionSet
+	p.consumeToken('{')
+	for p.next != '}' {
+		if p.next == '.' {
+			selections = append(selections, p.spread())
+		} else {
+			selections = append(selections, p.field())
+		}
+	}
+	p.consumeToken('}')
+	return selections
+}
+
+func (p *queryParser) field() *types.Field {
+	field := &types.Field{Alias: p.consumeIdent()}
+	field.Name = field.Alias
+	if p.next == ':' {
+		p.consumeToken(':')
+		field.Name = p.consumeIdent()
+	}
+	if p.next == '(' {
+		field.Arguments = p.arguments()
+	}
+	field.Directives = p.directives()
+	if p.next == '{' {
+		field.SelectionSet = p.selectionSet()
+	}
+	return field
+}
+
+func (p *queryParser) spread() types.Selection {
+	p.consumeToken('.')
+	p.consumeToken('.')
+	p.consumeToken('.')
+
+	frag := &types.InlineFragment{}
+	if p.next == scanner.Ident {
+		ident := p.consumeIdent()
+		if ident.Name != "on" {
+			return &types.FragmentSpread{Name: ident, Directives: p.directives()}
+		}
+		frag.On = types.TypeName{Ident: p.consumeIdent()}
+	}
+	frag.Directives = p.directives()
+	frag.Selections = p.selectionSet()
+	return frag
+}
+
+func (p *queryParser) arguments() types.ArgumentList {
+	var args types.ArgumentList
+	p.consumeToken('(')
+	for p.next != ')' {
+		arg := &types.Argument{Name: p.consumeIdent()}
+		p.consumeToken(':')
+		arg.Value = p.literal(false)
+		arg.Directives = p.directives()
+		args = append(args, arg)
+	}
+	p.consumeToken(')')
+	return args
+}
+
+func (p *queryParser) directives() types.DirectiveList {
+	var directives types.DirectiveList
+	for p.next == '@' {
+		p.consumeToken('@')
+		d := &types.Directive{Name: p.consumeIdent()}
+		if p.next == '(' {
+			d.Arguments = p.arguments()
+		}
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// literal parses a value, variables are only allowed when constOnly is
+// false.
+func (p *queryParser) literal(constOnly bool) types.Value {
+	switch p.next {
+	case '$':
+		if constOnly {
+			panic(syntaxError("variable not allowed"))
+		}
+		p.consumeToken('$')
+		return &types.Variable{Name: p.consumeIdent().Name}
+
+	case scanner.Int, scanner.Float, scanner.String, scanner.Ident:
+		lit := &types.PrimitiveValue{Type: p.next, Text: p.sc.TokenText()}
+		p.consumeWhitespace()
+		if lit.Type == scanner.Ident && lit.Text == "null" {
+			return &types.NullValue{}
+		}
+		return lit
+
+	case '-':
+		p.consumeToken('-')
+		lit := &types.PrimitiveValue{Type: p.next, Text: "-" + p.sc.TokenText()}
+		p.consumeWhitespace()
+		return lit
+
+	case '[':
+		p.consumeToken('[')
+		list := &types.ListValue{}
+		for p.next != ']' {
+			list.Values = append(list.Values, p.literal(constOnly))
+		}
+		p.consumeToken(']')
+		return list
+
+	case '{':
+		p.consumeToken('{')
+		obj := &types.ObjectValue{}
+		for p.next != '}' {
+			field := &types.ObjectField{Name: p.consumeIdent()}
+			p.consumeToken(':')
+			field.Value = p.literal(constOnly)
+			obj.Fields = append(obj.Fields, field)
+		}
+		p.consumeToken('}')
+		return obj
+
+	default:
+		panic(syntaxError("invalid value"))
+	}
+}
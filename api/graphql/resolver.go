@@ -0,0 +1,201 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mappingAPI "github.com/ThingsIXFoundation/data-aggregator/mapping/api"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// resolver resolves the fields of the Query type.
+type resolver struct {
+	g *GraphQL
+}
+
+type idArgs struct {
+	ID graphqlgo.ID
+}
+
+type pageArgs struct {
+	First *int32
+	After *string
+}
+
+// limit returns the requested page size, bounded by maxPageSize.
+func (args pageArgs) limit() int {
+	if args.First == nil || *args.First <= 0 {
+		return defaultPageSize
+	}
+	if *args.First > maxPageSize {
+		return maxPageSize
+	}
+	return int(*args.First)
+}
+
+func (args pageArgs) cursor() string {
+	if args.After == nil {
+		return ""
+	}
+	return *args.After
+}
+
+func (r *resolver) Gateway(ctx context.Context, args idArgs) (*gatewayResolver, error) {
+	return loadGateway(ctx, r.g, fromID(args.ID))
+}
+
+func (r *resolver) Mapper(ctx context.Context, args idArgs) (*mapperResolver, error) {
+	return loadMapper(ctx, r.g, fromID(args.ID))
+}
+
+func (r *resolver) Router(ctx context.Context, args idArgs) (*routerResolver, error) {
+	return loadRouter(ctx, r.g, fromID(args.ID))
+}
+
+func (r *resolver) Routers(ctx context.Context) ([]*routerResolver, error) {
+	routers, err := r.g.routerStore.GetAll(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve routers from DB")
+	}
+	return routerResolvers(r.g, routers), nil
+}
+
+func (r *resolver) Owner(args struct{ Address string }) (*ownerResolver, error) {
+	if !common.IsHexAddress(args.Address) {
+		return nil, fmt.Errorf("invalid address")
+	}
+	return &ownerResolver{g: r.g, address: common.HexToAddress(args.Address)}, nil
+}
+
+func (r *resolver) Mapping(ctx context.Context, args idArgs) (*mappingResolver, error) {
+	return loadMapping(ctx, r.g, fromID(args.ID))
+}
+
+func (r *resolver) Coverage(ctx context.Context, args struct {
+	Region string
+	Date   graphqlgo.Time
+}) ([]*coverageResolver, error) {
+	region, err := h3light.CellFromString(args.Region)
+	if err != nil {
+		return nil, fmt.Errorf("invalid h3 index")
+	}
+
+	res := region.Resolution()
+	if res > mappingAPI.MAP_COVERAGE_MAX_RES || res < mappingAPI.MAP_COVERAGE_MIN_RES {
+		return nil, fmt.Errorf("invalid h3 resolution")
+	}
+
+	if err := r.g.checkRewardsDate(ctx, args.Date.Time); err != nil {
+		return nil, err
+	}
+
+	coverage, err := r.g.mappingStore.GetCoverageInRegionAt(ctx, region, args.Date.Time)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting coverage locations")
+	}
+	return coverageResolvers(r.g, coverage), nil
+}
+
+func (r *resolver) LatestRewardsDate(ctx context.Context) (*graphqlgo.Time, error) {
+	date, err := r.g.rewardStore.GetLatestRewardsDateCached(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err, "cannot get latest reward date")
+	}
+	if date.IsZero() {
+		return nil, nil
+	}
+	return &graphqlgo.Time{Time: date}, nil
+}
+
+// checkRewardsDate returns an error when no rewards, and therefore no
+// coverage, have been calculated for date yet.
+func (g *GraphQL) checkRewardsDate(ctx context.Context, date time.Time) error {
+	latestRewardsDate, err := g.rewardStore.GetLatestRewardsDateCached(ctx)
+	if err != nil {
+		return internalError(ctx, err, "cannot get latest reward date")
+	}
+	if latestRewardsDate.Before(date) {
+		return fmt.Errorf("invalid date")
+	}
+	return nil
+}
+
+func toID(id types.ID) graphqlgo.ID {
+	return graphqlgo.ID(id.String())
+}
+
+func fromID(id graphqlgo.ID) types.ID {
+	return types.IDFromString(string(id))
+}
+
+func toTime(t time.Time) graphqlgo.Time {
+	return graphqlgo.Time{Time: t}
+}
+
+func addressPtrToStringPtr(addr *common.Address) *string {
+	if addr == nil {
+		return nil
+	}
+	s := addr.String()
+	return &s
+}
+
+func cellPtrToStringPtr(cell *h3light.Cell) *string {
+	if cell == nil {
+		return nil
+	}
+	s := cell.String()
+	return &s
+}
+
+func bandPtrToStringPtr(band *frequency_plan.BandName) *string {
+	if band == nil {
+		return nil
+	}
+	s := string(*band)
+	return &s
+}
+
+func float32PtrToFloat64Ptr(f *float32) *float64 {
+	if f == nil {
+		return nil
+	}
+	v := float64(*f)
+	return &v
+}
+
+func uintPtrToInt32Ptr(u *uint) *int32 {
+	if u == nil {
+		return nil
+	}
+	v := int32(*u)
+	return &v
+}
+
+func cursorPtr(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+	return &cursor
+}
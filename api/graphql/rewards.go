@@ -0,0 +1,172 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// ownerResolver resolves the entities owned by and the rewards of an address.
+type ownerResolver struct {
+	g       *GraphQL
+	address common.Address
+}
+
+func (r *ownerResolver) Address() string { return r.address.String() }
+
+func (r *ownerResolver) Gateways(ctx context.Context, args pageArgs) (*gatewayPageResolver, error) {
+	limit := args.limit()
+	gateways, cursor, err := r.g.gatewayStore.GetByOwner(ctx, r.address, limit, args.cursor())
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve gateways from DB")
+	}
+	return &gatewayPageResolver{g: r.g, page: newPage(gateways, cursor, limit)}, nil
+}
+
+func (r *ownerResolver) Mappers(ctx context.Context, args pageArgs) (*mapperPageResolver, error) {
+	limit := args.limit()
+	mappers, cursor, err := r.g.mapperStore.GetByOwner(ctx, r.address, limit, args.cursor())
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve mappers from DB")
+	}
+	return &mapperPageResolver{g: r.g, page: newPage(mappers, cursor, limit)}, nil
+}
+
+func (r *ownerResolver) Routers(ctx context.Context, args pageArgs) (*routerPageResolver, error) {
+	limit := args.limit()
+	routers, cursor, err := r.g.routerStore.GetByOwner(ctx, r.address, limit, args.cursor())
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve routers from DB")
+	}
+	return &routerPageResolver{g: r.g, page: newPage(routers, cursor, limit)}, nil
+}
+
+func (r *ownerResolver) Rewards(ctx context.Context, args pageArgs) (*accountRewardPageResolver, error) {
+	limit := args.limit()
+	rewards, cursor, err := r.g.rewardStore.GetAccountRewards(ctx, r.address, limit, args.cursor())
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting account rewards")
+	}
+	return &accountRewardPageResolver{page: newPage(rewards, cursor, limit)}, nil
+}
+
+func (r *ownerResolver) RewardsAt(ctx context.Context, args struct{ Date graphqlgo.Time }) (*accountRewardResolver, error) {
+	rewards, err := r.g.rewardStore.GetAccountRewardsAt(ctx, r.address, args.Date.Time)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting account rewards")
+	}
+	if rewards == nil {
+		return nil, nil
+	}
+	return &accountRewardResolver{rh: rewards}, nil
+}
+
+type gatewayRewardResolver struct {
+	g  *GraphQL
+	rh *types.GatewayRewardHistory
+}
+
+func (r *gatewayRewardResolver) GatewayID() graphqlgo.ID { return toID(r.rh.GatewayID) }
+func (r *gatewayRewardResolver) Date() graphqlgo.Time    { return toTime(r.rh.Date) }
+func (r *gatewayRewardResolver) AssumedCoverageShareUnits() *BigInt {
+	return newBigInt(r.rh.AssumedCoverageShareUnits)
+}
+func (r *gatewayRewardResolver) Rewards() *BigInt { return newBigInt(r.rh.Rewards) }
+
+func (r *gatewayRewardResolver) Gateway(ctx context.Context) (*gatewayResolver, error) {
+	return loadGateway(ctx, r.g, r.rh.GatewayID)
+}
+
+type gatewayRewardPageResolver struct {
+	g    *GraphQL
+	page page[*types.GatewayRewardHistory]
+}
+
+func (r *gatewayRewardPageResolver) Rewards() []*gatewayRewardResolver {
+	resolvers := make([]*gatewayRewardResolver, len(r.page.items))
+	for i, rh := range r.page.items {
+		resolvers[i] = &gatewayRewardResolver{g: r.g, rh: rh}
+	}
+	return resolvers
+}
+
+func (r *gatewayRewardPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type mapperRewardResolver struct {
+	g  *GraphQL
+	rh *types.MapperRewardHistory
+}
+
+func (r *mapperRewardResolver) MapperID() graphqlgo.ID { return toID(r.rh.MapperID) }
+func (r *mapperRewardResolver) Date() graphqlgo.Time   { return toTime(r.rh.Date) }
+func (r *mapperRewardResolver) MappingUnits() *BigInt  { return newBigInt(r.rh.MappingUnits) }
+func (r *mapperRewardResolver) Rewards() *BigInt       { return newBigInt(r.rh.Rewards) }
+
+func (r *mapperRewardResolver) Mapper(ctx context.Context) (*mapperResolver, error) {
+	return loadMapper(ctx, r.g, r.rh.MapperID)
+}
+
+type mapperRewardPageResolver struct {
+	g    *GraphQL
+	page page[*types.MapperRewardHistory]
+}
+
+func (r *mapperRewardPageResolver) Rewards() []*mapperRewardResolver {
+	resolvers := make([]*mapperRewardResolver, len(r.page.items))
+	for i, rh := range r.page.items {
+		resolvers[i] = &mapperRewardResolver{g: r.g, rh: rh}
+	}
+	return resolvers
+}
+
+func (r *mapperRewardPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type accountRewardResolver struct {
+	rh *types.AccountRewardHistory
+}
+
+func (r *accountRewardResolver) Account() string       { return r.rh.Account.String() }
+func (r *accountRewardResolver) Rewards() *BigInt      { return newBigInt(r.rh.Rewards) }
+func (r *accountRewardResolver) TotalRewards() *BigInt { return newBigInt(r.rh.TotalRewards) }
+func (r *accountRewardResolver) Processor() string     { return r.rh.Processor.String() }
+func (r *accountRewardResolver) Date() graphqlgo.Time  { return toTime(r.rh.Date) }
+
+func (r *accountRewardResolver) Signature() *string {
+	if len(r.rh.Signature) == 0 {
+		return nil
+	}
+	signature := r.rh.Signature.String()
+	return &signature
+}
+
+type accountRewardPageResolver struct {
+	page page[*types.AccountRewardHistory]
+}
+
+func (r *accountRewardPageResolver) Rewards() []*accountRewardResolver {
+	resolvers := make([]*accountRewardResolver, len(r.page.items))
+	for i, rh := range r.page.items {
+		resolvers[i] = &accountRewardResolver{rh: rh}
+	}
+	return resolvers
+}
+
+func (r *accountRewardPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
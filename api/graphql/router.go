@@ -0,0 +1,149 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/types"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type routerResolver struct {
+	g  *GraphQL
+	rt *types.Router
+}
+
+func loadRouter(ctx context.Context, g *GraphQL, id types.ID) (*routerResolver, error) {
+	rt, err := loadersFrom(ctx).routers.Load(ctx, id)
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting router details")
+	}
+	if rt == nil {
+		return nil, nil
+	}
+	return &routerResolver{g: g, rt: rt}, nil
+}
+
+func routerResolvers(g *GraphQL, routers []*types.Router) []*routerResolver {
+	resolvers := make([]*routerResolver, len(routers))
+	for i, rt := range routers {
+		resolvers[i] = &routerResolver{g: g, rt: rt}
+	}
+	return resolvers
+}
+
+func (r *routerResolver) ID() graphqlgo.ID      { return toID(r.rt.ID) }
+func (r *routerResolver) Contract() string      { return r.rt.ContractAddress.String() }
+func (r *routerResolver) Owner() string         { return r.rt.Owner.String() }
+func (r *routerResolver) NetID() int32          { return int32(r.rt.NetID) }
+func (r *routerResolver) Prefix() Uint64        { return Uint64(r.rt.Prefix) }
+func (r *routerResolver) Mask() int32           { return int32(r.rt.Mask) }
+func (r *routerResolver) FrequencyPlan() string { return string(r.rt.FrequencyPlan) }
+func (r *routerResolver) Endpoint() string      { return r.rt.Endpoint }
+
+func (r *routerResolver) Events(ctx context.Context, args pageArgs) (*routerEventPageResolver, error) {
+	p, err := loadersFrom(ctx).routerEvents.Load(ctx, pageKey{id: r.rt.ID, limit: args.limit(), cursor: args.cursor()})
+	if err != nil {
+		return nil, internalError(ctx, err, "unable to retrieve router events from DB")
+	}
+	return &routerEventPageResolver{g: r.g, page: p}, nil
+}
+
+func (r *routerResolver) HistoryAt(ctx context.Context, args struct{ Time graphqlgo.Time }) (*routerHistoryResolver, error) {
+	history, err := loadersFrom(ctx).routerHistory.Load(ctx, idAt{id: r.rt.ID, at: args.Time.Time})
+	if err != nil {
+		return nil, internalError(ctx, err, "error while getting router history")
+	}
+	if history == nil {
+		return nil, nil
+	}
+	return &routerHistoryResolver{h: history}, nil
+}
+
+type routerEventResolver struct {
+	g  *GraphQL
+	ev *types.RouterEvent
+}
+
+func (r *routerEventResolver) Contract() string         { return r.ev.ContractAddress.String() }
+func (r *routerEventResolver) Block() string            { return r.ev.Block.String() }
+func (r *routerEventResolver) BlockNumber() Uint64      { return Uint64(r.ev.BlockNumber) }
+func (r *routerEventResolver) Transaction() string      { return r.ev.Transaction.String() }
+func (r *routerEventResolver) TransactionIndex() int32  { return int32(r.ev.TransactionIndex) }
+func (r *routerEventResolver) LogIndex() int32          { return int32(r.ev.LogIndex) }
+func (r *routerEventResolver) Type() string             { return string(r.ev.Type) }
+func (r *routerEventResolver) RouterID() graphqlgo.ID   { return toID(r.ev.ID) }
+func (r *routerEventResolver) Owner() *string           { return addressPtrToStringPtr(r.ev.Owner) }
+func (r *routerEventResolver) NewNetID() int32          { return int32(r.ev.NewNetID) }
+func (r *routerEventResolver) OldNetID() int32          { return int32(r.ev.OldNetID) }
+func (r *routerEventResolver) NewPrefix() Uint64        { return Uint64(r.ev.NewPrefix) }
+func (r *routerEventResolver) OldPrefix() Uint64        { return Uint64(r.ev.OldPrefix) }
+func (r *routerEventResolver) NewMask() int32           { return int32(r.ev.NewMask) }
+func (r *routerEventResolver) OldMask() int32           { return int32(r.ev.OldMask) }
+func (r *routerEventResolver) NewFrequencyPlan() string { return string(r.ev.NewFrequencyPlan) }
+func (r *routerEventResolver) OldFrequencyPlan() string { return string(r.ev.OldFrequencyPlan) }
+func (r *routerEventResolver) NewEndpoint() string      { return r.ev.NewEndpoint }
+func (r *routerEventResolver) OldEndpoint() string      { return r.ev.OldEndpoint }
+func (r *routerEventResolver) Time() graphqlgo.Time     { return toTime(r.ev.Time) }
+
+func (r *routerEventResolver) Router(ctx context.Context) (*routerResolver, error) {
+	return loadRouter(ctx, r.g, r.ev.ID)
+}
+
+type routerHistoryResolver struct {
+	h *types.RouterHistory
+}
+
+func (r *routerHistoryResolver) ID() graphqlgo.ID      { return toID(r.h.ID) }
+func (r *routerHistoryResolver) Contract() string      { return r.h.ContractAddress.String() }
+func (r *routerHistoryResolver) Owner() *string        { return addressPtrToStringPtr(r.h.Owner) }
+func (r *routerHistoryResolver) NetID() int32          { return int32(r.h.NetID) }
+func (r *routerHistoryResolver) Prefix() Uint64        { return Uint64(r.h.Prefix) }
+func (r *routerHistoryResolver) Mask() int32           { return int32(r.h.Mask) }
+func (r *routerHistoryResolver) FrequencyPlan() string { return string(r.h.FrequencyPlan) }
+func (r *routerHistoryResolver) Endpoint() string      { return r.h.Endpoint }
+func (r *routerHistoryResolver) Time() graphqlgo.Time  { return toTime(r.h.Time) }
+func (r *routerHistoryResolver) BlockNumber() Uint64   { return Uint64(r.h.BlockNumber) }
+func (r *routerHistoryResolver) Block() string         { return r.h.Block.String() }
+func (r *routerHistoryResolver) Transaction() string   { return r.h.Transaction.String() }
+
+type routerPageResolver struct {
+	g    *GraphQL
+	page page[*types.Router]
+}
+
+func (r *routerPageResolver) Routers() []*routerResolver {
+	return routerResolvers(r.g, r.page.items)
+}
+
+func (r *routerPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
+
+type routerEventPageResolver struct {
+	g    *GraphQL
+	page page[*types.RouterEvent]
+}
+
+func (r *routerEventPageResolver) Events() []*routerEventResolver {
+	resolvers := make([]*routerEventResolver, len(r.page.items))
+	for i, ev := range r.page.items {
+		resolvers[i] = &routerEventResolver{g: r.g, ev: ev}
+	}
+	return resolvers
+}
+
+func (r *routerEventPageResolver) Cursor() *string { return cursorPtr(r.page.cursor) }
@@ -0,0 +1,94 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Uint64 is the GraphQL Uint64 scalar, it's encoded as a JSON number.
+type Uint64 uint64
+
+// ImplementsGraphQLType implements the graphql-go Unmarshaler interface.
+func (Uint64) ImplementsGraphQLType(name string) bool {
+	return name == "Uint64"
+}
+
+// UnmarshalGraphQL implements the graphql-go Unmarshaler interface.
+func (u *Uint64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		if v < 0 {
+			return fmt.Errorf("invalid Uint64 %d", v)
+		}
+		*u = Uint64(v)
+	case float64:
+		if v < 0 || v != float64(uint64(v)) {
+			return fmt.Errorf("invalid Uint64 %v", v)
+		}
+		*u = Uint64(v)
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Uint64 %q", v)
+		}
+		*u = Uint64(n)
+	default:
+		return fmt.Errorf("invalid Uint64 %v", input)
+	}
+	return nil
+}
+
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(u))
+}
+
+// BigInt is the GraphQL BigInt scalar, it's encoded as a decimal string.
+type BigInt struct {
+	big.Int
+}
+
+func newBigInt(i *big.Int) *BigInt {
+	if i == nil {
+		return nil
+	}
+	return &BigInt{Int: *i}
+}
+
+// ImplementsGraphQLType implements the graphql-go Unmarshaler interface.
+func (BigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+// UnmarshalGraphQL implements the graphql-go Unmarshaler interface.
+func (b *BigInt) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("invalid BigInt %v, expected a decimal string", input)
+	}
+	if _, ok := b.SetString(s, 10); !ok {
+		return fmt.Errorf("invalid BigInt %q", s)
+	}
+	return nil
+}
+
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/grpc/registryv1"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type gatewayService struct {
+	registryv1.UnimplementedGatewayServiceServer
+	s *Server
+}
+
+func (gs *gatewayService) GetGateway(ctx context.Context, req *registryv1.GetGatewayRequest) (*registryv1.GetGatewayResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	gateway, err := gs.s.gatewayStore.Get(ctx, id)
+	if err != nil {
+		return nil, gs.s.storeError(ctx, err, "error while getting gateway details")
+	}
+
+	return &registryv1.GetGatewayResponse{Gateway: gatewayToProto(gateway)}, nil
+}
+
+func (gs *gatewayService) ListOwnedGateways(ctx context.Context, req *registryv1.ListOwnedGatewaysRequest) (*registryv1.ListOwnedGatewaysResponse, error) {
+	owner, err := addressFromBytes(req.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	gateways, cursor, err := gs.s.gatewayStore.GetByOwner(ctx, owner, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, gs.s.storeError(ctx, err, "unable to retrieve gateways from DB")
+	}
+	gateways, cursor = trimPage(gateways, cursor, pageSize)
+
+	return &registryv1.ListOwnedGatewaysResponse{
+		Gateways:   gatewaysToProto(gateways),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (gs *gatewayService) ListGatewayEvents(ctx context.Context, req *registryv1.ListGatewayEventsRequest) (*registryv1.ListGatewayEventsResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	events, cursor, err := gs.s.gatewayStore.GetEvents(ctx, id, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, gs.s.storeError(ctx, err, "unable to retrieve gateway events from DB")
+	}
+	events, cursor = trimPage(events, cursor, pageSize)
+
+	return &registryv1.ListGatewayEventsResponse{
+		Events:     gatewayEventsToProto(events),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (gs *gatewayService) GetGatewaySnapshot(ctx context.Context, req *registryv1.GetGatewaySnapshotRequest) (*registryv1.GetGatewaySnapshotResponse, error) {
+	// read the block first, gateways that change while reading them are sent
+	// again by a watch from this block
+	currentBlock, err := gs.s.gatewayStore.CurrentBlock(ctx, "GatewayAggregator")
+	if err != nil {
+		return nil, gs.s.storeError(ctx, err, "error while getting sync state")
+	}
+
+	gateways, err := gs.s.gatewayStore.GetAll(ctx)
+	if err != nil {
+		return nil, gs.s.storeError(ctx, err, "error while getting gateways")
+	}
+
+	return &registryv1.GetGatewaySnapshotResponse{
+		BlockNumber: currentBlock,
+		Gateways:    gatewaysToProto(gateways),
+	}, nil
+}
+
+func (gs *gatewayService) WatchGatewayEvents(req *registryv1.WatchGatewayEventsRequest, stream registryv1.GatewayService_WatchGatewayEventsServer) error {
+	return watch[*types.GatewayEvent](stream.Context(), gs.s, gs.s.gatewayStore, "GatewayAggregator", req.GetFromBlock(),
+		func(to uint64, events []*types.GatewayEvent) error {
+			return stream.Send(&registryv1.WatchGatewayEventsResponse{
+				BlockNumber: to,
+				Events:      gatewayEventsToProto(events),
+			})
+		})
+}
+
+func gatewayToProto(gateway *types.Gateway) *registryv1.Gateway {
+	return &registryv1.Gateway{
+		Id:            gateway.ID[:],
+		Contract:      gateway.ContractAddress.Bytes(),
+		Version:       uint32(gateway.Version),
+		Owner:         gateway.Owner.Bytes(),
+		AntennaGain:   gateway.AntennaGain,
+		FrequencyPlan: bandToProto(gateway.FrequencyPlan),
+		Location:      cellToProto(gateway.Location),
+		Altitude:      uintToProto(gateway.Altitude),
+	}
+}
+
+func gatewaysToProto(gateways []*types.Gateway) []*registryv1.Gateway {
+	pb := make([]*registryv1.Gateway, len(gateways))
+	for i, gateway := range gateways {
+		pb[i] = gatewayToProto(gateway)
+	}
+	return pb
+}
+
+var gatewayEventTypes = map[types.GatewayEventType]registryv1.GatewayEventType{
+	types.GatewayOnboardedEvent:   registryv1.GatewayEventType_GATEWAY_EVENT_TYPE_ONBOARD,
+	types.GatewayOffboardedEvent:  registryv1.GatewayEventType_GATEWAY_EVENT_TYPE_OFFBOARD,
+	types.GatewayUpdatedEvent:     registryv1.GatewayEventType_GATEWAY_EVENT_TYPE_UPDATE,
+	types.GatewayTransferredEvent: registryv1.GatewayEventType_GATEWAY_EVENT_TYPE_TRANSFER,
+}
+
+func gatewayEventToProto(event *types.GatewayEvent) *registryv1.GatewayEvent {
+	return &registryv1.GatewayEvent{
+		Contract:         event.ContractAddress.Bytes(),
+		Block:            event.Block.Bytes(),
+		BlockNumber:      event.BlockNumber,
+		Transaction:      event.Transaction.Bytes(),
+		TransactionIndex: uint32(event.TransactionIndex),
+		LogIndex:         uint32(event.LogIndex),
+		Type:             gatewayEventTypes[event.Type],
+		GatewayId:        event.ID[:],
+		Version:          uint32(event.Version),
+		NewOwner:         addressToProto(event.NewOwner),
+		OldOwner:         addressToProto(event.OldOwner),
+		NewAntennaGain:   event.NewAntennaGain,
+		OldAntennaGain:   event.OldAntennaGain,
+		NewFrequencyPlan: bandToProto(event.NewFrequencyPlan),
+		OldFrequencyPlan: bandToProto(event.OldFrequencyPlan),
+		NewLocation:      cellToProto(event.NewLocation),
+		OldLocation:      cellToProto(event.OldLocation),
+		NewAltitude:      uintToProto(event.NewAltitude),
+		OldAltitude:      uintToProto(event.OldAltitude),
+		Time:             timestamppb.New(event.Time),
+	}
+}
+
+func gatewayEventsToProto(events []*types.GatewayEvent) []*registryv1.GatewayEvent {
+	pb := make([]*registryv1.GatewayEvent, len(events))
+	for i, event := range events {
+		pb[i] = gatewayEventToProto(event)
+	}
+	return pb
+}
+
+func addressToProto(addr *common.Address) []byte {
+	if addr == nil {
+		return nil
+	}
+	return addr.Bytes()
+}
+
+func bandToProto(band *frequency_plan.BandName) *string {
+	if band == nil {
+		return nil
+	}
+	s := string(*band)
+	return &s
+}
+
+func cellToProto(cell *h3light.Cell) *uint64 {
+	if cell == nil {
+		return nil
+	}
+	c := uint64(*cell)
+	return &c
+}
+
+func uintToProto(u *uint) *uint32 {
+	if u == nil {
+		return nil
+	}
+	v := uint32(*u)
+	return &v
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpc serves the gateway, mapper and router registries over gRPC for
+// machine consumers such as routers and forwarders.
+package grpc
+
+//go:generate protoc -I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative registryv1/gateway.proto registryv1/mapper.proto registryv1/router.proto
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/grpc/registryv1"
+	"github.com/ThingsIXFoundation/data-aggregator/api/ratelimit"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Options configure a gRPC server.
+type Options struct {
+	// ListenAddress is the address the gRPC service listens on.
+	ListenAddress string
+
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	// ChainID is the id of the chain the registries are deployed on.
+	ChainID uint64
+
+	// WatchPollInterval is the interval watch streams check for newly
+	// aggregated events.
+	WatchPollInterval time.Duration
+	// MaxBackfillBlocks is the maximum number of blocks a watch can start
+	// behind the aggregator.
+	MaxBackfillBlocks uint64
+
+	// RateLimit limits the calls, when nil calls aren't limited.
+	RateLimit *ratelimit.RateLimit
+
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Server serves the gateway, mapper and router services.
+type Server struct {
+	listenAddress string
+	log           logrus.FieldLogger
+
+	gatewayStore      gatewayStore.Store
+	mapperStore       mapperStore.Store
+	routerStore       routerStore.Store
+	chainID           uint64
+	watchPollInterval time.Duration
+	maxBackfillBlocks uint64
+	rateLimit         *ratelimit.RateLimit
+}
+
+// New creates a gRPC server with the given options.
+func New(opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Server{
+		listenAddress:     opts.ListenAddress,
+		log:               opts.Logger,
+		gatewayStore:      opts.GatewayStore,
+		mapperStore:       opts.MapperStore,
+		routerStore:       opts.RouterStore,
+		chainID:           opts.ChainID,
+		watchPollInterval: opts.WatchPollInterval,
+		maxBackfillBlocks: opts.MaxBackfillBlocks,
+		rateLimit:         opts.RateLimit,
+	}
+}
+
+// NewServer creates a gRPC server from the config.
+func NewServer() (*Server, error) {
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		ListenAddress:     viper.GetString(config.CONFIG_GRPC_API_LISTEN_ADDRESS),
+		GatewayStore:      gatewayStore,
+		MapperStore:       mapperStore,
+		RouterStore:       routerStore,
+		ChainID:           viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID),
+		WatchPollInterval: viper.GetDuration(config.CONFIG_GRPC_API_WATCH_POLL_INTERVAL),
+		MaxBackfillBlocks: viper.GetUint64(config.CONFIG_GRPC_API_MAX_BACKFILL_BLOCKS),
+	}
+
+	if viper.GetBool(config.CONFIG_API_RATELIMIT_ENABLED) {
+		rateLimit, err := ratelimit.NewRateLimit()
+		if err != nil {
+			return nil, err
+		}
+		opts.RateLimit = rateLimit
+	}
+
+	return New(opts), nil
+}
+
+// Serve starts the gRPC service in the background, it stops when ctx expires.
+// The returned channel reports when the service stopped.
+func (s *Server) Serve(ctx context.Context) chan error {
+	// buffered so neither goroutine blocks when the other already reported
+	stopped := make(chan error, 2)
+
+	lis, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		stopped <- err
+		return stopped
+	}
+
+	unary := []grpcgo.UnaryServerInterceptor{s.unaryInterceptor}
+	stream := []grpcgo.StreamServerInterceptor{s.streamInterceptor}
+	if s.rateLimit != nil {
+		unary = append(unary, s.rateLimit.UnaryServerInterceptor)
+		stream = append(stream, s.rateLimit.StreamServerInterceptor)
+	}
+
+	srv := grpcgo.NewServer(
+		grpcgo.ChainUnaryInterceptor(unary...),
+		grpcgo.ChainStreamInterceptor(stream...))
+	registryv1.RegisterGatewayServiceServer(srv, &gatewayService{s: s})
+	registryv1.RegisterMapperServiceServer(srv, &mapperService{s: s})
+	registryv1.RegisterRouterServiceServer(srv, &routerService{s: s})
+	reflection.Register(srv)
+
+	go func() {
+		s.log.WithField("addr", s.listenAddress).Info("start gRPC API service")
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpcgo.ErrServerStopped) {
+			s.log.WithError(err).Error("gRPC service crashed")
+			stopped <- err
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		// watch streams only end when their client goes away, don't wait
+		// for them longer than for HTTP requests
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(30 * time.Second):
+			srv.Stop()
+		}
+		stopped <- nil
+	}()
+
+	return stopped
+}
+
+// Run serves the gRPC API until ctx expires.
+func Run(ctx context.Context) error {
+	srv, err := NewServer()
+	if err != nil {
+		return err
+	}
+
+	return <-srv.Serve(ctx)
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/grpc/registryv1"
+	"github.com/ThingsIXFoundation/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mapperService struct {
+	registryv1.UnimplementedMapperServiceServer
+	s *Server
+}
+
+func (ms *mapperService) GetMapper(ctx context.Context, req *registryv1.GetMapperRequest) (*registryv1.GetMapperResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	mapper, err := ms.s.mapperStore.Get(ctx, id)
+	if err != nil {
+		return nil, ms.s.storeError(ctx, err, "error while getting mapper details")
+	}
+
+	return &registryv1.GetMapperResponse{Mapper: mapperToProto(mapper)}, nil
+}
+
+func (ms *mapperService) ListOwnedMappers(ctx context.Context, req *registryv1.ListOwnedMappersRequest) (*registryv1.ListOwnedMappersResponse, error) {
+	owner, err := addressFromBytes(req.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	mappers, cursor, err := ms.s.mapperStore.GetByOwner(ctx, owner, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, ms.s.storeError(ctx, err, "unable to retrieve mappers from DB")
+	}
+	mappers, cursor = trimPage(mappers, cursor, pageSize)
+
+	return &registryv1.ListOwnedMappersResponse{
+		Mappers:    mappersToProto(mappers),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (ms *mapperService) ListMapperEvents(ctx context.Context, req *registryv1.ListMapperEventsRequest) (*registryv1.ListMapperEventsResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	events, cursor, err := ms.s.mapperStore.GetEvents(ctx, id, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, ms.s.storeError(ctx, err, "unable to retrieve mapper events from DB")
+	}
+	events, cursor = trimPage(events, cursor, pageSize)
+
+	return &registryv1.ListMapperEventsResponse{
+		Events:     mapperEventsToProto(events),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (ms *mapperService) GetMapperSnapshot(ctx context.Context, req *registryv1.GetMapperSnapshotRequest) (*registryv1.GetMapperSnapshotResponse, error) {
+	// read the block first, mappers that change while reading them are sent
+	// again by a watch from this block
+	currentBlock, err := ms.s.mapperStore.CurrentBlock(ctx, "MapperAggregator")
+	if err != nil {
+		return nil, ms.s.storeError(ctx, err, "error while getting sync state")
+	}
+
+	mappers, err := ms.s.mapperStore.GetAll(ctx)
+	if err != nil {
+		return nil, ms.s.storeError(ctx, err, "error while getting mappers")
+	}
+
+	return &registryv1.GetMapperSnapshotResponse{
+		BlockNumber: currentBlock,
+		Mappers:     mappersToProto(mappers),
+	}, nil
+}
+
+func (ms *mapperService) WatchMapperEvents(req *registryv1.WatchMapperEventsRequest, stream registryv1.MapperService_WatchMapperEventsServer) error {
+	return watch[*types.MapperEvent](stream.Context(), ms.s, ms.s.mapperStore, "MapperAggregator", req.GetFromBlock(),
+		func(to uint64, events []*types.MapperEvent) error {
+			return stream.Send(&registryv1.WatchMapperEventsResponse{
+				BlockNumber: to,
+				Events:      mapperEventsToProto(events),
+			})
+		})
+}
+
+func mapperToProto(mapper *types.Mapper) *registryv1.Mapper {
+	return &registryv1.Mapper{
+		Id:            mapper.ID[:],
+		Contract:      mapper.ContractAddress.Bytes(),
+		Revision:      uint32(mapper.Revision),
+		FrequencyPlan: string(mapper.FrequencyPlan),
+		Owner:         addressToProto(mapper.Owner),
+		Active:        mapper.Active,
+	}
+}
+
+func mappersToProto(mappers []*types.Mapper) []*registryv1.Mapper {
+	pb := make([]*registryv1.Mapper, len(mappers))
+	for i, mapper := range mappers {
+		pb[i] = mapperToProto(mapper)
+	}
+	return pb
+}
+
+var mapperEventTypes = map[types.MapperEventType]registryv1.MapperEventType{
+	types.MapperRegisteredEvent: registryv1.MapperEventType_MAPPER_EVENT_TYPE_REGISTER,
+	types.MapperOnboardedEvent:  registryv1.MapperEventType_MAPPER_EVENT_TYPE_ONBOARD,
+	types.MapperClaimedEvent:    registryv1.MapperEventType_MAPPER_EVENT_TYPE_CLAIM,
+	types.MapperRemovedEvent:    registryv1.MapperEventType_MAPPER_EVENT_TYPE_REMOVE,
+	types.MapperDeactivated:     registryv1.MapperEventType_MAPPER_EVENT_TYPE_DEACTIVATE,
+	types.MapperActivated:       registryv1.MapperEventType_MAPPER_EVENT_TYPE_ACTIVATE,
+	types.MapperTransfered:      registryv1.MapperEventType_MAPPER_EVENT_TYPE_TRANSFER,
+}
+
+func mapperEventToProto(event *types.MapperEvent) *registryv1.MapperEvent {
+	return &registryv1.MapperEvent{
+		Contract:         event.ContractAddress.Bytes(),
+		Block:            event.Block.Bytes(),
+		BlockNumber:      event.BlockNumber,
+		Transaction:      event.Transaction.Bytes(),
+		TransactionIndex: uint32(event.TransactionIndex),
+		LogIndex:         uint32(event.LogIndex),
+		Type:             mapperEventTypes[event.Type],
+		MapperId:         event.ID[:],
+		Revision:         uint32(event.Revision),
+		FrequencyPlan:    string(event.FrequencyPlan),
+		NewOwner:         addressToProto(event.NewOwner),
+		OldOwner:         addressToProto(event.OldOwner),
+		Time:             timestamppb.New(event.Time),
+	}
+}
+
+func mapperEventsToProto(events []*types.MapperEvent) []*registryv1.MapperEvent {
+	pb := make([]*registryv1.MapperEvent, len(events))
+	for i, event := range events {
+		pb[i] = mapperEventToProto(event)
+	}
+	return pb
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/grpc/registryv1"
+	"github.com/ThingsIXFoundation/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type routerService struct {
+	registryv1.UnimplementedRouterServiceServer
+	s *Server
+}
+
+func (rs *routerService) GetRouter(ctx context.Context, req *registryv1.GetRouterRequest) (*registryv1.GetRouterResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := rs.s.routerStore.Get(ctx, id)
+	if err != nil {
+		return nil, rs.s.storeError(ctx, err, "error while getting router details")
+	}
+
+	return &registryv1.GetRouterResponse{Router: routerToProto(router)}, nil
+}
+
+func (rs *routerService) ListOwnedRouters(ctx context.Context, req *registryv1.ListOwnedRoutersRequest) (*registryv1.ListOwnedRoutersResponse, error) {
+	owner, err := addressFromBytes(req.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	routers, cursor, err := rs.s.routerStore.GetByOwner(ctx, owner, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, rs.s.storeError(ctx, err, "unable to retrieve routers from DB")
+	}
+	routers, cursor = trimPage(routers, cursor, pageSize)
+
+	return &registryv1.ListOwnedRoutersResponse{
+		Routers:    routersToProto(routers),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (rs *routerService) ListRouterEvents(ctx context.Context, req *registryv1.ListRouterEventsRequest) (*registryv1.ListRouterEventsResponse, error) {
+	id, err := idFromBytes(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pageSize(req.GetPageSize())
+	events, cursor, err := rs.s.routerStore.GetEvents(ctx, id, pageSize, req.GetCursor())
+	if err != nil {
+		return nil, rs.s.storeError(ctx, err, "unable to retrieve router events from DB")
+	}
+	events, cursor = trimPage(events, cursor, pageSize)
+
+	return &registryv1.ListRouterEventsResponse{
+		Events:     routerEventsToProto(events),
+		NextCursor: cursor,
+	}, nil
+}
+
+func (rs *routerService) GetRouterSnapshot(ctx context.Context, req *registryv1.GetRouterSnapshotRequest) (*registryv1.GetRouterSnapshotResponse, error) {
+	// read the block first, routers that change while reading them are sent
+	// again by a watch from this block
+	currentBlock, err := rs.s.routerStore.CurrentBlock(ctx, "RouterAggregator")
+	if err != nil {
+		return nil, rs.s.storeError(ctx, err, "error while getting sync state")
+	}
+
+	routers, err := rs.s.routerStore.GetAll(ctx)
+	if err != nil {
+		return nil, rs.s.storeError(ctx, err, "error while getting routers")
+	}
+
+	return &registryv1.GetRouterSnapshotResponse{
+		BlockNumber: currentBlock,
+		ChainId:     rs.s.chainID,
+		Routers:     routersToProto(routers),
+	}, nil
+}
+
+func (rs *routerService) WatchRouterEvents(req *registryv1.WatchRouterEventsRequest, stream registryv1.RouterService_WatchRouterEventsServer) error {
+	return watch[*types.RouterEvent](stream.Context(), rs.s, rs.s.routerStore, "RouterAggregator", req.GetFromBlock(),
+		func(to uint64, events []*types.RouterEvent) error {
+			return stream.Send(&registryv1.WatchRouterEventsResponse{
+				BlockNumber: to,
+				Events:      routerEventsToProto(events),
+			})
+		})
+}
+
+func routerToProto(router *types.Router) *registryv1.Router {
+	return &registryv1.Router{
+		Id:            router.ID[:],
+		Contract:      router.ContractAddress.Bytes(),
+		Owner:         router.Owner.Bytes(),
+		NetId:         router.NetID,
+		Prefix:        router.Prefix,
+		Mask:          uint32(router.Mask),
+		FrequencyPlan: string(router.FrequencyPlan),
+		Endpoint:      router.Endpoint,
+	}
+}
+
+func routersToProto(routers []*types.Router) []*registryv1.Router {
+	pb := make([]*registryv1.Router, len(routers))
+	for i, router := range routers {
+		pb[i] = routerToProto(router)
+	}
+	return pb
+}
+
+var routerEventTypes = map[types.RouterEventType]registryv1.RouterEventType{
+	types.RouterRegisteredEvent: registryv1.RouterEventType_ROUTER_EVENT_TYPE_REGISTER,
+	types.RouterUpdatedEvent:    registryv1.RouterEventType_ROUTER_EVENT_TYPE_UPDATE,
+	types.RouterRemovedEvent:    registryv1.RouterEventType_ROUTER_EVENT_TYPE_REMOVE,
+}
+
+func routerEventToProto(event *types.RouterEvent) *registryv1.RouterEvent {
+	return &registryv1.RouterEvent{
+		Contract:         event.ContractAddress.Bytes(),
+		Block:            event.Block.Bytes(),
+		BlockNumber:      event.BlockNumber,
+		Transaction:      event.Transaction.Bytes(),
+		TransactionIndex: uint32(event.TransactionIndex),
+		LogIndex:         uint32(event.LogIndex),
+		Type:             routerEventTypes[event.Type],
+		RouterId:         event.ID[:],
+		Owner:            addressToProto(event.Owner),
+		NewNetId:         event.NewNetID,
+		OldNetId:         event.OldNetID,
+		NewPrefix:        event.NewPrefix,
+		OldPrefix:        event.OldPrefix,
+		NewMask:          uint32(event.NewMask),
+		OldMask:          uint32(event.OldMask),
+		NewFrequencyPlan: string(event.NewFrequencyPlan),
+		OldFrequencyPlan: string(event.OldFrequencyPlan),
+		NewEndpoint:      event.NewEndpoint,
+		OldEndpoint:      event.OldEndpoint,
+		Time:             timestamppb.New(event.Time),
+	}
+}
+
+func routerEventsToProto(events []*types.RouterEvent) []*registryv1.RouterEvent {
+	pb := make([]*registryv1.RouterEvent, len(events))
+	for i, event := range events {
+		pb[i] = routerEventToProto(event)
+	}
+	return pb
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// requestTimeout bounds unary calls for which the client didn't set a
+	// shorter deadline.
+	requestTimeout = 15 * time.Second
+
+	defaultPageSize = 15
+	maxPageSize     = 100
+)
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (resp interface{}, err error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.WithField("method", info.FullMethod).WithField("panic", r).Error("gRPC handler panicked")
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpcgo.ServerStream, info *grpcgo.StreamServerInfo, handler grpcgo.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.WithField("method", info.FullMethod).WithField("panic", r).Error("gRPC handler panicked")
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// storeError converts an error returned by a store to a gRPC status.
+func (s *Server) storeError(ctx context.Context, err error, msg string) error {
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return status.Error(codes.NotFound, "not found")
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+
+	method, _ := grpcgo.Method(ctx)
+	s.log.WithError(err).WithField("method", method).Error(msg)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func idFromBytes(b []byte) (types.ID, error) {
+	var id types.ID
+	if len(b) != len(id) {
+		return id, status.Errorf(codes.InvalidArgument, "id must be %d bytes", len(id))
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+func addressFromBytes(b []byte) (common.Address, error) {
+	if len(b) != common.AddressLength {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "address must be %d bytes", common.AddressLength)
+	}
+	return common.BytesToAddress(b), nil
+}
+
+// pageSize returns the requested page size, bounded by maxPageSize.
+func pageSize(requested int32) int {
+	if requested <= 0 {
+		return defaultPageSize
+	}
+	if requested > maxPageSize {
+		return maxPageSize
+	}
+	return int(requested)
+}
+
+// trimPage returns at most pageSize items and the cursor to the next page,
+// the stores return an item more than the page size when there is one.
+func trimPage[T any](items []T, cursor string, pageSize int) ([]T, string) {
+	if len(items) <= pageSize {
+		return items, ""
+	}
+	return items[:pageSize], cursor
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchMaxBlockRange is the maximum number of blocks of events sent in a
+// single watch message.
+const watchMaxBlockRange = 10_000
+
+// eventStore is the part of a registry store that is needed to watch its
+// events.
+type eventStore[E any] interface {
+	CurrentBlock(ctx context.Context, process string) (uint64, error)
+	EventsFromTo(ctx context.Context, from, to uint64) ([]E, error)
+}
+
+// watch sends the events from block from up to the block the aggregator
+// process is at, and keeps sending newly aggregated events until ctx expires.
+// Send is called every time the aggregator progressed, also when there are
+// no new events, so clients can store the block to resume from. Watches that
+// start more than maxBackfillBlocks behind the aggregator are rejected, the
+// client must resync through the unary calls instead.
+func watch[E any](ctx context.Context, s *Server, store eventStore[E], process string, from uint64, send func(to uint64, events []E) error) error {
+	ticker := time.NewTicker(s.watchPollInterval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		current, err := store.CurrentBlock(ctx, process)
+		if err != nil {
+			return s.storeError(ctx, err, "error while getting sync state")
+		}
+		if first && current > from && current-from > s.maxBackfillBlocks {
+			return status.Errorf(codes.OutOfRange, "from_block %d is more than %d blocks behind block %d, resync and watch from a recent block", from, s.maxBackfillBlocks, current)
+		}
+
+		// the aggregator has processed all events before current
+		for from < current {
+			to := current
+			if to-from > watchMaxBlockRange {
+				to = from + watchMaxBlockRange
+			}
+
+			events, err := store.EventsFromTo(ctx, from, to)
+			if err != nil {
+				return s.storeError(ctx, err, "error while getting events")
+			}
+			if err := send(to, events); err != nil {
+				return err
+			}
+			from = to
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package openapi describes the HTTP API of the gateway, mapper, router,
+// mapping and rewards registries in an OpenAPI 3 document and validates
+// requests and responses against it.
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/go-chi/chi/v5"
+	"github.com/spf13/viper"
+)
+
+// Options configure the OpenAPI document service and validation.
+type Options struct {
+	// ValidateRequests rejects requests to documented endpoints that don't
+	// match the document.
+	ValidateRequests bool
+	// ValidateResponses replaces responses of documented endpoints that don't
+	// match the document with an error. Responses are buffered to validate
+	// them, this is meant for tests.
+	ValidateResponses bool
+}
+
+// OpenAPI serves the OpenAPI document and validates requests and responses
+// against it.
+type OpenAPI struct {
+	raw               []byte
+	router            routers.Router
+	validateRequests  bool
+	validateResponses bool
+}
+
+// New creates an OpenAPI with the given options.
+func New(opts Options) (*OpenAPI, error) {
+	doc, err := Document()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode OpenAPI document: %w", err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to route OpenAPI document: %w", err)
+	}
+
+	return &OpenAPI{
+		raw:               raw,
+		router:            router,
+		validateRequests:  opts.ValidateRequests,
+		validateResponses: opts.ValidateResponses,
+	}, nil
+}
+
+// NewOpenAPI creates an OpenAPI from the config.
+func NewOpenAPI() (*OpenAPI, error) {
+	return New(Options{
+		ValidateRequests:  viper.GetBool(config.CONFIG_API_OPENAPI_VALIDATE_REQUESTS),
+		ValidateResponses: viper.GetBool(config.CONFIG_API_OPENAPI_VALIDATE_RESPONSES),
+	})
+}
+
+// Document builds the OpenAPI document. The schemas are derived from the
+// types the handlers encode and decode so they can't drift apart.
+func Document() (*openapi3.T, error) {
+	var (
+		schemas = newSchemas()
+		doc     = &openapi3.T{
+			OpenAPI: "3.0.3",
+			Info: &openapi3.Info{
+				Title:       "ThingsIX data aggregator",
+				Description: "Gateways, mappers, routers, mappings, coverage, rewards and network statistics of the ThingsIX registries.",
+				Version:     utils.Version(),
+				License: &openapi3.License{
+					Name: "Apache-2.0",
+					URL:  "https://www.apache.org/licenses/LICENSE-2.0",
+				},
+			},
+			Paths: openapi3.Paths{},
+		}
+	)
+
+	for _, op := range operations {
+		operation, err := op.build(schemas)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe %s %s: %w", op.Method, op.Path, err)
+		}
+		doc.AddOperation(op.Path, op.Method, operation)
+	}
+	doc.Components = &openapi3.Components{Schemas: schemas.components}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (op *operation) build(schemas *schemas) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.OperationID = op.ID
+	operation.Summary = op.Summary
+	operation.Description = op.Description
+	operation.Tags = []string{op.Tag}
+
+	for _, param := range op.Parameters {
+		operation.AddParameter(param)
+	}
+	if op.GeoJSON {
+		operation.AddParameter(queryParam("format", "format of the reply, geojson is the same as accepting application/geo+json",
+			openapi3.NewStringSchema().WithEnum(string(utils.FormatJSON), string(utils.FormatGeoJSON))))
+	}
+
+	if op.Request != nil {
+		body, err := schemas.of(op.Request.Body)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(op.Request.Required).WithJSONSchemaRef(body),
+		}
+	}
+
+	// handlers reply errors with http.Error
+	operation.Responses = openapi3.Responses{
+		"default": &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Error, the body describes the problem").
+				WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/plain"})),
+		},
+	}
+	for _, resp := range op.Responses {
+		response := openapi3.NewResponse().WithDescription(resp.Description)
+		if resp.ContentType != "" {
+			response.WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema().WithFormat("binary"), []string{resp.ContentType}))
+		} else if resp.Body != nil {
+			body, err := schemas.of(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Nullable {
+				body = nullable(body)
+			}
+			response.WithJSONSchemaRef(body)
+			if op.GeoJSON {
+				features, err := schemas.of(geo.FeatureCollection{})
+				if err != nil {
+					return nil, err
+				}
+				response.Content[geo.GeoJSONContentType] = openapi3.NewMediaType().WithSchemaRef(features)
+			}
+		}
+		operation.AddResponse(resp.Status, response)
+	}
+
+	return operation, nil
+}
+
+func (o *OpenAPI) Bind(root *chi.Mux) error {
+	root.Get("/openapi.json", o.ServeDocument)
+	return nil
+}
+
+// ServeDocument serves the OpenAPI document.
+func (o *OpenAPI) ServeDocument(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(o.raw)
+}
@@ -0,0 +1,624 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/activity"
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/api/tiles"
+	gatewayapi "github.com/ThingsIXFoundation/data-aggregator/gateway/api"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	webhookapi "github.com/ThingsIXFoundation/data-aggregator/webhook/api"
+	webhookmodels "github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// operation describes an endpoint, the request and response bodies are
+// described by (zero) values of the Go types that are (un)marshalled.
+type operation struct {
+	Method      string
+	Path        string
+	ID          string
+	Tag         string
+	Summary     string
+	Parameters  []*openapi3.Parameter
+	Request     *request
+	Responses   []response
+	Description string
+	// GeoJSON is set for endpoints that reply a GeoJSON feature collection
+	// instead when asked for with the Accept header or format parameter.
+	GeoJSON bool
+}
+
+// request is a JSON request body.
+type request struct {
+	Body     interface{}
+	Required bool
+}
+
+type response struct {
+	Status      int
+	Description string
+	// Body is nil for responses without content.
+	Body interface{}
+	// Nullable is set when the handler replies null when nothing is found.
+	Nullable bool
+	// ContentType is set for binary bodies, Body is then ignored.
+	ContentType string
+}
+
+var (
+	ownerParam     = pathParam("owner", "address of the owner", addressSchema())
+	onboarderParam = pathParam("onboarder", "address of the onboarder contract", addressSchema())
+	accountParam   = pathParam("account", "address of the account", addressSchema())
+	idParam        = pathParam("id", "id of the gateway, mapper or mapping record", idSchema())
+	gatewayIDParam = pathParam("gatewayID", "id of the gateway", idSchema())
+	mapperIDParam  = pathParam("mapperID", "id of the mapper", idSchema())
+	hexParam       = pathParam("hex", "h3 cell index in hex", cellSchema())
+	dateParam      = pathParam("date", "date formatted as YYYY-MM-DD", dateSchema())
+	webhookIDParam = pathParam("id", "id of the webhook", openapi3.NewStringSchema().WithPattern(`^[0-9a-f]{32}$`))
+	// webhookTimestampParam and webhookSignatureParam carry the owner signature
+	// of webhook requests without a body.
+	webhookTimestampParam = headerParam(webhookapi.TimestampHeader, "unix time the request was signed at", openapi3.NewInt64Schema())
+	webhookSignatureParam = headerParam(webhookapi.SignatureHeader, "wallet signature of the owner", openapi3.NewStringSchema())
+
+	cursorParam   = queryParam("cursor", "cursor of the page to fetch, as returned with the previous page", openapi3.NewStringSchema())
+	pageSizeParam = queryParam("pageSize", "maximum number of items to return, defaults to 15 and is capped at 100", openapi3.NewIntegerSchema().WithMin(0))
+	startParam    = queryParam("start", "first date, formatted as YYYY-MM-DD or as an offset in days from the end, defaults to 30 days before the end", dateOrOffsetSchema())
+	endParam      = queryParam("end", "last date, formatted as YYYY-MM-DD or as an offset in days from today, defaults to today", dateOrOffsetSchema())
+
+	activityTypeParam = queryParam("type", "type of activity to return, repeat to select several types, defaults to all types",
+		openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema().WithEnum(stringsToInterfaces(activityTypes())...)))
+)
+
+func pathParam(name, description string, schema *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewPathParameter(name).WithDescription(description).WithSchema(schema)
+}
+
+func queryParam(name, description string, schema *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewQueryParameter(name).WithDescription(description).WithSchema(schema)
+}
+
+func headerParam(name, description string, schema *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewHeaderParameter(name).WithDescription(description).WithRequired(true).WithSchema(schema)
+}
+
+// nearbyParams are the location and frequency plan parameters of the nearby
+// gateway endpoints followed by the given parameters.
+func nearbyParams(params ...*openapi3.Parameter) []*openapi3.Parameter {
+	return append([]*openapi3.Parameter{
+		queryParam("lat", "latitude of the location in degrees", openapi3.NewFloat64Schema().WithMin(-90).WithMax(90)),
+		queryParam("lon", "longitude of the location in degrees", openapi3.NewFloat64Schema().WithMin(-180).WithMax(180)),
+		queryParam("cell", "h3 cell index in hex of the location, instead of lat and lon", cellSchema()),
+		queryParam("frequencyPlan", "only return gateways with this frequency plan", openapi3.NewStringSchema()),
+	}, params...)
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	ret := make([]interface{}, len(values))
+	for i, v := range values {
+		ret[i] = v
+	}
+	return ret
+}
+
+func activityTypes() []string {
+	var types []string
+	for _, t := range activity.Types() {
+		types = append(types, string(t))
+	}
+	return types
+}
+
+func euiSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^(0[xX])?[0-9a-fA-F]{2}([:-]?[0-9a-fA-F]{2}){7}$`)
+}
+
+func dateOrOffsetSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^([0-9]{4}-[0-9]{2}-[0-9]{2}|-?[0-9]+)$`)
+}
+
+func ok(body interface{}) []response {
+	return []response{{Status: http.StatusOK, Description: "OK", Body: body}}
+}
+
+func okOrNull(body interface{}) []response {
+	return []response{{Status: http.StatusOK, Description: "OK, null when not found", Body: body, Nullable: true}}
+}
+
+// conditional adds the reply of endpoints that support If-None-Match.
+func conditional(responses []response) []response {
+	return append(responses, response{Status: http.StatusNotModified, Description: "Not modified, the copy with the given ETag is current"})
+}
+
+func jsonBody(body interface{}, required bool) *request {
+	return &request{Body: body, Required: required}
+}
+
+// operations are all endpoints of the gateway, mapper, router, mapping,
+// rewards and status APIs. Keep them in sync with the routes bound by these
+// APIs.
+var operations = []operation{
+	// gateways
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/owned/{owner}", ID: "ownedGateways", Tag: "gateways",
+		Summary:    "List the gateways of an owner",
+		Parameters: []*openapi3.Parameter{ownerParam, cursorParam, pageSizeParam},
+		Responses:  conditional(ok(apitypes.GatewaysResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/search", ID: "searchGateways", Tag: "gateways",
+		Summary: "Search gateways by their attributes",
+		Description: "Every filter that is set must match, ranges include their bounds except for onboardedTo. " +
+			"A page can hold fewer gateways than the page size while there are more results, continue until the cursor is empty. " +
+			"Region searches sorted on id are ordered by location first.",
+		Parameters: []*openapi3.Parameter{
+			queryParam("frequencyPlan", "frequency plan of the gateway", openapi3.NewStringSchema()),
+			queryParam("minAntennaGain", "minimum antenna gain in dBi", openapi3.NewFloat64Schema()),
+			queryParam("maxAntennaGain", "maximum antenna gain in dBi", openapi3.NewFloat64Schema()),
+			queryParam("minAltitude", "minimum altitude in meters", openapi3.NewIntegerSchema().WithMin(0)),
+			queryParam("maxAltitude", "maximum altitude in meters", openapi3.NewIntegerSchema().WithMin(0)),
+			queryParam("version", "version of the gateway registry the gateway was onboarded with", openapi3.NewIntegerSchema().WithMin(0).WithMax(255)),
+			queryParam("owner", "address of an owner, repeat to select the gateways of several owners", openapi3.NewArraySchema().WithItems(addressSchema())),
+			queryParam("region", "h3 cell index in hex of any resolution the gateway is located in", cellSchema()),
+			queryParam("onboardedFrom", "first onboard time, formatted as YYYY-MM-DD or RFC 3339", openapi3.NewStringSchema()),
+			queryParam("onboardedTo", "onboard time the gateways are onboarded before, formatted as YYYY-MM-DD or RFC 3339", openapi3.NewStringSchema()),
+			queryParam("eui", "EUI of the gateway, the local id of its onboard message", euiSchema()),
+			queryParam("sort", "property to sort on, prefixed with - to sort descending, defaults to id", openapi3.NewStringSchema().WithEnum(
+				"id", "-id", "antennaGain", "-antennaGain", "altitude", "-altitude", "version", "-version")),
+			cursorParam, pageSizeParam,
+		},
+		Responses: conditional(ok(apitypes.GatewaysResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/nearest", ID: "nearestGateways", Tag: "gateways",
+		Summary:     "List the gateways nearest to a location",
+		Description: "The location is given by lat and lon or by the centre of an h3 cell. Distances are great-circle distances in meters to the centre of the cell the gateway is located in.",
+		Parameters: nearbyParams(
+			queryParam("k", "number of gateways to return, defaults to 10 and is at most 100", openapi3.NewIntegerSchema().WithMin(1).WithMax(gatewayapi.MaxNearestCount)),
+			queryParam("maxRadius", "distance in meters to search within, defaults to 50 km and is at most 200 km", openapi3.NewFloat64Schema().WithMin(0).WithMax(gatewayapi.MaxNearbyRadius)),
+		),
+		Responses: conditional(ok(apitypes.NearbyGatewaysResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/radius", ID: "gatewaysInRadius", Tag: "gateways",
+		Summary:     "List the gateways within a radius around a location, nearest first",
+		Description: "The location is given by lat and lon or by the centre of an h3 cell. Distances are great-circle distances in meters to the centre of the cell the gateway is located in.",
+		Parameters: nearbyParams(
+			queryParam("radius", "distance in meters, at most 200 km", openapi3.NewFloat64Schema().WithMin(0).WithMax(gatewayapi.MaxNearbyRadius)).WithRequired(true),
+		),
+		Responses: conditional(ok(apitypes.NearbyGatewaysResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/local/{eui}", ID: "gatewayByLocalID", Tag: "gateways",
+		Summary:     "Get a gateway by its EUI",
+		Description: "The EUI is the local id of the onboard message whose signature the gateway is onboarded with, an EUI keeps mapping to the first gateway while it is onboarded. It is written as 16 hex digits, optionally separated by : or -.",
+		Parameters:  []*openapi3.Parameter{pathParam("eui", "EUI of the gateway", euiSchema())},
+		Responses:   conditional(ok(types.Gateway{})),
+	},
+	{
+		Method: http.MethodPost, Path: "/gateways/v1/batch", ID: "gatewaysBatch", Tag: "gateways",
+		Summary:     "Get multiple gateways by id",
+		Description: "At most 100 ids are looked up at once, the ids of gateways that don't exist are listed as missing.",
+		Request:     jsonBody(utils.BatchRequest{}, true),
+		Responses:   ok(apitypes.GatewaysBatchResponse{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/{id}", ID: "gatewayDetails", Tag: "gateways",
+		Summary:    "Get a gateway",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  conditional(ok(types.Gateway{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/{id}/list", ID: "gatewayList", Tag: "gateways",
+		Summary:    "Get a gateway as a list with zero or one gateway",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  conditional(ok(apitypes.GatewaysResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/{id}/events", ID: "gatewayEvents", Tag: "gateways",
+		Summary:    "List the confirmed events of a gateway, newest first",
+		Parameters: []*openapi3.Parameter{idParam, cursorParam, pageSizeParam},
+		Responses:  conditional(ok(apitypes.GatewayEventsResponse{})),
+	},
+	{
+		Method: http.MethodPost, Path: "/gateways/v1/events/owner/{owner}/pending", ID: "pendingGatewayEvents", Tag: "gateways",
+		Summary:    "List the pending gateway events of an owner",
+		Parameters: []*openapi3.Parameter{ownerParam},
+		Request:    jsonBody([]types.GatewayEventType{}, false),
+		Responses:  ok(apitypes.PendingGatewayEventsResponse{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/frequencyplan/all", ID: "supportedFrequencyPlans", Tag: "gateways",
+		Summary:   "List the supported frequency plans",
+		Responses: conditional(ok([]types.FrequencyPlan{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/frequencyplan/{hex}", ID: "frequencyPlansAtLocation", Tag: "gateways",
+		Summary:    "List the frequency plans that are valid in a resolution 10 cell",
+		Parameters: []*openapi3.Parameter{hexParam},
+		Responses:  conditional(ok(apitypes.ValidFrequencyPlansForLocation{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/map/res0", ID: "gatewayMapRes0", Tag: "gateways",
+		Summary:   "Count the gateways per resolution 3 cell grouped by resolution 0 cell",
+		Responses: conditional(ok(apitypes.Res0GatewayHex{})),
+		GeoJSON:   true,
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/map/{hex}", ID: "gatewayMap", Tag: "gateways",
+		Summary:     "Count or list the gateways in a cell",
+		Description: "Counts the gateways per child cell 3 resolutions below the given cell, from resolution 7 on the gateways are included.",
+		Parameters:  []*openapi3.Parameter{hexParam},
+		Responses:   conditional(ok(apitypes.GatewayHex{})),
+		GeoJSON:     true,
+	},
+	{
+		Method: http.MethodPost, Path: "/gateways/v1/onboards/{onboarder}/{owner}", ID: "createGatewayOnboard", Tag: "gateways",
+		Summary:     "Store a signed gateway onboard message",
+		Description: "The local id is bound to the signature it is first stored with, it can't be changed by storing the signature again.",
+		Parameters:  []*openapi3.Parameter{onboarderParam, ownerParam},
+		Request:     jsonBody(apitypes.CreateGatewayOnboardRequest{}, true),
+		Responses: []response{
+			{Status: http.StatusCreated, Description: "Created"},
+			{Status: http.StatusConflict, Description: "The signature is already stored with another local id"},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/onboards/{onboarder}/{owner}", ID: "gatewayOnboardsByOwner", Tag: "gateways",
+		Summary:    "List the gateway onboard messages of an owner",
+		Parameters: []*openapi3.Parameter{onboarderParam, ownerParam, cursorParam, pageSizeParam},
+		Responses:  ok(apitypes.GatewayOnboardsResponse{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/gateways/v1/onboards/{gatewayID}", ID: "gatewayOnboard", Tag: "gateways",
+		Summary:    "Get the onboard message of a gateway",
+		Parameters: []*openapi3.Parameter{gatewayIDParam},
+		Responses:  ok(apitypes.GatewayOnboard{}),
+	},
+
+	// mappers
+	{
+		Method: http.MethodGet, Path: "/mappers/v1/owned/{owner}", ID: "ownedMappers", Tag: "mappers",
+		Summary:    "List the mappers of an owner",
+		Parameters: []*openapi3.Parameter{ownerParam, cursorParam, pageSizeParam},
+		Responses:  conditional(ok(apitypes.MappersResponse{})),
+	},
+	{
+		Method: http.MethodPost, Path: "/mappers/v1/batch", ID: "mappersBatch", Tag: "mappers",
+		Summary:     "Get multiple mappers by id",
+		Description: "At most 100 ids are looked up at once, the ids of mappers that don't exist are listed as missing.",
+		Request:     jsonBody(utils.BatchRequest{}, true),
+		Responses:   ok(apitypes.MappersBatchResponse{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/mappers/v1/{id}", ID: "mapperDetails", Tag: "mappers",
+		Summary:    "Get a mapper",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  conditional(ok(types.Mapper{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/mappers/v1/{id}/list", ID: "mapperList", Tag: "mappers",
+		Summary:    "Get a mapper as a list with zero or one mapper",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  conditional(ok(apitypes.MappersResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/mappers/v1/{id}/events", ID: "mapperEvents", Tag: "mappers",
+		Summary:    "List the confirmed events of a mapper, newest first",
+		Parameters: []*openapi3.Parameter{idParam, cursorParam, pageSizeParam},
+		Responses:  conditional(ok(apitypes.MapperEventsResponse{})),
+	},
+	{
+		Method: http.MethodPost, Path: "/mappers/v1/events/owner/{owner}/pending", ID: "pendingMapperEvents", Tag: "mappers",
+		Summary:    "List the pending mapper events of an owner",
+		Parameters: []*openapi3.Parameter{ownerParam},
+		Request:    jsonBody([]types.MapperEventType{}, false),
+		Responses:  ok(apitypes.PendingMapperEventsResponse{}),
+	},
+
+	// routers
+	{
+		Method: http.MethodGet, Path: "/routers/v1/snapshot", ID: "routerSnapshot", Tag: "routers",
+		Summary:   "List all registered routers",
+		Responses: conditional(ok(apitypes.RouterSnapshotResponse{})),
+	},
+	{
+		Method: http.MethodPost, Path: "/routers/v1/batch", ID: "routersBatch", Tag: "routers",
+		Summary:     "Get multiple routers by id",
+		Description: "At most 100 ids are looked up at once, the ids of routers that don't exist are listed as missing.",
+		Request:     jsonBody(utils.BatchRequest{}, true),
+		Responses:   ok(apitypes.RoutersBatchResponse{}),
+	},
+
+	// mapping
+	{
+		Method: http.MethodGet, Path: "/mapping/v1/{id}", ID: "mapping", Tag: "mapping",
+		Summary:    "Get a mapping record",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  okOrNull(types.MappingRecord{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/mapping/v1/mapper/{id}/recent", ID: "recentMappings", Tag: "mapping",
+		Summary:     "List the mapping records of a mapper of the last day",
+		Description: "Records of the last hour are only included for the owner of the mapper, as identified by the code.",
+		Parameters: []*openapi3.Parameter{idParam, cursorParam, pageSizeParam,
+			queryParam("code", "code as returned by submitSignature", openapi3.NewStringSchema())},
+		Responses: ok(apitypes.MappingsResponse{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/mapping/v1/auth/challenge", ID: "createChallenge", Tag: "mapping",
+		Summary:   "Create a challenge for an owner to sign",
+		Request:   jsonBody(apitypes.ChallengeRequest{}, true),
+		Responses: ok(apitypes.ChallengeResponse{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/mapping/v1/auth/signature", ID: "submitSignature", Tag: "mapping",
+		Summary:   "Exchange a signed challenge for a code",
+		Request:   jsonBody(apitypes.SignatureRequest{}, true),
+		Responses: ok(apitypes.SignatureResponse{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/mapping/v1/auth/check", ID: "checkCode", Tag: "mapping",
+		Summary: "Check if a code belongs to the owner of a mapper",
+		Request: jsonBody(apitypes.CodeCheckRequest{}, true),
+		Responses: []response{
+			{Status: http.StatusOK, Description: "The code belongs to the owner of the mapper"},
+			{Status: http.StatusUnauthorized, Description: "The code doesn't belong to the owner of the mapper"},
+		},
+	},
+
+	// coverage
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/minmaxdate", ID: "minMaxCoverageDates", Tag: "coverage",
+		Summary:   "Get the first and last date coverage is known for",
+		Responses: conditional(ok(apitypes.MinMaxCoverageDates{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/map/{date}/res0/assumed", ID: "assumedCoverageMapRes0", Tag: "coverage",
+		Summary:    "List the resolution 6 cells with assumed coverage at a date",
+		Parameters: []*openapi3.Parameter{dateParam},
+		Responses:  conditional(ok(apitypes.AssumedCoverageHexContainer{})),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/map/{date}/{hex}/assumed", ID: "assumedCoverageMap", Tag: "coverage",
+		Summary:    "List the cells with assumed coverage in a cell at a date",
+		Parameters: []*openapi3.Parameter{dateParam, hexParam},
+		Responses:  conditional(ok(apitypes.AssumedCoverageHexContainer{})),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/map/{date}/{hex}/coverage", ID: "coverageMap", Tag: "coverage",
+		Summary:    "List the coverage in a cell at a date",
+		Parameters: []*openapi3.Parameter{dateParam, hexParam},
+		Responses:  conditional(ok(apitypes.CoverageHexContainer{})),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/gateway/{id}/{date}/assumed", ID: "assumedCoverageForGateway", Tag: "coverage",
+		Summary:    "List the cells a gateway is assumed to cover at a date",
+		Parameters: []*openapi3.Parameter{idParam, dateParam},
+		Responses:  conditional(ok(apitypes.AssumedCoverageHexContainer{})),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/coverage/v1/gateway/{id}/{date}/coverage", ID: "coverageForGateway", Tag: "coverage",
+		Summary:    "List the coverage of a gateway at a date",
+		Parameters: []*openapi3.Parameter{idParam, dateParam},
+		Responses:  conditional(ok(apitypes.CoverageHexContainer{})),
+		GeoJSON:    true,
+	},
+
+	// unverified mapping
+	{
+		Method: http.MethodPost, Path: "/unverifiedmapping/v1/chirpstack", ID: "storeUnverifiedMapping", Tag: "unverifiedmapping",
+		Summary:     "Store an unverified mapping from a ChirpStack HTTP integration",
+		Description: "Only up events are stored, other events are accepted and ignored.",
+		Parameters:  []*openapi3.Parameter{queryParam("event", "ChirpStack event type", openapi3.NewStringSchema())},
+		Request:     jsonBody(map[string]interface{}{}, true),
+		Responses: []response{
+			{Status: http.StatusOK, Description: "The event is ignored"},
+			{Status: http.StatusAccepted, Description: "The mapping is stored"},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/unverifiedmapping/v1/map/res0/assumed", ID: "assumedUnverifiedCoverageMapRes0", Tag: "unverifiedmapping",
+		Summary:   "List the resolution 6 cells with assumed unverified coverage",
+		Responses: ok(apitypes.AssumedCoverageHexContainer{}),
+		GeoJSON:   true,
+	},
+	{
+		Method: http.MethodGet, Path: "/unverifiedmapping/v1/map/{hex}/assumed", ID: "assumedUnverifiedCoverageMap", Tag: "unverifiedmapping",
+		Summary:    "List the cells with assumed unverified coverage in a cell",
+		Parameters: []*openapi3.Parameter{hexParam},
+		Responses:  ok(apitypes.AssumedCoverageHexContainer{}),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/unverifiedmapping/v1/map/{hex}/coverage", ID: "unverifiedCoverageMap", Tag: "unverifiedmapping",
+		Summary:    "List the unverified mappings in a cell",
+		Parameters: []*openapi3.Parameter{hexParam},
+		Responses:  ok(apitypes.UnverifiedCoverageHexContainer{}),
+		GeoJSON:    true,
+	},
+	{
+		Method: http.MethodGet, Path: "/unverifiedmapping/v1/{id}", ID: "unverifiedMapping", Tag: "unverifiedmapping",
+		Summary:    "Get an unverified mapping record",
+		Parameters: []*openapi3.Parameter{idParam},
+		Responses:  okOrNull(types.UnverifiedMappingRecord{}),
+	},
+
+	// rewards
+	{
+		Method: http.MethodGet, Path: "/rewards/v1/accounts/{account}/history", ID: "accountRewardsHistory", Tag: "rewards",
+		Summary:    "List the daily rewards of an account, newest first",
+		Parameters: []*openapi3.Parameter{accountParam, startParam, endParam},
+		Responses:  conditional(ok(apitypes.AccountRewardsHistoryResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/rewards/v1/accounts/{account}/cheque", ID: "latestCheque", Tag: "rewards",
+		Summary:    "Get the latest signed rewards cheque of an account",
+		Parameters: []*openapi3.Parameter{accountParam},
+		Responses:  ok(apitypes.RewardCheque{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/rewards/v1/accounts/{account}/latest", ID: "latestAccountRewards", Tag: "rewards",
+		Summary:    "Get the latest rewards of an account",
+		Parameters: []*openapi3.Parameter{accountParam},
+		Responses:  conditional(ok(types.AccountRewardHistory{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/rewards/v1/gateways/{gatewayID}/history", ID: "gatewayRewardsHistory", Tag: "rewards",
+		Summary:    "List the daily rewards of a gateway, newest first",
+		Parameters: []*openapi3.Parameter{gatewayIDParam, startParam, endParam},
+		Responses:  conditional(ok(apitypes.GatewayRewardsHistoryResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/rewards/v1/mappers/{mapperID}/history", ID: "mapperRewardsHistory", Tag: "rewards",
+		Summary:    "List the daily rewards of a mapper, newest first",
+		Parameters: []*openapi3.Parameter{mapperIDParam, startParam, endParam},
+		Responses:  conditional(ok(apitypes.MapperRewardsHistoryResponse{})),
+	},
+
+	// stats
+	{
+		Method: http.MethodGet, Path: "/stats/v1/network", ID: "networkStats", Tag: "stats",
+		Summary:     "List the network statistics per day, week or month, oldest first",
+		Description: "Event counts are totals over a period, the other counts are taken at the end of the period. Weeks start on Monday, a range spans at most 1098 days.",
+		Parameters: []*openapi3.Parameter{
+			queryParam("start", "first date, formatted as YYYY-MM-DD, defaults to 30 days, 26 weeks or 12 months before the end and is moved back to the start of its week or month", openapi3.NewStringSchema().WithFormat("date")),
+			queryParam("end", "last date, formatted as YYYY-MM-DD, defaults to the last day with statistics", openapi3.NewStringSchema().WithFormat("date")),
+			queryParam("granularity", "length of the periods, defaults to day", openapi3.NewStringSchema().WithEnum(stringsToInterfaces([]string{"day", "week", "month"})...)),
+		},
+		Responses: conditional(ok(apitypes.NetworkStatsResponse{})),
+	},
+	{
+		Method: http.MethodGet, Path: "/stats/v1/network/latest", ID: "latestNetworkStats", Tag: "stats",
+		Summary:   "Get the network statistics of the last day statistics are computed for",
+		Responses: conditional(ok(apitypes.NetworkStats{})),
+	},
+
+	// owners
+	{
+		Method: http.MethodGet, Path: "/owners/v1/{owner}", ID: "ownerPortfolio", Tag: "owners",
+		Summary:     "Get the gateways, mappers, pending events and rewards of an owner",
+		Description: "Parts that could not be fetched in time are left empty and listed in unavailable.",
+		Parameters:  []*openapi3.Parameter{ownerParam},
+		Responses:   ok(apitypes.OwnerResponse{}),
+	},
+
+	// activity
+	{
+		Method: http.MethodGet, Path: "/activity/v1", ID: "networkActivity", Tag: "activity",
+		Summary:    "List the gateway, mapper and router events and reward dates of the network, newest first",
+		Parameters: []*openapi3.Parameter{activityTypeParam, cursorParam, pageSizeParam},
+		Responses:  ok(apitypes.ActivityResponse{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/activity/v1/owners/{owner}", ID: "ownerActivity", Tag: "activity",
+		Summary:     "List the activity of the devices an owner owns or has owned and the rewards of the owner, newest first",
+		Description: "Events of devices are included when the owner is the old or the new owner of the event.",
+		Parameters:  []*openapi3.Parameter{ownerParam, activityTypeParam, cursorParam, pageSizeParam},
+		Responses:   ok(apitypes.ActivityResponse{}),
+	},
+
+	// webhooks
+	{
+		Method: http.MethodPost, Path: "/webhooks/v1", ID: "registerWebhook", Tag: "webhooks",
+		Summary: "Register a webhook that receives the events and rewards of an owner",
+		Description: "The request is signed by the owner over the register action and the URL and filter hash separated by `|`. " +
+			"The filter hash is the hex encoded keccak256 hash of `registries=<registries>;ids=<ids>;types=<types>;pending=<pending>;rewards=<rewards>`, " +
+			"in which the lists are sorted, deduplicated and comma separated, the ids are 0x prefixed lower case hex and pending and rewards are `true` or `false`. " +
+			"The secret deliveries are signed with is only returned here.",
+		Request:   jsonBody(webhookapi.RegisterWebhookRequest{}, true),
+		Responses: []response{{Status: http.StatusCreated, Description: "Created", Body: webhookmodels.Webhook{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/webhooks/v1/owner/{owner}", ID: "ownerWebhooks", Tag: "webhooks",
+		Summary:     "List the webhooks of an owner",
+		Description: "The request is signed by the owner over the list action and the lower case owner address, every signature is only accepted once.",
+		Parameters:  []*openapi3.Parameter{ownerParam, webhookTimestampParam, webhookSignatureParam},
+		Responses: []response{
+			{Status: http.StatusOK, Description: "OK", Body: webhookapi.WebhooksResponse{}},
+			{Status: http.StatusUnauthorized, Description: "The request isn't signed by the owner"},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/webhooks/v1/{id}", ID: "webhook", Tag: "webhooks",
+		Summary:     "Get a webhook",
+		Description: "The request is signed by the owner over the details action and the webhook id, every signature is only accepted once.",
+		Parameters:  []*openapi3.Parameter{webhookIDParam, webhookTimestampParam, webhookSignatureParam},
+		Responses: []response{
+			{Status: http.StatusOK, Description: "OK", Body: webhookmodels.Webhook{}},
+			{Status: http.StatusUnauthorized, Description: "The request isn't signed by the owner"},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/webhooks/v1/{id}", ID: "deleteWebhook", Tag: "webhooks",
+		Summary:     "Delete a webhook",
+		Description: "The request is signed by the owner over the delete action and the webhook id.",
+		Parameters:  []*openapi3.Parameter{webhookIDParam},
+		Request:     jsonBody(webhookapi.DeleteWebhookRequest{}, true),
+		Responses:   []response{{Status: http.StatusNoContent, Description: "Deleted"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/webhooks/v1/{id}/deliveries", ID: "webhookDeliveries", Tag: "webhooks",
+		Summary:     "List the deliveries of a webhook, newest first",
+		Description: "The request is signed by the owner over the deliveries action and the webhook id, every signature is only accepted once.",
+		Parameters: []*openapi3.Parameter{
+			webhookIDParam, webhookTimestampParam, webhookSignatureParam, cursorParam, pageSizeParam,
+		},
+		Responses: []response{
+			{Status: http.StatusOK, Description: "OK", Body: webhookapi.DeliveriesResponse{}},
+			{Status: http.StatusUnauthorized, Description: "The request isn't signed by the owner"},
+		},
+	},
+
+	// tiles
+	{
+		Method: http.MethodGet, Path: "/tiles/{layer}/{z}/{x}/{y}.mvt", ID: "tile", Tag: "tiles",
+		Summary:     "Get a vector tile of gateways or coverage",
+		Description: "Mapbox vector tile with a single layer named after the requested layer. Cells are shown at the H3 resolution that fits the zoom level, gateways are shown from zoom level 10 and verified coverage from zoom level 8.",
+		Parameters: []*openapi3.Parameter{
+			pathParam("layer", "layer of the tile", openapi3.NewStringSchema().WithEnum(stringsToInterfaces(tiles.Layers())...)),
+			pathParam("z", "zoom level", openapi3.NewIntegerSchema().WithMin(0).WithMax(tiles.MaxZoom)),
+			pathParam("x", "column of the tile", openapi3.NewIntegerSchema().WithMin(0)),
+			pathParam("y", "row of the tile, from north to south", openapi3.NewIntegerSchema().WithMin(0)),
+			queryParam("date", "date of the coverage layers formatted as YYYY-MM-DD, defaults to the latest rewards date", dateSchema()),
+		},
+		Responses: conditional([]response{{Status: http.StatusOK, Description: "OK", ContentType: tiles.ContentType}}),
+	},
+
+	// status
+	{
+		Method: http.MethodGet, Path: "/readyz", ID: "readiness", Tag: "status",
+		Summary: "Check that the stores and the RPC node are reachable",
+		Responses: []response{
+			{Status: http.StatusOK, Description: "Ready", Body: apitypes.ReadinessResponse{}},
+			{Status: http.StatusServiceUnavailable, Description: "Not ready, the failed checks hold the error", Body: apitypes.ReadinessResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/status", ID: "status", Tag: "status",
+		Summary:   "Get the sync status and lag of the registries",
+		Responses: ok(apitypes.StatusResponse{}),
+	},
+}
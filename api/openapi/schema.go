@@ -0,0 +1,249 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// knownSchemas describe types that are encoded differently in JSON than
+// their Go kind suggests.
+var knownSchemas = map[reflect.Type]func() *openapi3.Schema{
+	reflect.TypeOf(common.Address{}):  addressSchema,
+	reflect.TypeOf(common.Hash{}):     hashSchema,
+	reflect.TypeOf(types.ID{}):        idSchema,
+	reflect.TypeOf(h3light.Cell(0)):   cellSchema,
+	reflect.TypeOf(big.Int{}):         openapi3.NewIntegerSchema,
+	reflect.TypeOf(hexutil.Big{}):     hexSchema,
+	reflect.TypeOf(hexutil.Bytes{}):   hexSchema,
+	reflect.TypeOf(time.Time{}):       openapi3.NewDateTimeSchema,
+	reflect.TypeOf(json.RawMessage{}): func() *openapi3.Schema { return &openapi3.Schema{} },
+}
+
+func addressSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^(0x)?[0-9a-fA-F]{40}$`)
+}
+
+func hashSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^(0x)?[0-9a-fA-F]{64}$`)
+}
+
+func idSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^(0x)?[0-9a-fA-F]{64}$`)
+}
+
+func cellSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^[0-9a-fA-F]{15}$`)
+}
+
+func hexSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^0x[0-9a-fA-F]*$`)
+}
+
+func dateSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithPattern(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+}
+
+// schemas derives JSON schemas from Go types the same way encoding/json
+// encodes them. Named structs are added as components and referenced.
+type schemas struct {
+	components openapi3.Schemas
+	types      map[string]reflect.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: make(openapi3.Schemas),
+		types:      make(map[string]reflect.Type),
+	}
+}
+
+// of returns the schema for the type of v.
+func (s *schemas) of(v interface{}) (*openapi3.SchemaRef, error) {
+	return s.schemaRef(reflect.TypeOf(v))
+}
+
+func (s *schemas) schemaRef(t reflect.Type) (*openapi3.SchemaRef, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if schema, ok := knownSchemas[t]; ok {
+		return openapi3.NewSchemaRef("", schema()), nil
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return openapi3.NewSchemaRef("", openapi3.NewStringSchema()), nil
+	}
+
+	var schema *openapi3.Schema
+	switch t.Kind() {
+	case reflect.Bool:
+		schema = openapi3.NewBoolSchema()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema = openapi3.NewIntegerSchema()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = openapi3.NewIntegerSchema().WithMin(0)
+	case reflect.Float32, reflect.Float64:
+		schema = openapi3.NewFloat64Schema()
+	case reflect.String:
+		schema = openapi3.NewStringSchema()
+	case reflect.Interface:
+		schema = &openapi3.Schema{}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			schema = openapi3.NewBytesSchema()
+			break
+		}
+		items, err := s.schemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema = openapi3.NewArraySchema()
+		schema.Items = items
+	case reflect.Map:
+		values, err := s.schemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema = openapi3.NewObjectSchema()
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: values}
+	case reflect.Struct:
+		return s.structRef(t)
+	default:
+		return nil, fmt.Errorf("type %s can't be described in JSON", t)
+	}
+
+	return openapi3.NewSchemaRef("", schema), nil
+}
+
+// structRef adds the struct as component, anonymous structs are inlined.
+func (s *schemas) structRef(t reflect.Type) (*openapi3.SchemaRef, error) {
+	if t.Name() == "" {
+		schema := openapi3.NewObjectSchema()
+		if err := s.fields(t, schema); err != nil {
+			return nil, err
+		}
+		return openapi3.NewSchemaRef("", schema), nil
+	}
+
+	name := t.Name()
+	if known, ok := s.types[name]; ok {
+		if known != t {
+			return nil, fmt.Errorf("schema %s is used by both %s and %s", name, known, t)
+		}
+		return openapi3.NewSchemaRef("#/components/schemas/"+name, s.components[name].Value), nil
+	}
+
+	// register before walking the fields so recursive types terminate
+	schema := openapi3.NewObjectSchema()
+	s.types[name] = t
+	s.components[name] = openapi3.NewSchemaRef("", schema)
+	if err := s.fields(t, schema); err != nil {
+		return nil, err
+	}
+
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, schema), nil
+}
+
+// fields adds the struct fields as properties to the schema. Fields that are
+// always encoded are required, nil pointers, slices and maps are encoded as
+// null and therefore nullable.
+func (s *schemas) fields(t reflect.Type, schema *openapi3.Schema) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// fields of embedded structs are promoted
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := s.fields(ft, schema); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		ref, err := s.schemaRef(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		omitEmpty := false
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				omitEmpty = true
+			case "string":
+				ref = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+			}
+		}
+
+		if !omitEmpty {
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				ref = nullable(ref)
+			}
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = ref
+	}
+
+	return nil
+}
+
+// nullable allows null for the schema, references can't have siblings and
+// are wrapped.
+func nullable(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref.Ref == "" {
+		ref.Value.Nullable = true
+		return ref
+	}
+
+	return openapi3.NewSchemaRef("", &openapi3.Schema{
+		Nullable: true,
+		AllOf:    openapi3.SchemaRefs{ref},
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/tiles"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+func init() {
+	// GeoJSON replies are validated as any other JSON reply, vector tiles
+	// as binary
+	openapi3filter.RegisterBodyDecoder(geo.GeoJSONContentType, openapi3filter.RegisteredBodyDecoder("application/json"))
+	openapi3filter.RegisterBodyDecoder(tiles.ContentType, openapi3filter.FileBodyDecoder)
+}
+
+// Middleware validates requests to and responses from documented endpoints
+// when enabled. Requests that don't match the document are rejected with
+// 400, responses that don't match are replaced with a 500 error. Requests to
+// endpoints that aren't documented, such as GraphQL and the event stream,
+// are passed through.
+func (o *OpenAPI) Middleware(next http.Handler) http.Handler {
+	if !o.validateRequests && !o.validateResponses {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := o.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var (
+			log   = logging.WithContext(r.Context())
+			input = &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{IncludeResponseStatus: true},
+			}
+		)
+
+		if o.validateRequests {
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				log.WithError(err).Debug("request doesn't match OpenAPI document")
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if !o.validateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &recorder{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 rec.status,
+			Header:                 rec.header,
+			Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+			Options:                input.Options,
+		})
+		if err != nil {
+			log.WithError(err).Error("response doesn't match OpenAPI document")
+			http.Error(w, "response doesn't match OpenAPI document", http.StatusInternalServerError)
+			return
+		}
+
+		for key, values := range rec.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.status)
+		w.Write(rec.body.Bytes())
+	})
+}
+
+// recorder buffers a response so it can be validated before it is sent.
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.status = status
+	rec.wroteHeader = true
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(b)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/ThingsIXFoundation/data-aggregator/apikey"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCGroup is the route group of gRPC calls, its limits are configured like
+// those of the HTTP route groups. A watch stream takes a single token.
+const GRPCGroup = "grpc"
+
+// UnaryServerInterceptor limits unary gRPC calls like Middleware limits HTTP
+// requests. The API key is sent in the x-api-key or authorization metadata.
+func (rl *RateLimit) UnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (interface{}, error) {
+	if err := rl.allowCall(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor limits gRPC streams like Middleware limits HTTP
+// requests.
+func (rl *RateLimit) StreamServerInterceptor(srv interface{}, ss grpcgo.ServerStream, _ *grpcgo.StreamServerInfo, handler grpcgo.StreamHandler) error {
+	if err := rl.allowCall(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// allowCall returns a gRPC status error when the call is rejected. When the
+// limiter or the key store fails the call is let through.
+func (rl *RateLimit) allowCall(ctx context.Context) error {
+	res, _, err := rl.take(ctx, GRPCGroup, peerIP(ctx), metadataAPIKey(ctx))
+	switch {
+	case errors.Is(err, apikey.ErrInvalidKey):
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	case err != nil:
+		logging.WithContext(ctx).WithError(err).Error("unable to rate limit call")
+		return nil
+	case !res.Allowed:
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ss", ceilSeconds(res.RetryAfter))
+	}
+	return nil
+}
+
+// peerIP returns the IP of the client that made the call.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// metadataAPIKey returns the API key of the call, or an empty string.
+func metadataAPIKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get(strings.ToLower(APIKeyHeader)); len(keys) > 0 && keys[0] != "" {
+		return keys[0]
+	}
+	for _, auth := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket that holds at most Burst tokens and is refilled
+// with Rate tokens per second. Every request takes a token.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// window is the time an empty bucket takes to fill up.
+func (l Limit) window() time.Duration {
+	return seconds(float64(l.Burst) / l.Rate)
+}
+
+// Result is the state of a bucket after a request tried to take a token.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available, it is zero
+	// when the request is allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter keeps the token buckets.
+type Limiter interface {
+	// Allow takes a token from the bucket with the given key.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// refill returns the tokens in a bucket that had tokens at updated.
+func refill(tokens float64, updated, now time.Time, limit Limit) float64 {
+	if elapsed := now.Sub(updated); elapsed > 0 {
+		tokens += elapsed.Seconds() * limit.Rate
+	}
+	return math.Min(tokens, float64(limit.Burst))
+}
+
+// result returns the result for a bucket that has tokens left after the
+// request tried to take one.
+func result(allowed bool, tokens float64, limit Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the interval in which buckets that are full again are
+// removed from the memory limiter.
+const sweepInterval = time.Minute
+
+// MemoryLimiter keeps the buckets in memory, every replica of the API limits
+// requests on its own.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is the time the bucket is full again, from then on it is the same
+	// as a new bucket
+	full time.Time
+}
+
+// NewMemoryLimiter creates a limiter that keeps the buckets in memory.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow implements Limiter
+func (ml *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	if now.Sub(ml.lastSweep) >= sweepInterval {
+		ml.sweep(now)
+	}
+
+	b, ok := ml.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		ml.buckets[key] = b
+	}
+
+	b.tokens = refill(b.tokens, b.updated, now, limit)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	res := result(allowed, b.tokens, limit)
+	b.full = now.Add(res.Reset)
+	return res, nil
+}
+
+func (ml *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range ml.buckets {
+		if !now.Before(b.full) {
+			delete(ml.buckets, key)
+		}
+	}
+	ml.lastSweep = now
+}
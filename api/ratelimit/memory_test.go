@@ -0,0 +1,131 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRefill(t *testing.T) {
+	var (
+		now   = time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+		limit = Limit{Rate: 2, Burst: 10}
+	)
+
+	for _, tc := range []struct {
+		name    string
+		tokens  float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{"no time elapsed", 3, 0, 3},
+		{"partial token", 3, 250 * time.Millisecond, 3.5},
+		{"tokens per second", 0, 2 * time.Second, 4},
+		{"capped at burst", 8, 5 * time.Second, 10},
+		{"clock went back", 3, -time.Second, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := refill(tc.tokens, now.Add(-tc.elapsed), now, limit); got != tc.want {
+				t.Errorf("refill %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 10}
+
+	for _, tc := range []struct {
+		name    string
+		allowed bool
+		tokens  float64
+		want    Result
+	}{
+		{"full", true, 10, Result{Allowed: true, Remaining: 10}},
+		{"partial tokens round down", true, 4.5, Result{Allowed: true, Remaining: 4, Reset: 2750 * time.Millisecond}},
+		{"empty", true, 0, Result{Allowed: true, Remaining: 0, Reset: 5 * time.Second}},
+		{"denied", false, 0.5, Result{Allowed: false, Remaining: 0, Reset: 4750 * time.Millisecond, RetryAfter: 250 * time.Millisecond}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := result(tc.allowed, tc.tokens, limit); got != tc.want {
+				t.Errorf("result %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMemoryLimiterBurst(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		limit Limit
+	}{
+		{"single", Limit{Rate: 0.001, Burst: 1}},
+		{"burst", Limit{Rate: 0.001, Burst: 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ml := NewMemoryLimiter()
+			for i := 0; i < tc.limit.Burst; i++ {
+				res, err := ml.Allow(context.Background(), "key", tc.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !res.Allowed || res.Remaining != tc.limit.Burst-i-1 {
+					t.Fatalf("request %d allowed %v with %d remaining, want allowed with %d", i, res.Allowed, res.Remaining, tc.limit.Burst-i-1)
+				}
+			}
+
+			res, err := ml.Allow(context.Background(), "key", tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Allowed || res.RetryAfter <= 0 {
+				t.Errorf("request after burst allowed %v with retry after %v, want denied with retry after", res.Allowed, res.RetryAfter)
+			}
+
+			// other keys have their own bucket
+			if res, _ := ml.Allow(context.Background(), "other", tc.limit); !res.Allowed {
+				t.Errorf("request with other key denied, want allowed")
+			}
+		})
+	}
+}
+
+func TestMemoryLimiterRefill(t *testing.T) {
+	var (
+		ml    = NewMemoryLimiter()
+		limit = Limit{Rate: 1, Burst: 2}
+	)
+	for i := 0; i < 3; i++ {
+		ml.Allow(context.Background(), "key", limit)
+	}
+
+	// the bucket is refilled with a token per second since its last update
+	ml.buckets["key"].updated = ml.buckets["key"].updated.Add(-1500 * time.Millisecond)
+
+	res, err := ml.Allow(context.Background(), "key", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed || res.Remaining != 0 {
+		t.Errorf("request after refill allowed %v with %d remaining, want allowed with 0", res.Allowed, res.Remaining)
+	}
+	if res, _ := ml.Allow(context.Background(), "key", limit); res.Allowed {
+		t.Errorf("request after refilled token allowed, want denied")
+	}
+}
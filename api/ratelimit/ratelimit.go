@@ -0,0 +1,369 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the requests to the HTTP and gRPC API with token
+// buckets. Requests are limited per route group, named after the first path
+// segment such as gateways or mapping, and writes have their own buckets in
+// the group with a -write suffix. Requests with a valid API key are limited
+// per key, other requests per IP. The IP is the address of the peer, the
+// forwarded headers are only read from requests of trusted proxies.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/apikey"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+)
+
+// APIKeyHeader is the header that carries the API key, alternatively it is
+// sent as bearer token in the Authorization header.
+const APIKeyHeader = "X-API-Key"
+
+// Limits are the limits of a route group.
+type Limits struct {
+	IP  Limit
+	Key Limit
+}
+
+// Options configure a RateLimit.
+type Options struct {
+	Limiter Limiter
+	// Keys verifies API keys, when nil requests are always limited per IP.
+	Keys *apikey.Keys
+	// Read and Write are the limits of route groups without their own.
+	Read  Limits
+	Write Limits
+	// Groups are the limits of specific route groups.
+	Groups map[string]Limits
+	// TrustedProxies are the networks of the proxies in front of the API,
+	// the client IP is only taken from the forwarded headers of requests
+	// these proxies forward.
+	TrustedProxies []*net.IPNet
+}
+
+type RateLimit struct {
+	limiter        Limiter
+	keys           *apikey.Keys
+	read           Limits
+	write          Limits
+	groups         map[string]Limits
+	trustedProxies []*net.IPNet
+}
+
+// New creates a RateLimit with the given options.
+func New(opts Options) *RateLimit {
+	return &RateLimit{
+		limiter:        opts.Limiter,
+		keys:           opts.Keys,
+		read:           opts.Read,
+		write:          opts.Write,
+		groups:         opts.Groups,
+		trustedProxies: opts.TrustedProxies,
+	}
+}
+
+// groupConfig are the limits of a route group in the config, limits that
+// aren't set default to the read or write limits.
+type groupConfig struct {
+	IPRate   float64 `mapstructure:"ip-rate"`
+	IPBurst  int     `mapstructure:"ip-burst"`
+	KeyRate  float64 `mapstructure:"key-rate"`
+	KeyBurst int     `mapstructure:"key-burst"`
+}
+
+// NewRateLimit creates a RateLimit from the config.
+func NewRateLimit() (*RateLimit, error) {
+	opts := Options{
+		Read: Limits{
+			IP:  Limit{Rate: viper.GetFloat64(config.CONFIG_API_RATELIMIT_IP_RATE), Burst: viper.GetInt(config.CONFIG_API_RATELIMIT_IP_BURST)},
+			Key: Limit{Rate: viper.GetFloat64(config.CONFIG_API_RATELIMIT_KEY_RATE), Burst: viper.GetInt(config.CONFIG_API_RATELIMIT_KEY_BURST)},
+		},
+		Write: Limits{
+			IP:  Limit{Rate: viper.GetFloat64(config.CONFIG_API_RATELIMIT_WRITE_IP_RATE), Burst: viper.GetInt(config.CONFIG_API_RATELIMIT_WRITE_IP_BURST)},
+			Key: Limit{Rate: viper.GetFloat64(config.CONFIG_API_RATELIMIT_WRITE_KEY_RATE), Burst: viper.GetInt(config.CONFIG_API_RATELIMIT_WRITE_KEY_BURST)},
+		},
+		Groups: make(map[string]Limits),
+	}
+
+	var groups map[string]groupConfig
+	if err := viper.UnmarshalKey(config.CONFIG_API_RATELIMIT_GROUPS, &groups); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", config.CONFIG_API_RATELIMIT_GROUPS, err)
+	}
+	for name, group := range groups {
+		limits := opts.Read
+		if strings.HasSuffix(name, "-write") {
+			limits = opts.Write
+		}
+		if group.IPRate != 0 {
+			limits.IP.Rate = group.IPRate
+		}
+		if group.IPBurst != 0 {
+			limits.IP.Burst = group.IPBurst
+		}
+		if group.KeyRate != 0 {
+			limits.Key.Rate = group.KeyRate
+		}
+		if group.KeyBurst != 0 {
+			limits.Key.Burst = group.KeyBurst
+		}
+		if limits.IP.Rate < 0 || limits.IP.Burst < 0 || limits.Key.Rate < 0 || limits.Key.Burst < 0 {
+			return nil, fmt.Errorf("invalid %s.%s: limits must be larger than 0", config.CONFIG_API_RATELIMIT_GROUPS, name)
+		}
+		opts.Groups[name] = limits
+	}
+
+	trustedProxies, err := parseTrustedProxies(viper.GetStringSlice(config.CONFIG_API_RATELIMIT_TRUSTED_PROXIES))
+	if err != nil {
+		return nil, err
+	}
+	opts.TrustedProxies = trustedProxies
+
+	switch store := viper.GetString(config.CONFIG_API_RATELIMIT_STORE); store {
+	case "memory":
+		opts.Limiter = NewMemoryLimiter()
+	case "redis":
+		opts.Limiter = NewRedisLimiter(redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{viper.GetString(config.CONFIG_GATEWAY_CACHER_REDIS_HOST)}}))
+	default:
+		return nil, fmt.Errorf("invalid rate limit store: %s", store)
+	}
+
+	keys, err := apikey.NewKeys()
+	if err != nil {
+		return nil, err
+	}
+	opts.Keys = keys
+
+	return New(opts), nil
+}
+
+// Middleware limits the requests and sets the RateLimit headers on the
+// replies. Requests with an invalid API key are rejected. When the limiter or
+// the key store fails the request is let through.
+func (rl *RateLimit) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS preflight requests are answered before they get here
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res, limit, err := rl.take(r.Context(), routeGroup(r), rl.clientIP(r), apiKey(r))
+		switch {
+		case errors.Is(err, apikey.ErrInvalidKey):
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+			return
+		case err != nil:
+			logging.WithContext(r.Context()).WithError(err).Error("unable to rate limit request")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("RateLimit-Reset", ceilSeconds(res.Reset))
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Burst, ceilSeconds(limit.window())))
+
+		if !res.Allowed {
+			w.Header().Set("Retry-After", ceilSeconds(res.RetryAfter))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take takes a token for a request in the route group from the bucket of
+// the API key, or from the bucket of the client IP when there is no key or
+// the key store fails. A key that isn't verified by the cache first takes a
+// token from the bucket of the IP, so invalid keys are limited per IP and
+// can't be used to flood the key store. It returns apikey.ErrInvalidKey for
+// an invalid key.
+func (rl *RateLimit) take(ctx context.Context, group, ip, key string) (Result, Limit, error) {
+	var (
+		limits   = rl.limits(group)
+		ipBucket = fmt.Sprintf("%s.ip.%s", group, ip)
+	)
+
+	if key == "" || rl.keys == nil {
+		res, err := rl.limiter.Allow(ctx, ipBucket, limits.IP)
+		return res, limits.IP, err
+	}
+
+	var (
+		res     Result
+		charged bool
+	)
+	if !rl.keys.Cached(key) {
+		var err error
+		if res, err = rl.limiter.Allow(ctx, ipBucket, limits.IP); err != nil || !res.Allowed {
+			return res, limits.IP, err
+		}
+		charged = true
+	}
+
+	verified, err := rl.keys.Verify(ctx, key)
+	switch {
+	case errors.Is(err, apikey.ErrInvalidKey):
+		return Result{}, limits.IP, err
+	case err != nil:
+		logging.WithContext(ctx).WithError(err).Error("unable to verify API key, limit request per IP")
+		if charged {
+			return res, limits.IP, nil
+		}
+		res, err := rl.limiter.Allow(ctx, ipBucket, limits.IP)
+		return res, limits.IP, err
+	}
+
+	res, err = rl.limiter.Allow(ctx, fmt.Sprintf("%s.key.%s", group, verified.ID), limits.Key)
+	return res, limits.Key, err
+}
+
+// limits returns the limits of the route group.
+func (rl *RateLimit) limits(group string) Limits {
+	if limits, ok := rl.groups[group]; ok {
+		return limits
+	}
+	if strings.HasSuffix(group, "-write") {
+		return rl.write
+	}
+	return rl.read
+}
+
+// routeGroup returns the first path segment, with a -write suffix for
+// requests that aren't reads.
+func routeGroup(r *http.Request) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if group == "" {
+		group = "root"
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return group
+	default:
+		return group + "-write"
+	}
+}
+
+// apiKey returns the API key of the request, or an empty string.
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+type peerAddrKey struct{}
+
+// PeerAddr keeps the remote address of the connection for the rate limiter.
+// It must be installed before middleware that replaces the remote address
+// with a forwarded address, like the chi RealIP middleware.
+func PeerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), peerAddrKey{}, r.RemoteAddr)))
+	})
+}
+
+// clientIP returns the IP of the client. When the peer is a trusted proxy
+// the X-Forwarded-For header is read from right to left and the first
+// address that isn't a trusted proxy is the client, X-Real-IP is only used
+// when there is no X-Forwarded-For header.
+func (rl *RateLimit) clientIP(r *http.Request) string {
+	addr, ok := r.Context().Value(peerAddrKey{}).(string)
+	if !ok {
+		addr = r.RemoteAddr
+	}
+	peer, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		peer = addr
+	}
+	if ip := net.ParseIP(peer); ip == nil || !rl.trusted(ip) {
+		return peer
+	}
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		hops := strings.Split(strings.Join(values, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				return peer
+			}
+			if !rl.trusted(ip) {
+				return ip.String()
+			}
+		}
+		return peer
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return peer
+}
+
+// trusted reports if the IP is a trusted proxy.
+func (rl *RateLimit) trusted(ip net.IP) bool {
+	for _, proxy := range rl.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses IPs and CIDR ranges, an IP is a range of a
+// single address.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid %s: invalid IP or CIDR range %q", config.CONFIG_API_RATELIMIT_TRUSTED_PROXIES, proxy)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip), 8*len(ip))})
+	}
+	return networks, nil
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareHeaders(t *testing.T) {
+	rl := New(Options{
+		Limiter: NewMemoryLimiter(),
+		Read:    Limits{IP: Limit{Rate: 1, Burst: 2}},
+		Write:   Limits{IP: Limit{Rate: 0.1, Burst: 1}},
+		Groups:  map[string]Limits{"stats": {IP: Limit{Rate: 5, Burst: 20}}},
+	})
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// the requests are made in order, they share the buckets
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		status     int
+		limit      string
+		remaining  string
+		reset      string
+		policy     string
+		retryAfter string
+	}{
+		{"first read", http.MethodGet, "/gateways/v1/search", http.StatusOK, "2", "1", "1", "2;w=2", ""},
+		{"last token", http.MethodGet, "/gateways/v1/nearest", http.StatusOK, "2", "0", "2", "2;w=2", ""},
+		{"burst used", http.MethodGet, "/gateways/v1/search", http.StatusTooManyRequests, "2", "0", "2", "2;w=2", "1"},
+		{"other group", http.MethodGet, "/mappers/v1/batch", http.StatusOK, "2", "1", "1", "2;w=2", ""},
+		{"group limits", http.MethodGet, "/stats/v1/network", http.StatusOK, "20", "19", "1", "20;w=4", ""},
+		{"write", http.MethodPost, "/gateways/v1/batch", http.StatusOK, "1", "0", "10", "1;w=10", ""},
+		{"write burst used", http.MethodPost, "/gateways/v1/batch", http.StatusTooManyRequests, "1", "0", "10", "1;w=10", "10"},
+		{"preflight", http.MethodOptions, "/gateways/v1/batch", http.StatusOK, "", "", "", "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if w.Code != tc.status {
+				t.Errorf("status %d, want %d", w.Code, tc.status)
+			}
+			for header, want := range map[string]string{
+				"RateLimit-Limit":     tc.limit,
+				"RateLimit-Remaining": tc.remaining,
+				"RateLimit-Reset":     tc.reset,
+				"RateLimit-Policy":    tc.policy,
+				"Retry-After":         tc.retryAfter,
+			} {
+				if got := w.Header().Get(header); got != want {
+					t.Errorf("%s %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// takeToken refills the bucket in KEYS[1] and takes a token from it. The
+// bucket expires when it is full again, a missing bucket is full.
+var takeToken = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+
+if now > updated then
+	tokens = math.min(burst, tokens + (now - updated) / 1000 * rate)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps the buckets in Redis so all replicas of the API share
+// them.
+type RedisLimiter struct {
+	redis redis.UniversalClient
+}
+
+// NewRedisLimiter creates a limiter that keeps the buckets in Redis.
+func NewRedisLimiter(redis redis.UniversalClient) *RedisLimiter {
+	return &RedisLimiter{redis: redis}
+}
+
+// Allow implements Limiter
+func (rl *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixMilli()
+
+	reply, err := takeToken.Run(ctx, rl.redis, []string{fmt.Sprintf("RateLimit.%s", key)},
+		limit.Rate, limit.Burst, now).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensStr, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit tokens %q: %w", tokensStr, err)
+	}
+
+	return result(allowed == 1, tokens, limit), nil
+}
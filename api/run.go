@@ -20,13 +20,47 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/grpc"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+// Components returns the API components, the status endpoint reports the
+// components run by the given supervisor.
+func Components(sup *supervisor.Supervisor) []supervisor.Component {
+	components := []supervisor.Component{
+		{
+			Name:     "api",
+			Registry: "api",
+			Role:     supervisor.RoleAPI,
+			Run: func(ctx context.Context) error {
+				return Run(ctx, sup)
+			},
+			Policy:   supervisor.DefaultRestartPolicy(),
+			Critical: true,
+		},
+	}
+
+	if viper.GetBool(config.CONFIG_GRPC_API_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "grpc-api",
+			Registry: "api",
+			Role:     supervisor.RoleAPI,
+			Run:      grpc.Run,
+			Policy:   supervisor.DefaultRestartPolicy(),
+			Critical: true,
+		})
+	}
+
+	return components
+}
+
+func Run(ctx context.Context, sup *supervisor.Supervisor) error {
 	errChan := make(chan error)
 
-	gapi, err := NewAPI()
+	gapi, err := NewAPI(sup)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,477 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package status serves the readiness probe and reports how far the
+// ingestors, aggregators and cachers of the registries are behind the chain.
+// Liveness is served by the standard heartbeat on /healthz.
+package status
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/data-aggregator/status/store"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// checkTimeout bounds the time a single readiness or status check takes.
+	checkTimeout = 5 * time.Second
+	// resultTTL is the time the readiness and status results are cached, the
+	// probes and status requests in that time share a single check.
+	resultTTL = 5 * time.Second
+)
+
+// Options configure a Status. Registries whose store is nil aren't reported.
+type Options struct {
+	GatewayStore gatewayStore.Store
+	RouterStore  routerStore.Store
+	MapperStore  mapperStore.Store
+	// Polls is the store the processes record their successful polls in.
+	Polls store.Store
+	// Dialer connects to the RPC node, when nil the chain head isn't reported
+	// and the RPC node isn't checked for readiness.
+	Dialer chainsync.Dialer
+	// ChainID is the id of the chain the registries are deployed on.
+	ChainID uint64
+	// Contracts and Confirmations of the registries by name.
+	Contracts     map[string]common.Address
+	Confirmations map[string]uint64
+	// Supervisor runs the components of the process, when nil the state of
+	// the components isn't reported.
+	Supervisor *supervisor.Supervisor
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Status struct {
+	registries []registry
+	polls      store.Store
+	dialer     chainsync.Dialer
+	chainID    uint64
+	supervisor *supervisor.Supervisor
+	log        logrus.FieldLogger
+
+	// client is the connection to the RPC node, it is dialed on first use
+	// and again after the chain head could not be fetched
+	clientMu sync.Mutex
+	client   *ethclient.Client
+
+	readiness result[apitypes.ReadinessResponse]
+	status    result[apitypes.StatusResponse]
+}
+
+// result caches the outcome of a check for resultTTL.
+type result[T any] struct {
+	mu      sync.Mutex
+	value   T
+	expires time.Time
+}
+
+// get returns the cached value, or runs the check when it expired.
+// Concurrent callers wait for a single check.
+func (r *result[T]) get(check func() T) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Now().Before(r.expires) {
+		return r.value
+	}
+	r.value = check()
+	r.expires = time.Now().Add(resultTTL)
+	return r.value
+}
+
+// registry is a registry the status is reported for.
+type registry struct {
+	name          string
+	process       string
+	contract      common.Address
+	confirmations uint64
+	current       func(ctx context.Context, process string) (uint64, error)
+	cached        bool
+}
+
+// New creates a Status with the given options.
+func New(opts Options) *Status {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	s := &Status{
+		polls:      opts.Polls,
+		dialer:     opts.Dialer,
+		chainID:    opts.ChainID,
+		supervisor: opts.Supervisor,
+		log:        opts.Logger,
+	}
+
+	for _, r := range []struct {
+		name    string
+		process string
+		enabled bool
+		current func(ctx context.Context, process string) (uint64, error)
+		cached  bool
+	}{
+		{"gateway", "Gateway", opts.GatewayStore != nil, func(ctx context.Context, process string) (uint64, error) {
+			return opts.GatewayStore.CurrentBlock(ctx, process)
+		}, true},
+		{"router", "Router", opts.RouterStore != nil, func(ctx context.Context, process string) (uint64, error) {
+			return opts.RouterStore.CurrentBlock(ctx, process)
+		}, false},
+		{"mapper", "Mapper", opts.MapperStore != nil, func(ctx context.Context, process string) (uint64, error) {
+			return opts.MapperStore.CurrentBlock(ctx, process)
+		}, true},
+	} {
+		if !r.enabled {
+			continue
+		}
+		s.registries = append(s.registries, registry{
+			name:          r.name,
+			process:       r.process,
+			contract:      opts.Contracts[r.name],
+			confirmations: opts.Confirmations[r.name],
+			current:       r.current,
+			cached:        r.cached,
+		})
+	}
+
+	return s
+}
+
+// NewStatus creates a Status from the config for the registries that have a
+// contract configured and the components run by the given supervisor.
+func NewStatus(sup *supervisor.Supervisor) (*Status, error) {
+	polls, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		Polls:         polls,
+		Supervisor:    sup,
+		ChainID:       viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID),
+		Contracts:     make(map[string]common.Address),
+		Confirmations: make(map[string]uint64),
+	}
+
+	if viper.GetString(config.CONFIG_CHAINSYNC_RPC_ENDPOINT) != "" {
+		opts.Dialer = chainsync.DialerFromConfig()
+	}
+
+	if viper.GetString(config.CONFIG_GATEWAY_CONTRACT) != "" {
+		if opts.GatewayStore, err = gatewayStore.NewStore(); err != nil {
+			return nil, err
+		}
+		opts.Contracts["gateway"] = config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)
+		opts.Confirmations["gateway"] = viper.GetUint64(config.CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS)
+	}
+
+	if viper.GetString(config.CONFIG_ROUTER_CONTRACT) != "" {
+		if opts.RouterStore, err = routerStore.NewStore(); err != nil {
+			return nil, err
+		}
+		opts.Contracts["router"] = config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)
+		opts.Confirmations["router"] = viper.GetUint64(config.CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS)
+	}
+
+	if viper.GetString(config.CONFIG_MAPPER_CONTRACT) != "" {
+		if opts.MapperStore, err = mapperStore.NewStore(); err != nil {
+			return nil, err
+		}
+		opts.Contracts["mapper"] = config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT)
+		opts.Confirmations["mapper"] = viper.GetUint64(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS)
+	}
+
+	return New(opts), nil
+}
+
+func (s *Status) Bind(root *chi.Mux) {
+	root.Get("/status", s.Status)
+}
+
+// Ready is a middleware that answers readiness probes on /readyz. It is a
+// middleware like the heartbeat on /healthz so probes aren't rate limited.
+func (s *Status) Ready(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && r.URL.Path == "/readyz" {
+			s.Readiness(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Readiness checks that the stores and the RPC node are reachable. It replies
+// with 503 Service Unavailable when one of the checks fails.
+func (s *Status) Readiness(w http.ResponseWriter, r *http.Request) {
+	resp := s.readiness.get(s.checkReadiness)
+
+	status := http.StatusOK
+	if !resp.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	encoding.ReplyJSON(w, r, status, &resp)
+}
+
+// checkReadiness runs the readiness checks, it doesn't use the context of
+// the request as the result is shared with other probes.
+func (s *Status) checkReadiness() apitypes.ReadinessResponse {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), checkTimeout)
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		resp        = apitypes.ReadinessResponse{Ready: true, Checks: make(map[string]string)}
+	)
+	defer cancel()
+
+	check := func(name string, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fn(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				s.log.WithError(err).WithField("check", name).Warn("readiness check failed")
+				resp.Ready = false
+				resp.Checks[name] = err.Error()
+				return
+			}
+			resp.Checks[name] = "ok"
+		}()
+	}
+
+	for _, reg := range s.registries {
+		reg := reg
+		check(fmt.Sprintf("%s-store", reg.name), func(ctx context.Context) error {
+			_, err := reg.current(ctx, reg.process+"Ingestor")
+			return err
+		})
+	}
+	if s.polls != nil {
+		check("status-store", func(ctx context.Context) error {
+			_, err := s.polls.LastPoll(ctx, "Readiness", common.Address{})
+			return err
+		})
+	}
+	if s.dialer != nil {
+		check("rpc", func(ctx context.Context) error {
+			_, _, err := s.chainHead(ctx)
+			return err
+		})
+	}
+	wg.Wait()
+
+	return resp
+}
+
+// Status reports per registry the blocks the ingestor and aggregator synced
+// to, how far they lag behind the chain head and when they and the cacher
+// last polled successfully. The state of the components isn't cached.
+func (s *Status) Status(w http.ResponseWriter, r *http.Request) {
+	resp := s.status.get(s.checkStatus)
+	resp.Components = s.components()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	encoding.ReplyJSON(w, r, http.StatusOK, &resp)
+}
+
+// checkStatus collects the status of the registries, it doesn't use the
+// context of the request as the result is shared with other requests.
+func (s *Status) checkStatus() apitypes.StatusResponse {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), checkTimeout)
+		resp        = apitypes.StatusResponse{ChainID: s.chainID, Registries: make(map[string]*apitypes.RegistryStatus)}
+		client      *ethclient.Client
+	)
+	defer cancel()
+
+	if s.dialer != nil {
+		var err error
+		if client, resp.Head, err = s.chainHead(ctx); err != nil {
+			s.log.WithError(err).Warn("unable to get chain head")
+			resp.Error = err.Error()
+		}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, reg := range s.registries {
+		reg := reg
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := s.registryStatus(ctx, reg, client, resp.Head)
+
+			mu.Lock()
+			resp.Registries[reg.name] = status
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return resp
+}
+
+// components returns the state of the supervised components.
+func (s *Status) components() []*apitypes.ComponentStatus {
+	if s.supervisor == nil {
+		return nil
+	}
+
+	var components []*apitypes.ComponentStatus
+	for _, c := range s.supervisor.Status() {
+		components = append(components, &apitypes.ComponentStatus{
+			Name:      c.Name,
+			Registry:  c.Registry,
+			Role:      string(c.Role),
+			State:     string(c.State),
+			Restarts:  c.Restarts,
+			LastError: c.LastError,
+			Since:     c.Since,
+		})
+	}
+	return components
+}
+
+func (s *Status) registryStatus(ctx context.Context, reg registry, client *ethclient.Client, head *apitypes.ChainHead) *apitypes.RegistryStatus {
+	status := &apitypes.RegistryStatus{
+		Contract:      reg.contract,
+		Confirmations: reg.confirmations,
+		Ingestor:      s.processStatus(ctx, reg, reg.process+"Ingestor", client, head),
+		Aggregator:    s.processStatus(ctx, reg, reg.process+"Aggregator", client, head),
+	}
+
+	if reg.cached {
+		status.Cacher = &apitypes.CacherStatus{}
+		if lastRefresh, err := s.lastPoll(ctx, reg.process+"Cacher", reg.contract); err != nil {
+			status.Cacher.Error = err.Error()
+		} else {
+			status.Cacher.LastRefresh = lastRefresh
+		}
+	}
+
+	return status
+}
+
+// processStatus reports the lag when the client and chain head are known.
+func (s *Status) processStatus(ctx context.Context, reg registry, process string, client *ethclient.Client, head *apitypes.ChainHead) *apitypes.ProcessStatus {
+	status := &apitypes.ProcessStatus{}
+
+	lastPoll, err := s.lastPoll(ctx, process, reg.contract)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.LastPoll = lastPoll
+
+	block, err := reg.current(ctx, process)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Block = block
+
+	if client == nil || head == nil || block == 0 {
+		return status
+	}
+
+	lagBlocks := uint64(0)
+	if head.Block > block {
+		lagBlocks = head.Block - block
+	}
+	status.LagBlocks = &lagBlocks
+
+	blockTime, err := chainsync.BlockTime(ctx, client, block)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	lagSeconds := head.Time.Sub(blockTime).Seconds()
+	if lagSeconds < 0 {
+		lagSeconds = 0
+	}
+	status.BlockTime = &blockTime
+	status.LagSeconds = &lagSeconds
+
+	return status
+}
+
+// lastPoll returns nil when the process never polled or polls aren't recorded.
+func (s *Status) lastPoll(ctx context.Context, process string, contract common.Address) (*time.Time, error) {
+	if s.polls == nil {
+		return nil, nil
+	}
+
+	at, err := s.polls.LastPoll(ctx, process, contract)
+	if err != nil || at.IsZero() {
+		return nil, err
+	}
+	return &at, nil
+}
+
+// chainHead returns the client to the RPC node and the latest block of the
+// chain. The client is dialed once and kept, when the chain head can't be
+// fetched it is closed and dialed again by the next check.
+func (s *Status) chainHead(ctx context.Context) (*ethclient.Client, *apitypes.ChainHead, error) {
+	s.clientMu.Lock()
+	client := s.client
+	if client == nil {
+		var err error
+		if client, err = s.dialer(ctx); err != nil {
+			s.clientMu.Unlock()
+			return nil, nil, err
+		}
+		s.client = client
+	}
+	s.clientMu.Unlock()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		s.clientMu.Lock()
+		if s.client == client {
+			s.client = nil
+			client.Close()
+		}
+		s.clientMu.Unlock()
+		return nil, nil, err
+	}
+
+	return client, &apitypes.ChainHead{
+		Block: header.Number.Uint64(),
+		Time:  time.Unix(int64(header.Time), 0).UTC(),
+	}, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// filter selects the events a client receives. Every criterion that is set
+// must match, within a criterion one of the values must match.
+type filter struct {
+	registries map[string]bool
+	owners     map[common.Address]bool
+	ids        map[types.ID]bool
+	eventTypes map[string]bool
+	cells      []h3light.Cell
+	pending    bool
+}
+
+// parseFilter parses the filter from the query parameters registry, owner,
+// id, type and cell that can be repeated or contain comma separated values,
+// and pending that excludes pending events when false.
+func parseFilter(query url.Values) (*filter, error) {
+	f := &filter{pending: true}
+
+	for _, registry := range values(query, "registry") {
+		switch registry {
+		case eventbus.RegistryGateway, eventbus.RegistryMapper, eventbus.RegistryRouter:
+		default:
+			return nil, fmt.Errorf("invalid registry %q", registry)
+		}
+		if f.registries == nil {
+			f.registries = make(map[string]bool)
+		}
+		f.registries[registry] = true
+	}
+
+	for _, owner := range values(query, "owner") {
+		if !common.IsHexAddress(owner) {
+			return nil, fmt.Errorf("invalid owner %q", owner)
+		}
+		if f.owners == nil {
+			f.owners = make(map[common.Address]bool)
+		}
+		f.owners[common.HexToAddress(owner)] = true
+	}
+
+	for _, id := range values(query, "id") {
+		if b, err := hex.DecodeString(strings.TrimPrefix(id, "0x")); err != nil || len(b) != len(types.ID{}) {
+			return nil, fmt.Errorf("invalid id %q", id)
+		}
+		if f.ids == nil {
+			f.ids = make(map[types.ID]bool)
+		}
+		f.ids[types.IDFromString(id)] = true
+	}
+
+	for _, eventType := range values(query, "type") {
+		if f.eventTypes == nil {
+			f.eventTypes = make(map[string]bool)
+		}
+		f.eventTypes[eventType] = true
+	}
+
+	for _, c := range values(query, "cell") {
+		cell, err := h3light.CellFromString(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cell %q", c)
+		}
+		f.cells = append(f.cells, cell)
+	}
+
+	if pending := query.Get("pending"); pending != "" {
+		include, err := strconv.ParseBool(pending)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending %q", pending)
+		}
+		f.pending = include
+	}
+
+	return f, nil
+}
+
+func values(query url.Values, key string) []string {
+	var values []string
+	for _, value := range query[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// includes returns true if the filter selects events of the registry.
+func (f *filter) includes(registry string) bool {
+	return f.registries == nil || f.registries[registry]
+}
+
+func (f *filter) match(event *eventbus.Event) bool {
+	if event.Pending && !f.pending {
+		return false
+	}
+	if !f.includes(event.Registry) {
+		return false
+	}
+	if f.eventTypes != nil && !f.eventTypes[event.Type()] {
+		return false
+	}
+	if f.ids != nil && !f.ids[event.ID()] {
+		return false
+	}
+	if f.owners != nil && !f.matchOwner(event) {
+		return false
+	}
+	if f.cells != nil && !f.matchCell(event) {
+		return false
+	}
+	return true
+}
+
+func (f *filter) matchOwner(event *eventbus.Event) bool {
+	for _, owner := range event.Owners() {
+		if f.owners[owner] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCell returns true if one of the event locations lies within one of the
+// filter cells.
+func (f *filter) matchCell(event *eventbus.Event) bool {
+	for _, location := range event.Locations() {
+		for _, cell := range f.cells {
+			if location.Resolution() >= cell.Resolution() && location.Parent(cell.Resolution()) == cell {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+)
+
+// backfillMaxBlockRange is the maximum number of blocks of events read from
+// the stores at once when a client resumes.
+const backfillMaxBlockRange = 10_000
+
+// errDropped is returned when the hub disconnected the client because it
+// didn't keep up or the subscription on the bus was lost.
+var errDropped = errors.New("stream interrupted, resume from the last cursor")
+
+// errCursorTooOld is returned when a client resumes from further behind the
+// ingestors than the stream backfills.
+var errCursorTooOld = errors.New("cursor is too old, resync from the REST API and stream without a cursor")
+
+// follow sends the confirmed events after cursor from the stores when cursor
+// is set, and then the events from the bus as they are published until ctx
+// expires or the client is dropped. KeepAlive is called when the stream is
+// idle.
+//
+// Events of the registries are ingested independently, confirmed events are
+// therefore deduplicated per registry against the last one sent.
+func (s *Stream) follow(ctx context.Context, f *filter, cursor *eventbus.Cursor, send func(*eventbus.Event) error, keepAlive func() error) error {
+	// subscribe before reading the stores so no event falls in between
+	sub := s.hub.subscribe(f)
+	defer s.hub.unsubscribe(sub)
+
+	last := make(map[string]eventbus.Cursor)
+	if cursor != nil {
+		for _, registry := range []string{eventbus.RegistryGateway, eventbus.RegistryMapper, eventbus.RegistryRouter} {
+			last[registry] = *cursor
+		}
+		if err := s.backfill(ctx, f, *cursor, last, send); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.dropped:
+			return errDropped
+		case <-ticker.C:
+			if err := keepAlive(); err != nil {
+				return err
+			}
+		case event := <-sub.events:
+			if !event.Pending {
+				position := event.Position()
+				if prev, ok := last[event.Registry]; ok && !prev.Before(position) {
+					continue
+				}
+				last[event.Registry] = position
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkCursor returns errCursorTooOld when cursor is more than
+// maxBackfillBlocks behind the ingestors, so a stream is rejected before it
+// starts.
+func (s *Stream) checkCursor(ctx context.Context, f *filter, cursor *eventbus.Cursor) error {
+	if cursor == nil {
+		return nil
+	}
+
+	current, err := s.currentBlock(ctx, f)
+	if err != nil {
+		return err
+	}
+	return s.checkBackfill(*cursor, current)
+}
+
+func (s *Stream) checkBackfill(cursor eventbus.Cursor, current uint64) error {
+	if current > cursor.BlockNumber && current-cursor.BlockNumber > s.maxBackfillBlocks {
+		return errCursorTooOld
+	}
+	return nil
+}
+
+// backfill sends the stored confirmed events after cursor up to the block
+// the ingestors are at, in chain order.
+func (s *Stream) backfill(ctx context.Context, f *filter, cursor eventbus.Cursor, last map[string]eventbus.Cursor, send func(*eventbus.Event) error) error {
+	current, err := s.currentBlock(ctx, f)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBackfill(cursor, current); err != nil {
+		return err
+	}
+
+	for from := cursor.BlockNumber; from <= current; from += backfillMaxBlockRange {
+		events, err := s.eventsFromTo(ctx, f, from, from+backfillMaxBlockRange)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			position := event.Position()
+			if !last[event.Registry].Before(position) || !f.match(event) {
+				continue
+			}
+			last[event.Registry] = position
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// currentBlock returns the highest block the ingestors of the registries in
+// the filter stored events for.
+func (s *Stream) currentBlock(ctx context.Context, f *filter) (uint64, error) {
+	var current uint64
+	for _, c := range []struct {
+		registry string
+		current  func(ctx context.Context, process string) (uint64, error)
+		process  string
+	}{
+		{eventbus.RegistryGateway, s.gatewayStore.CurrentBlock, "GatewayIngestor"},
+		{eventbus.RegistryMapper, s.mapperStore.CurrentBlock, "MapperIngestor"},
+		{eventbus.RegistryRouter, s.routerStore.CurrentBlock, "RouterIngestor"},
+	} {
+		if !f.includes(c.registry) {
+			continue
+		}
+
+		block, err := c.current(ctx, c.process)
+		if err != nil {
+			return 0, err
+		}
+		if block > current {
+			current = block
+		}
+	}
+	return current, nil
+}
+
+// eventsFromTo returns the confirmed events of the registries in the filter
+// in the blocks [from, to) in chain order.
+func (s *Stream) eventsFromTo(ctx context.Context, f *filter, from, to uint64) ([]*eventbus.Event, error) {
+	var events []*eventbus.Event
+
+	if f.includes(eventbus.RegistryGateway) {
+		gatewayEvents, err := s.gatewayStore.EventsFromTo(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range gatewayEvents {
+			events = append(events, eventbus.FromGatewayEvent(event, false))
+		}
+	}
+	if f.includes(eventbus.RegistryMapper) {
+		mapperEvents, err := s.mapperStore.EventsFromTo(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range mapperEvents {
+			events = append(events, eventbus.FromMapperEvent(event, false))
+		}
+	}
+	if f.includes(eventbus.RegistryRouter) {
+		routerEvents, err := s.routerStore.EventsFromTo(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range routerEvents {
+			events = append(events, eventbus.FromRouterEvent(event, false))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Position().Before(events[j].Position())
+	})
+
+	return events, nil
+}
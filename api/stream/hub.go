@@ -0,0 +1,97 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"sync"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+)
+
+// hub fans the events from the bus out to the connected clients.
+type hub struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber receives the events that match its filter.
+type subscriber struct {
+	filter *filter
+	events chan *eventbus.Event
+	// dropped is closed when the hub disconnected the subscriber
+	dropped chan struct{}
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[*subscriber]struct{})}
+}
+
+func (h *hub) subscribe(f *filter) *subscriber {
+	sub := &subscriber{
+		filter:  f,
+		events:  make(chan *eventbus.Event, subscriberBuffer),
+		dropped: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+func (h *hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	h.drop(sub)
+	h.mu.Unlock()
+}
+
+// publish sends the event to all subscribers it matches without blocking,
+// subscribers that don't keep up are dropped.
+func (h *hub) publish(event *eventbus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if !sub.filter.match(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			h.drop(sub)
+		}
+	}
+}
+
+func (h *hub) disconnectAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		h.drop(sub)
+	}
+}
+
+// drop removes the subscriber, h.mu must be held.
+func (h *hub) drop(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.dropped)
+	}
+}
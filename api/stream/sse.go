@@ -0,0 +1,105 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+)
+
+// sseRetry is the reconnection delay in milliseconds EventSource clients use.
+const sseRetry = 5000
+
+// ServeSSE streams the events as Server-Sent Events. Confirmed events carry
+// their cursor as event id so EventSource clients resume where they left off
+// when they reconnect, the event name is the registry of the event.
+func (s *Stream) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	log := logging.WithContext(r.Context())
+
+	f, cursor, err := parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch err := s.checkCursor(r.Context(), f, cursor); {
+	case errors.Is(err, errCursorTooOld):
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	case err != nil:
+		log.WithError(err).Error("error while getting sync state")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// the stream outlives the write timeout of the HTTP server
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.WithError(err).Error("unable to clear write deadline for event stream")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(frame []byte) error {
+		if _, err := w.Write(frame); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := write([]byte(fmt.Sprintf("retry: %d\n\n", sseRetry))); err != nil {
+		return
+	}
+
+	send := func(event *eventbus.Event) error {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		var frame bytes.Buffer
+		if event.Cursor != "" {
+			fmt.Fprintf(&frame, "id: %s\n", event.Cursor)
+		}
+		fmt.Fprintf(&frame, "event: %s\ndata: %s\n\n", event.Registry, payload)
+		return write(frame.Bytes())
+	}
+	keepAlive := func() error {
+		return write([]byte(": keep-alive\n\n"))
+	}
+
+	err = s.follow(r.Context(), f, cursor, send, keepAlive)
+	switch {
+	case errors.Is(err, errDropped), errors.Is(err, errCursorTooOld):
+		_ = write([]byte(fmt.Sprintf("event: error\ndata: %q\n\n", err.Error())))
+	case err != nil && r.Context().Err() == nil:
+		log.WithError(err).Warn("event stream failed")
+		_ = write([]byte("event: error\ndata: \"internal error\"\n\n"))
+	}
+}
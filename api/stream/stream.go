@@ -0,0 +1,181 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package stream pushes pending and confirmed gateway, mapper and router
+// events to clients over Server-Sent Events and WebSocket as the ingestors
+// store them.
+package stream
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// subscriberBuffer is the number of events buffered for a client, a
+	// client that falls further behind is disconnected and must resume from
+	// its last cursor.
+	subscriberBuffer = 1024
+	// keepAliveInterval is the interval idle streams send a keep-alive in.
+	keepAliveInterval = 30 * time.Second
+	// resubscribeInterval is the time to wait before subscribing to the bus
+	// again after the subscription failed.
+	resubscribeInterval = 5 * time.Second
+)
+
+// Options configure a Stream.
+type Options struct {
+	// Subscriber delivers the events published by the ingestors.
+	Subscriber eventbus.Subscriber
+
+	// The stores are used to send the confirmed events a resuming client
+	// missed.
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	// MaxBackfillBlocks is the maximum number of blocks a client can resume
+	// from behind the ingestors.
+	MaxBackfillBlocks uint64
+
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Stream serves the event stream endpoints.
+type Stream struct {
+	subscriber eventbus.Subscriber
+	hub        *hub
+	log        logrus.FieldLogger
+
+	gatewayStore gatewayStore.Store
+	mapperStore  mapperStore.Store
+	routerStore  routerStore.Store
+
+	maxBackfillBlocks uint64
+}
+
+// New creates a Stream with the given options.
+func New(opts Options) *Stream {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Stream{
+		subscriber:   opts.Subscriber,
+		hub:          newHub(),
+		log:          opts.Logger,
+		gatewayStore: opts.GatewayStore,
+		mapperStore:  opts.MapperStore,
+		routerStore:  opts.RouterStore,
+
+		maxBackfillBlocks: opts.MaxBackfillBlocks,
+	}
+}
+
+// NewStream creates a Stream from the config.
+func NewStream() (*Stream, error) {
+	bus, err := eventbus.NewBus()
+	if err != nil {
+		return nil, err
+	}
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Subscriber:   bus,
+		GatewayStore: gatewayStore,
+		MapperStore:  mapperStore,
+		RouterStore:  routerStore,
+
+		MaxBackfillBlocks: viper.GetUint64(config.CONFIG_EVENTS_API_MAX_BACKFILL_BLOCKS),
+	}), nil
+}
+
+func (s *Stream) Bind(root *chi.Mux) {
+	root.Route("/events/v1", func(router chi.Router) {
+		router.Get("/stream", s.ServeSSE)
+		router.Get("/ws", s.ServeWebSocket)
+	})
+}
+
+// Run forwards the events from the bus to the connected clients until ctx
+// expires. When the subscription is lost all clients are disconnected, they
+// resume from their last cursor.
+func (s *Stream) Run(ctx context.Context) {
+	for {
+		events, err := s.subscriber.Subscribe(ctx)
+		if err != nil {
+			s.log.WithError(err).Error("unable to subscribe to event bus")
+		} else {
+			s.log.Info("subscribed to event bus")
+			for event := range events {
+				s.hub.publish(event)
+			}
+		}
+
+		s.hub.disconnectAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+// parseRequest returns the filter and the cursor to resume from of a stream
+// request. The cursor query parameter takes precedence over the
+// Last-Event-ID header EventSource sends when it reconnects.
+func parseRequest(r *http.Request) (*filter, *eventbus.Cursor, error) {
+	f, err := parseFilter(r.URL.Query())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := r.URL.Query().Get("cursor")
+	if c == "" {
+		c = r.Header.Get("Last-Event-ID")
+	}
+	if c == "" {
+		return f, nil, nil
+	}
+
+	cursor, err := eventbus.ParseCursor(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, &cursor, nil
+}
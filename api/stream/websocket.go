@@ -0,0 +1,105 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/gorilla/websocket"
+)
+
+// wsWriteTimeout is the time a WebSocket client has to accept a message.
+const wsWriteTimeout = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	// the API is public, its CORS policy allows all origins as well
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// ServeWebSocket streams the events as JSON messages over a WebSocket. The
+// connection is closed with code 1013 (try again later) when the client must
+// resume from its last cursor.
+func (s *Stream) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	log := logging.WithContext(r.Context())
+
+	f, cursor, err := parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch err := s.checkCursor(r.Context(), f, cursor); {
+	case errors.Is(err, errCursorTooOld):
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	case err != nil:
+		log.WithError(err).Error("error while getting sync state")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		log.WithError(err).Debug("unable to upgrade to WebSocket")
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// clients don't send messages, but reading is required to handle control
+	// frames and to notice the client went away
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event *eventbus.Event) error {
+		if err := conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(event)
+	}
+	keepAlive := func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+	}
+
+	code, reason := websocket.CloseNormalClosure, ""
+	err = s.follow(ctx, f, cursor, send, keepAlive)
+	switch {
+	case errors.Is(err, errDropped):
+		code, reason = websocket.CloseTryAgainLater, err.Error()
+	case errors.Is(err, errCursorTooOld):
+		code, reason = websocket.ClosePolicyViolation, err.Error()
+	case err != nil && ctx.Err() == nil:
+		log.WithError(err).Warn("event stream failed")
+		code, reason = websocket.CloseInternalServerErr, "internal error"
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteTimeout))
+}
@@ -0,0 +1,388 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// Layers that tiles are served for.
+const (
+	LayerGateways           = "gateways"
+	LayerGatewayDensity     = "gateway-density"
+	LayerAssumedCoverage    = "assumed-coverage"
+	LayerCoverage           = "coverage"
+	LayerUnverifiedCoverage = "unverified-coverage"
+)
+
+const (
+	// gatewaysMinZoom is the zoom level from which gateways are shown, the
+	// density is shown below it.
+	gatewaysMinZoom = 10
+	// coverageMinZoom is the zoom level from which the verified coverage is
+	// shown, the assumed coverage is shown below it.
+	coverageMinZoom = 8
+
+	densityMaxRes            = 9
+	assumedCoverageMaxRes    = 8
+	coverageMaxRes           = 10
+	unverifiedCoverageMaxRes = 8
+
+	// gatewayInterval is the interval the gateway tiles are rebuilt in.
+	gatewayInterval     = 5 * time.Minute
+	gatewayCacheControl = "public, max-age=300"
+	// coverageCacheControl is used for coverage at a given date, that only
+	// changes when the rewards are recalculated.
+	coverageCacheControl = "public, max-age=86400"
+	// unverifiedInterval is the interval the unverified coverage tiles are
+	// rebuilt in.
+	unverifiedInterval     = 3 * time.Hour
+	unverifiedCacheControl = "public, max-age=10800"
+)
+
+var errInvalidDate = errors.New("invalid date")
+
+// Layers returns the names of the layers that tiles are served for.
+func Layers() []string {
+	return []string{LayerGateways, LayerGatewayDensity, LayerAssumedCoverage, LayerCoverage, LayerUnverifiedCoverage}
+}
+
+// layer is a layer that tiles can be requested for.
+type layer struct {
+	// minZoom is the lowest zoom level the layer has features at, tiles
+	// below it are empty.
+	minZoom int
+	// dated layers show the coverage at the date query parameter, which
+	// defaults to the latest rewards date.
+	dated bool
+	// source returns the state of the data the layer is built from, the date
+	// is nil when it isn't given.
+	source func(ctx context.Context, date *time.Time) (*source, error)
+	// features adds the features in the tile of the builder.
+	features func(ctx context.Context, b *layerBuilder, src *source) error
+}
+
+// source is the state of the data a tile is built from.
+type source struct {
+	// version changes when the data changes, it keys the tile cache and is
+	// included in the ETag.
+	version      string
+	at           time.Time
+	cacheControl string
+}
+
+func (t *Tiles) newLayers() map[string]*layer {
+	return map[string]*layer{
+		LayerGateways:           {minZoom: gatewaysMinZoom, source: t.gatewaySource, features: t.gateways},
+		LayerGatewayDensity:     {source: t.gatewaySource, features: t.gatewayDensity},
+		LayerAssumedCoverage:    {dated: true, source: t.coverageSource, features: t.assumedCoverage},
+		LayerCoverage:           {minZoom: coverageMinZoom, dated: true, source: t.coverageSource, features: t.coverage},
+		LayerUnverifiedCoverage: {source: t.unverifiedSource, features: t.unverifiedCoverage},
+	}
+}
+
+// gatewaySource versions gateway tiles by the interval they are rebuilt in,
+// the block the aggregator synced to changes on every poll while gateways
+// change rarely.
+func (t *Tiles) gatewaySource(ctx context.Context, _ *time.Time) (*source, error) {
+	return &source{
+		version:      time.Now().UTC().Truncate(gatewayInterval).Format(time.RFC3339),
+		cacheControl: gatewayCacheControl,
+	}, nil
+}
+
+// coverageSource versions coverage tiles by the latest rewards date, the
+// coverage at a date only changes when the rewards are (re)calculated.
+func (t *Tiles) coverageSource(ctx context.Context, date *time.Time) (*source, error) {
+	latest, err := t.rewardStore.GetLatestRewardsDateCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{at: latest, cacheControl: utils.Revalidate}
+	if date != nil {
+		if latest.Before(*date) {
+			return nil, errInvalidDate
+		}
+		src.at, src.cacheControl = *date, coverageCacheControl
+	}
+	src.version = fmt.Sprintf("%s-%s", src.at.Format(time.DateOnly), latest.Format(time.DateOnly))
+
+	return src, nil
+}
+
+// unverifiedSource versions unverified coverage tiles by the interval they
+// are rebuilt in, unverified mappings arrive continuously.
+func (t *Tiles) unverifiedSource(ctx context.Context, _ *time.Time) (*source, error) {
+	return &source{
+		version:      time.Now().UTC().Truncate(unverifiedInterval).Format(time.RFC3339),
+		cacheControl: unverifiedCacheControl,
+	}, nil
+}
+
+func (t *Tiles) gateways(ctx context.Context, b *layerBuilder, src *source) error {
+	var (
+		mu       sync.Mutex
+		gateways []*types.Gateway
+	)
+	err := forEachRegion(ctx, b.tile.cells(b.tile.regionResolution(maxResolution)), func(ctx context.Context, region h3light.Cell) error {
+		gws, err := t.gatewayStore.GetInCell(ctx, region)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		gateways = append(gateways, gws...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// keep the order of the features stable for the tile cache and clients
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].ID.String() < gateways[j].ID.String()
+	})
+	for _, gw := range gateways {
+		if gw.Location == nil {
+			continue
+		}
+		properties := map[string]interface{}{
+			"id":      gw.ID.String(),
+			"owner":   gw.Owner.Hex(),
+			"version": gw.Version,
+			"cell":    gw.Location.String(),
+		}
+		if gw.FrequencyPlan != nil {
+			properties["frequencyPlan"] = string(*gw.FrequencyPlan)
+		}
+		if gw.AntennaGain != nil {
+			properties["antennaGain"] = *gw.AntennaGain
+		}
+		if gw.Altitude != nil {
+			properties["altitude"] = *gw.Altitude
+		}
+		lat, lon := gw.Location.LatLon()
+		b.addPoint(0, lat, lon, properties)
+	}
+
+	return nil
+}
+
+func (t *Tiles) gatewayDensity(ctx context.Context, b *layerBuilder, src *source) error {
+	var (
+		res    = minInt(b.tile.Resolution(), densityMaxRes)
+		mu     sync.Mutex
+		counts = make(map[h3light.Cell]uint64)
+	)
+	err := forEachRegion(ctx, b.tile.cells(b.tile.regionResolution(res)), func(ctx context.Context, region h3light.Cell) error {
+		regionCounts, err := t.gatewayStore.GetCountInCellAtRes(ctx, region, res)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		for cell, count := range regionCounts {
+			counts[cell] += count
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cell := range sortedCells(counts) {
+		addCell(b, cell, map[string]interface{}{"count": counts[cell]})
+	}
+
+	return nil
+}
+
+func (t *Tiles) assumedCoverage(ctx context.Context, b *layerBuilder, src *source) error {
+	res := minInt(b.tile.Resolution(), assumedCoverageMaxRes)
+	cells, err := t.cellsInRegions(ctx, b.tile, res, func(ctx context.Context, region h3light.Cell) ([]h3light.Cell, error) {
+		return t.mappingStore.GetAssumedCoverageLocationsInRegionAtWithRes(ctx, region, src.at, res)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cell := range cells {
+		addCell(b, cell, nil)
+	}
+
+	return nil
+}
+
+func (t *Tiles) unverifiedCoverage(ctx context.Context, b *layerBuilder, src *source) error {
+	res := minInt(b.tile.Resolution(), unverifiedCoverageMaxRes)
+	cells, err := t.cellsInRegions(ctx, b.tile, res, func(ctx context.Context, region h3light.Cell) ([]h3light.Cell, error) {
+		return t.mappingStore.GetAssumedUnverifiedCoverageLocationsInRegionWithRes(ctx, region, res)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cell := range cells {
+		addCell(b, cell, nil)
+	}
+
+	return nil
+}
+
+// coverage shows per cell the best RSSI a mapper was received with and the
+// number of gateways that received mappers in it.
+func (t *Tiles) coverage(ctx context.Context, b *layerBuilder, src *source) error {
+	type cellCoverage struct {
+		rssi     int
+		gateways map[types.ID]struct{}
+	}
+
+	var (
+		res      = minInt(b.tile.Resolution(), coverageMaxRes)
+		mu       sync.Mutex
+		coverage = make(map[h3light.Cell]*cellCoverage)
+	)
+	err := forEachRegion(ctx, b.tile.cells(b.tile.regionResolution(res)), func(ctx context.Context, region h3light.Cell) error {
+		chs, err := t.mappingStore.GetCoverageInRegionAt(ctx, region, src.at)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ch := range chs {
+			cell := ch.Location
+			if cell.Resolution() > res {
+				cell = cell.Parent(res)
+			}
+			cc, ok := coverage[cell]
+			if !ok {
+				cc = &cellCoverage{rssi: ch.RSSI, gateways: make(map[types.ID]struct{})}
+				coverage[cell] = cc
+			}
+			if ch.RSSI > cc.rssi {
+				cc.rssi = ch.RSSI
+			}
+			cc.gateways[ch.GatewayID] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cell := range sortedCells(coverage) {
+		addCell(b, cell, map[string]interface{}{
+			"rssi":     coverage[cell].rssi,
+			"gateways": len(coverage[cell].gateways),
+		})
+	}
+
+	return nil
+}
+
+// cellsInRegions returns the distinct cells in the regions that cover the
+// tile, ordered by index.
+func (t *Tiles) cellsInRegions(ctx context.Context, tile Tile, res int, get func(ctx context.Context, region h3light.Cell) ([]h3light.Cell, error)) ([]h3light.Cell, error) {
+	var (
+		mu    sync.Mutex
+		cells = make(map[h3light.Cell]struct{})
+	)
+	err := forEachRegion(ctx, tile.cells(tile.regionResolution(res)), func(ctx context.Context, region h3light.Cell) error {
+		regionCells, err := get(ctx, region)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		for _, cell := range regionCells {
+			cells[cell] = struct{}{}
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedCells(cells), nil
+}
+
+// forEachRegion calls fn concurrently for each region and returns the first
+// error.
+func forEachRegion(ctx context.Context, regions []h3light.Cell, fn func(ctx context.Context, region h3light.Cell) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(regions))
+	)
+
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region h3light.Cell) {
+			defer wg.Done()
+			if errs[i] = fn(ctx, region); errs[i] != nil {
+				cancel()
+			}
+		}(i, region)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCell adds the cell as polygon, the cell index is both the feature id and
+// the cell property.
+func addCell(b *layerBuilder, cell h3light.Cell, properties map[string]interface{}) {
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+	properties["cell"] = cell.String()
+	b.addPolygon(uint64(cell), geo.CellBoundary(cell), properties)
+}
+
+func sortedCells[V any](cells map[h3light.Cell]V) []h3light.Cell {
+	sorted := make([]h3light.Cell, 0, len(cells))
+	for cell := range cells {
+		sorted = append(sorted, cell)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
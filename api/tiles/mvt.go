@@ -0,0 +1,313 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tiles
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers and values of the Mapbox vector tile specification 2.1.
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureID       = 1
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueDouble = 3
+	valueInt    = 4
+	valueUint   = 5
+	valueBool   = 7
+
+	geomPoint   = 1
+	geomPolygon = 3
+
+	cmdMoveTo    = 1
+	cmdLineTo    = 2
+	cmdClosePath = 7
+
+	// extent is the number of units along the side of a tile.
+	extent = 4096
+	// buffer is the number of units around the tile features are kept in so
+	// the tiles seamlessly join.
+	buffer = 64
+)
+
+// layerBuilder collects the features of a tile layer in tile coordinates.
+type layerBuilder struct {
+	name     string
+	tile     Tile
+	keys     []string
+	keyIndex map[string]uint32
+	values   []interface{}
+	valIndex map[interface{}]uint32
+	features [][]byte
+}
+
+func newLayerBuilder(name string, tile Tile) *layerBuilder {
+	return &layerBuilder{
+		name:     name,
+		tile:     tile,
+		keyIndex: make(map[string]uint32),
+		valIndex: make(map[interface{}]uint32),
+	}
+}
+
+// addPoint adds a point feature, it is dropped when it is outside the tile
+// and its buffer.
+func (l *layerBuilder) addPoint(id uint64, lat, lon float64, properties map[string]interface{}) {
+	x, y := l.tile.project(lat, lon)
+	if !l.tile.contains(x, y, x, y) {
+		return
+	}
+
+	var geometry []byte
+	geometry = protowire.AppendVarint(geometry, command(cmdMoveTo, 1))
+	geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(x))
+	geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(y))
+
+	l.addFeature(id, geomPoint, geometry, properties)
+}
+
+// addPolygon adds a polygon feature with a single ring of lat, lon vertices,
+// it is dropped when it is outside the tile and its buffer or when it is too
+// small to be visible.
+func (l *layerBuilder) addPolygon(id uint64, vertices [][2]float64, properties map[string]interface{}) {
+	var (
+		ring                   = make([][2]int64, 0, len(vertices))
+		minX, minY, maxX, maxY = int64(math.MaxInt64), int64(math.MaxInt64), int64(math.MinInt64), int64(math.MinInt64)
+	)
+	for _, v := range vertices {
+		x, y := l.tile.project(v[0], v[1])
+		if len(ring) > 0 && ring[len(ring)-1] == [2]int64{x, y} {
+			continue
+		}
+		ring = append(ring, [2]int64{x, y})
+		minX, maxX = min64(minX, x), max64(maxX, x)
+		minY, maxY = min64(minY, y), max64(maxY, y)
+	}
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 || !l.tile.contains(minX, minY, maxX, maxY) {
+		return
+	}
+
+	// exterior rings are clockwise in tile coordinates, which have y
+	// pointing down, so their area by the surveyor's formula is positive
+	var area int64
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		area += ring[i][0]*ring[j][1] - ring[j][0]*ring[i][1]
+	}
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+
+	var (
+		geometry []byte
+		cx, cy   int64
+	)
+	for i, p := range ring {
+		switch i {
+		case 0:
+			geometry = protowire.AppendVarint(geometry, command(cmdMoveTo, 1))
+		case 1:
+			geometry = protowire.AppendVarint(geometry, command(cmdLineTo, len(ring)-1))
+		}
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(p[0]-cx))
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(p[1]-cy))
+		cx, cy = p[0], p[1]
+	}
+	geometry = protowire.AppendVarint(geometry, command(cmdClosePath, 1))
+
+	l.addFeature(id, geomPolygon, geometry, properties)
+}
+
+func (l *layerBuilder) addFeature(id uint64, geomType uint64, geometry []byte, properties map[string]interface{}) {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var tags []byte
+	for _, k := range keys {
+		v := normalize(properties[k])
+		if v == nil {
+			continue
+		}
+		tags = protowire.AppendVarint(tags, uint64(l.key(k)))
+		tags = protowire.AppendVarint(tags, uint64(l.value(v)))
+	}
+
+	var feature []byte
+	if id != 0 {
+		feature = protowire.AppendTag(feature, featureID, protowire.VarintType)
+		feature = protowire.AppendVarint(feature, id)
+	}
+	if len(tags) > 0 {
+		feature = protowire.AppendTag(feature, featureTags, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, tags)
+	}
+	feature = protowire.AppendTag(feature, featureType, protowire.VarintType)
+	feature = protowire.AppendVarint(feature, geomType)
+	feature = protowire.AppendTag(feature, featureGeometry, protowire.BytesType)
+	feature = protowire.AppendBytes(feature, geometry)
+
+	l.features = append(l.features, feature)
+}
+
+func (l *layerBuilder) key(k string) uint32 {
+	i, ok := l.keyIndex[k]
+	if !ok {
+		i = uint32(len(l.keys))
+		l.keyIndex[k] = i
+		l.keys = append(l.keys, k)
+	}
+	return i
+}
+
+func (l *layerBuilder) value(v interface{}) uint32 {
+	i, ok := l.valIndex[v]
+	if !ok {
+		i = uint32(len(l.values))
+		l.valIndex[v] = i
+		l.values = append(l.values, v)
+	}
+	return i
+}
+
+// normalize converts a property to the string, double, int, uint or bool
+// vector tiles support, nil is returned for properties that can't be encoded.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string, float64, int64, uint64, bool:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return nil
+	}
+}
+
+// encode appends the layer to the tile, layers without features are left
+// out.
+func (l *layerBuilder) encode(tile []byte) []byte {
+	if len(l.features) == 0 {
+		return tile
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, layerVersion, protowire.VarintType)
+	b = protowire.AppendVarint(b, 2)
+	b = protowire.AppendTag(b, layerName, protowire.BytesType)
+	b = protowire.AppendString(b, l.name)
+	for _, f := range l.features {
+		b = protowire.AppendTag(b, layerFeatures, protowire.BytesType)
+		b = protowire.AppendBytes(b, f)
+	}
+	for _, k := range l.keys {
+		b = protowire.AppendTag(b, layerKeys, protowire.BytesType)
+		b = protowire.AppendString(b, k)
+	}
+	for _, v := range l.values {
+		b = protowire.AppendTag(b, layerValues, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeValue(v))
+	}
+	b = protowire.AppendTag(b, layerExtent, protowire.VarintType)
+	b = protowire.AppendVarint(b, extent)
+
+	tile = protowire.AppendTag(tile, tileLayers, protowire.BytesType)
+	return protowire.AppendBytes(tile, b)
+}
+
+func encodeValue(v interface{}) []byte {
+	var b []byte
+	switch v := v.(type) {
+	case string:
+		b = protowire.AppendTag(b, valueString, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	case float64:
+		b = protowire.AppendTag(b, valueDouble, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v))
+	case int64:
+		b = protowire.AppendTag(b, valueInt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case uint64:
+		b = protowire.AppendTag(b, valueUint, protowire.VarintType)
+		b = protowire.AppendVarint(b, v)
+	case bool:
+		b = protowire.AppendTag(b, valueBool, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v))
+	}
+	return b
+}
+
+func command(id uint64, count int) uint64 {
+	return id&0x7 | uint64(count)<<3
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tiles
+
+import (
+	"math"
+
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+)
+
+const (
+	// MaxZoom is the highest zoom level tiles are served for.
+	MaxZoom = 18
+	// maxResolution is the finest H3 resolution.
+	maxResolution = 15
+	// maxLat is the latitude where web mercator tiles end.
+	maxLat = 85.05112878
+	// zoomPerResolution is the number of zoom levels a resolution spans, a
+	// zoom level halves the side of a tile while a resolution divides the
+	// edge of a cell by the square root of 7.
+	zoomPerResolution = 1.4036774610288021 // log(sqrt(7)) / log(2)
+	// regionOffset is the number of resolutions the cells the data of a tile
+	// is fetched in are above the resolution of the cells shown in the tile.
+	regionOffset = 3
+	// maxSamples bounds the number of locations sampled along a side of a
+	// tile to find the cells that cover it.
+	maxSamples = 256
+)
+
+// Tile is a web mercator tile in the XYZ scheme, the tile 0/0/0 covers the
+// world and y increases to the south.
+type Tile struct {
+	Z, X, Y int
+}
+
+// Valid returns whether the tile exists and its zoom level is served.
+func (t Tile) Valid() bool {
+	n := 1 << t.Z
+	return t.Z >= 0 && t.Z <= MaxZoom && t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// Resolution returns the H3 resolution of the cells shown at the zoom level
+// of the tile, cells are about 5 to 10 pixels across on a 256 pixel tile.
+func (t Tile) Resolution() int {
+	return int(math.Round(float64(t.Z) / zoomPerResolution))
+}
+
+// project returns the location in tile coordinates.
+func (t Tile) project(lat, lon float64) (int64, int64) {
+	var (
+		n    = float64(int(1) << t.Z)
+		sinY = math.Sin(math.Max(-maxLat, math.Min(maxLat, lat)) * math.Pi / 180)
+		x    = (lon+180)/360*n - float64(t.X)
+		y    = (0.5-math.Log((1+sinY)/(1-sinY))/(4*math.Pi))*n - float64(t.Y)
+	)
+	return int64(math.Round(x * extent)), int64(math.Round(y * extent))
+}
+
+// unproject returns the location of the tile coordinates.
+func (t Tile) unproject(x, y float64) (float64, float64) {
+	var (
+		n   = float64(int(1) << t.Z)
+		lon = (float64(t.X)+x/extent)/n*360 - 180
+		lat = math.Atan(math.Sinh(math.Pi*(1-2*(float64(t.Y)+y/extent)/n))) * 180 / math.Pi
+	)
+	return lat, lon
+}
+
+// contains returns whether the bounding box in tile coordinates overlaps the
+// tile and its buffer.
+func (t Tile) contains(minX, minY, maxX, maxY int64) bool {
+	return maxX >= -buffer && minX <= extent+buffer && maxY >= -buffer && minY <= extent+buffer
+}
+
+// cells returns the cells at the resolution that cover the tile and its
+// buffer. The tile is sampled at less than half the edge length of the cells
+// and with a margin of a few cells so that every cell that overlaps the tile
+// contains a sample, the number of samples is bounded for cells that are
+// small compared to the tile.
+func (t Tile) cells(res int) []h3light.Cell {
+	var (
+		north, _ = t.unproject(0, 0)
+		south, _ = t.unproject(0, extent)
+		// units are the smallest near the equator and the largest near the
+		// poles
+		equator = math.Min(math.Abs(north), math.Abs(south))
+		pole    = math.Min(math.Max(math.Abs(north), math.Abs(south)), 85)
+		edge    = geo.AverageEdgeLength(res)
+		unit    = 2 * math.Pi * geo.EarthRadius / float64(int(1)<<t.Z) / extent
+		step    = 0.5 * edge / (unit * math.Cos(equator*math.Pi/180))
+		margin  = math.Min(3*edge/(unit*math.Cos(pole*math.Pi/180)), extent)
+	)
+	if north*south < 0 {
+		step = 0.5 * edge / unit
+	}
+
+	var (
+		from    = -buffer - margin
+		to      = extent + buffer + margin
+		samples = int(math.Min(math.Ceil((to-from)/step), maxSamples))
+		seen    = make(map[h3light.Cell]struct{})
+		cells   []h3light.Cell
+	)
+	for i := 0; i <= samples; i++ {
+		for j := 0; j <= samples; j++ {
+			lat, lon := t.unproject(from+(to-from)*float64(i)/float64(samples), from+(to-from)*float64(j)/float64(samples))
+			if lon < -180 || lon >= 180 {
+				lon = math.Mod(math.Mod(lon+180, 360)+360, 360) - 180
+			}
+			cell := h3light.LatLonToCell(lat, lon, res)
+			if _, ok := seen[cell]; !ok {
+				seen[cell] = struct{}{}
+				cells = append(cells, cell)
+			}
+		}
+	}
+
+	return cells
+}
+
+// regionResolution returns the resolution of the cells the data of cells at
+// the resolution is fetched in for the tile.
+func (t Tile) regionResolution(res int) int {
+	region := t.Resolution() - regionOffset
+	if region > res {
+		region = res
+	}
+	if region < 0 {
+		region = 0
+	}
+	return region
+}
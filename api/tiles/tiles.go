@@ -0,0 +1,254 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tiles serves gateways, gateway density and coverage as Mapbox
+// vector tiles. The H3 resolution of the cells in a tile follows the zoom
+// level so maps stay light from the world down to street level.
+package tiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	mappingStore "github.com/ThingsIXFoundation/data-aggregator/mapping/store"
+	rewardStore "github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/go-chi/chi/v5"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/singleflight"
+)
+
+// ContentType is the media type of Mapbox vector tiles.
+const ContentType = "application/vnd.mapbox-vector-tile"
+
+// requestTimeout bounds the time to build a tile, it stays under the write
+// timeout of the API server.
+const requestTimeout = 10 * time.Second
+
+// Options configure Tiles.
+type Options struct {
+	GatewayStore gatewayStore.Store
+	MappingStore mappingStore.Store
+	RewardStore  rewardStore.Store
+	// CacheSize is the number of encoded tiles kept in memory, 0 disables
+	// the cache.
+	CacheSize int
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Tiles serves the vector tile endpoints.
+type Tiles struct {
+	gatewayStore gatewayStore.Store
+	mappingStore mappingStore.Store
+	rewardStore  rewardStore.Store
+	// cache holds encoded tiles by layer, tile and version of the data they
+	// are built from, it is nil when disabled.
+	cache *lru.Cache[string, []byte]
+	// builds makes concurrent requests for a tile that isn't cached share a
+	// single build.
+	builds singleflight.Group
+	layers map[string]*layer
+	log    logrus.FieldLogger
+}
+
+// New creates Tiles with the given options.
+func New(opts Options) (*Tiles, error) {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	t := &Tiles{
+		gatewayStore: opts.GatewayStore,
+		mappingStore: opts.MappingStore,
+		rewardStore:  opts.RewardStore,
+		log:          opts.Logger,
+	}
+	t.layers = t.newLayers()
+
+	if opts.CacheSize > 0 {
+		cache, err := lru.New[string, []byte](opts.CacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create tile cache: %w", err)
+		}
+		t.cache = cache
+	}
+
+	return t, nil
+}
+
+// NewTiles creates Tiles from the config.
+func NewTiles() (*Tiles, error) {
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mappingStore, err := mappingStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	rewardStore, err := rewardStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		GatewayStore: gatewayStore,
+		MappingStore: mappingStore,
+		RewardStore:  rewardStore,
+		CacheSize:    viper.GetInt(config.CONFIG_TILES_API_CACHE_SIZE),
+	})
+}
+
+func (t *Tiles) Bind(root *chi.Mux) {
+	root.Route("/tiles", func(r chi.Router) {
+		r.Get("/{layer}/{z:[0-9]+}/{x:[0-9]+}/{y:[0-9]+}.mvt", t.ServeTile)
+	})
+}
+
+// ServeTile replies the features of the layer in the tile. Tiles are cached
+// until the data they are built from changes, gateway layers are rebuilt in
+// an interval and coverage layers when the latest rewards date changes.
+func (t *Tiles) ServeTile(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), requestTimeout)
+		name        = chi.URLParam(r, "layer")
+	)
+	defer cancel()
+
+	l, ok := t.layers[name]
+	if !ok {
+		http.Error(w, "unknown layer", http.StatusNotFound)
+		return
+	}
+
+	tile, err := tileFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var date *time.Time
+	if l.dated && r.URL.Query().Has("date") {
+		at, err := time.Parse(time.DateOnly, r.URL.Query().Get("date"))
+		if err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		date = &at
+	}
+
+	src, err := l.source(ctx, date)
+	if errors.Is(err, errInvalidDate) {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.WithError(err).Error("unable to get version of tile data")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	etag := utils.NewETag(r, src.version)
+	if etag.NotModified(w, r, src.cacheControl) {
+		return
+	}
+
+	key := fmt.Sprintf("%s/%d/%d/%d/%s", name, tile.Z, tile.X, tile.Y, src.version)
+	data, ok := t.cachedTile(key)
+	if !ok {
+		data, err = t.buildTile(ctx, key, l, name, tile, src)
+		if err != nil {
+			log.WithError(err).WithField("tile", key).Error("unable to build tile")
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	etag.Set(w, src.cacheControl)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// buildTile builds and caches the tile, concurrent requests for the same tile
+// wait for the build that is in progress.
+func (t *Tiles) buildTile(ctx context.Context, key string, l *layer, name string, tile Tile, src *source) ([]byte, error) {
+	ch := t.builds.DoChan(key, func() (interface{}, error) {
+		// the build is shared, it must not stop when the request that
+		// started it is cancelled
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		b := newLayerBuilder(name, tile)
+		if tile.Z >= l.minZoom {
+			if err := l.features(ctx, b, src); err != nil {
+				return nil, err
+			}
+		}
+		data := b.encode(nil)
+		if t.cache != nil {
+			t.cache.Add(key, data)
+		}
+		return data, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *Tiles) cachedTile(key string) ([]byte, bool) {
+	if t.cache == nil {
+		return nil, false
+	}
+	return t.cache.Get(key)
+}
+
+func tileFromRequest(r *http.Request) (Tile, error) {
+	var (
+		tile Tile
+		err  error
+	)
+	for _, c := range []struct {
+		param string
+		value *int
+	}{{"z", &tile.Z}, {"x", &tile.X}, {"y", &tile.Y}} {
+		*c.value, err = strconv.Atoi(chi.URLParam(r, c.param))
+		if err != nil {
+			return tile, fmt.Errorf("invalid %s", c.param)
+		}
+	}
+	if !tile.Valid() {
+		return tile, fmt.Errorf("invalid tile, zoom levels up to %d are served", MaxZoom)
+	}
+	return tile, nil
+}
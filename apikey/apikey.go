@@ -0,0 +1,241 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apikey issues, revokes and verifies the keys clients use to get
+// their own rate limits on the API. A key is formatted as <id>.<secret>, only
+// the hash of the secret is stored.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store"
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/spf13/viper"
+)
+
+const (
+	idLength     = 16
+	secretLength = 32
+	// maxCachedKeys and maxUnknownIDs bound the caches of stored keys and of
+	// ids without a key, the least recently used entries are evicted first.
+	maxCachedKeys = 10000
+	maxUnknownIDs = 10000
+)
+
+// ErrInvalidKey is returned for keys that are malformed, unknown or revoked.
+var ErrInvalidKey = errors.New("invalid API key")
+
+// Issue creates and stores a key with the given name. The returned key is the
+// only copy of the secret.
+func Issue(ctx context.Context, store store.Store, name string) (string, *models.APIKey, error) {
+	id, err := randomHex(idLength)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomHex(secretLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := &models.APIKey{
+		ID:        id,
+		Name:      name,
+		Hash:      hash(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := store.StoreAPIKey(ctx, key); err != nil {
+		return "", nil, err
+	}
+
+	return id + "." + secret, key, nil
+}
+
+// Revoke revokes the key with the given id, revoking a revoked key is a no-op.
+func Revoke(ctx context.Context, store store.Store, id string) (*models.APIKey, error) {
+	key, err := store.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown API key: %s", id)
+	}
+	if key.Revoked() {
+		return key, nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	return key, store.StoreAPIKey(ctx, key)
+}
+
+// Options configure Keys.
+type Options struct {
+	Store store.Store
+	// CacheTTL is the time a key is cached, a revocation can take this long
+	// to take effect.
+	CacheTTL time.Duration
+}
+
+// Keys verifies API keys.
+type Keys struct {
+	store    store.Store
+	cacheTTL time.Duration
+
+	// known caches the stored keys and unknown the expiry of ids without a
+	// key, they are kept apart so a flood of unknown ids can't evict the
+	// stored keys
+	known   *lru.Cache[string, cachedKey]
+	unknown *lru.Cache[string, time.Time]
+}
+
+type cachedKey struct {
+	key     *models.APIKey
+	expires time.Time
+}
+
+// New creates Keys with the given options.
+func New(opts Options) *Keys {
+	// lru.New only fails for sizes below 1
+	known, _ := lru.New[string, cachedKey](maxCachedKeys)
+	unknown, _ := lru.New[string, time.Time](maxUnknownIDs)
+
+	return &Keys{
+		store:    opts.Store,
+		cacheTTL: opts.CacheTTL,
+		known:    known,
+		unknown:  unknown,
+	}
+}
+
+// NewKeys creates Keys from the config.
+func NewKeys() (*Keys, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Store:    store,
+		CacheTTL: viper.GetDuration(config.CONFIG_API_KEY_CACHE_TTL),
+	}), nil
+}
+
+// Verify returns the stored key for the given key, or ErrInvalidKey when the
+// key is malformed, unknown or revoked.
+func (k *Keys) Verify(ctx context.Context, key string) (*models.APIKey, error) {
+	id, secret, err := parse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := k.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return verify(stored, secret)
+}
+
+// Cached reports if the key verifies against the cache, Verify then doesn't
+// need the store.
+func (k *Keys) Cached(key string) bool {
+	id, secret, err := parse(key)
+	if err != nil {
+		return false
+	}
+	cached, ok := k.known.Get(id)
+	if !ok || !time.Now().Before(cached.expires) {
+		return false
+	}
+	_, err = verify(cached.key, secret)
+	return err == nil
+}
+
+// parse splits the key in its id and secret.
+func parse(key string) (string, string, error) {
+	id, secret, ok := strings.Cut(key, ".")
+	if !ok || len(id) != 2*idLength || len(secret) != 2*secretLength {
+		return "", "", ErrInvalidKey
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", "", ErrInvalidKey
+	}
+	return id, secret, nil
+}
+
+// verify checks the secret against the stored key, which is nil when there
+// is no key with the id.
+func verify(stored *models.APIKey, secret string) (*models.APIKey, error) {
+	if stored == nil || stored.Revoked() {
+		return nil, ErrInvalidKey
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(secret)), []byte(stored.Hash)) != 1 {
+		return nil, ErrInvalidKey
+	}
+	return stored, nil
+}
+
+// get returns the key with the given id from the cache or the store, it
+// returns nil when there is no key with the id.
+func (k *Keys) get(ctx context.Context, id string) (*models.APIKey, error) {
+	now := time.Now()
+
+	if cached, ok := k.known.Get(id); ok && now.Before(cached.expires) {
+		return cached.key, nil
+	}
+	if expires, ok := k.unknown.Get(id); ok && now.Before(expires) {
+		return nil, nil
+	}
+
+	key, err := k.store.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		k.known.Remove(id)
+		k.unknown.Add(id, now.Add(k.cacheTTL))
+	} else {
+		k.unknown.Remove(id)
+		k.known.Add(id, cachedKey{key: key, expires: now.Add(k.cacheTTL)})
+	}
+
+	return key, nil
+}
+
+func hash(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// randomHex returns n random bytes hex encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+)
+
+type DBAPIKey struct {
+	ID   string
+	Name string `datastore:",noindex"`
+	// Hash is the hex encoded SHA-256 hash of the key secret.
+	Hash      string `datastore:",noindex"`
+	CreatedAt time.Time
+	RevokedAt time.Time `datastore:",noindex"`
+}
+
+func (e *DBAPIKey) Entity() string {
+	return "APIKey"
+}
+
+func (e *DBAPIKey) Key() string {
+	return e.ID
+}
+
+func (e *DBAPIKey) APIKey() *APIKey {
+	key := &APIKey{
+		ID:        e.ID,
+		Name:      e.Name,
+		Hash:      e.Hash,
+		CreatedAt: e.CreatedAt,
+	}
+	if !e.RevokedAt.IsZero() {
+		revokedAt := e.RevokedAt
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
+
+func NewDBAPIKey(key *APIKey) *DBAPIKey {
+	dbKey := &DBAPIKey{
+		ID:        key.ID,
+		Name:      key.Name,
+		Hash:      key.Hash,
+		CreatedAt: key.CreatedAt,
+	}
+	if key.RevokedAt != nil {
+		dbKey.RevokedAt = *key.RevokedAt
+	}
+	return dbKey
+}
+
+// APIKey identifies a client of the API, requests with a key are rate limited
+// per key instead of per IP. Only the hash of the secret is stored.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Revoked returns true when the key is revoked.
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
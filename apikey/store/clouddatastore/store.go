@@ -0,0 +1,109 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store/clouddatastore/models"
+	daclouddatastore "github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Store struct {
+	client *datastore.Client
+	log    logrus.FieldLogger
+}
+
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Client: client,
+	}), nil
+}
+
+// StoreAPIKey implements store.Store
+func (s *Store) StoreAPIKey(ctx context.Context, key *models.APIKey) error {
+	dbKey := models.NewDBAPIKey(key)
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(dbKey), dbKey)
+	if err != nil {
+		s.log.WithError(err).Errorf("error while storing API key %s in Cloud DataStore", key.ID)
+		return err
+	}
+
+	return nil
+}
+
+// GetAPIKey implements store.Store
+func (s *Store) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	dbKey := models.DBAPIKey{ID: id}
+
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbKey), &dbKey)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dbKey.APIKey(), nil
+}
+
+// GetAPIKeys implements store.Store
+func (s *Store) GetAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	q := datastore.NewQuery((&models.DBAPIKey{}).Entity()).Order("CreatedAt")
+
+	var dbKeys []*models.DBAPIKey
+	_, err := s.client.GetAll(ctx, q, &dbKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*models.APIKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		keys[i] = dbKey.APIKey()
+	}
+
+	return keys, nil
+}
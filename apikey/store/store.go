@@ -0,0 +1,43 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/spf13/viper"
+)
+
+type Store interface {
+	StoreAPIKey(ctx context.Context, key *models.APIKey) error
+	// GetAPIKey returns nil when there is no key with the id.
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+}
+
+func NewStore() (Store, error) {
+	store := viper.GetString(config.CONFIG_API_KEY_STORE)
+	if store == "clouddatastore" {
+		return clouddatastore.NewStore(context.Background())
+	} else {
+		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_API_KEY_STORE))
+	}
+}
@@ -18,31 +18,55 @@ package chainsync
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/sirupsen/logrus"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/spf13/viper"
 )
 
-func DialRpc(ctx context.Context) (*ethclient.Client, error) {
-	client, err := ethclient.DialContext(ctx, viper.GetString(config.CONFIG_CHAINSYNC_RPC_ENDPOINT))
-	if err != nil {
-		return nil, err
-	}
+// Dialer connects to an RPC node. The caller owns the returned client and
+// must close it when done.
+type Dialer func(ctx context.Context) (*ethclient.Client, error)
 
-	// ensure that service connected to the correct chain by checking the chain id
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, err
-	}
+// httpClient traces the calls to RPC nodes served over HTTP and records their
+// metrics.
+var httpClient = &http.Client{Transport: &rpcTransport{next: http.DefaultTransport}}
+
+// NewDialer returns a Dialer that connects to the RPC node at endpoint and
+// ensures that it serves the chain with the given chain id.
+func NewDialer(endpoint string, chainID uint64) Dialer {
+	return func(ctx context.Context) (*ethclient.Client, error) {
+		rpcClient, err := rpc.DialOptions(ctx, endpoint, rpc.WithHTTPClient(httpClient))
+		if err != nil {
+			return nil, err
+		}
+		client := ethclient.NewClient(rpcClient)
+
+		// ensure that service connected to the correct chain by checking the chain id
+		got, err := client.ChainID(ctx)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
 
-	if chainID.Uint64() != viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID) {
-		logrus.WithFields(logrus.Fields{
-			"got":      chainID,
-			"expected": viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID),
-		}).Fatal("connected to unexpected chain")
+		if got.Uint64() != chainID {
+			client.Close()
+			return nil, fmt.Errorf("connected to unexpected chain %s, expected %d", got, chainID)
+		}
+
+		return client, nil
 	}
+}
 
-	return client, nil
+// DialerFromConfig returns a Dialer for the RPC node in the config.
+func DialerFromConfig() Dialer {
+	return NewDialer(viper.GetString(config.CONFIG_CHAINSYNC_RPC_ENDPOINT), viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID))
+}
+
+// DialRpc connects to the RPC node in the config.
+func DialRpc(ctx context.Context) (*ethclient.Client, error) {
+	return DialerFromConfig()(ctx)
 }
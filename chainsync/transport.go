@@ -0,0 +1,132 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chainsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// errRPCFailed is recorded on the span of calls that failed without a
+// transport error.
+var errRPCFailed = errors.New("RPC call failed")
+
+// rpcTransport traces the JSON-RPC calls made through next and records their
+// latency and errors. Calls that are answered with a JSON-RPC error count as
+// failed.
+type rpcTransport struct {
+	next http.RoundTripper
+}
+
+func (t *rpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		start     = time.Now()
+		method    = rpcMethod(req)
+		ctx, span = tracing.Start(req.Context(), "rpc "+method,
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.method", method))
+	)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	failed := err != nil || resp.StatusCode != http.StatusOK || rpcFailed(resp)
+
+	metrics.ObserveRPC(ctx, method, start, failed)
+	if failed && err == nil {
+		tracing.End(span, errRPCFailed)
+	} else {
+		tracing.End(span, err)
+	}
+
+	return resp, err
+}
+
+type rpcMessage struct {
+	Method string          `json:"method"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// rpcMethod returns the JSON-RPC method of the request, batches are labelled
+// as batch.
+func rpcMethod(req *http.Request) string {
+	if req.GetBody == nil {
+		return "unknown"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "unknown"
+	}
+	defer body.Close()
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return "unknown"
+	}
+	if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '[' {
+		return "batch"
+	}
+
+	var msg rpcMessage
+	if err := json.Unmarshal(raw, &msg); err != nil || msg.Method == "" {
+		return "unknown"
+	}
+	return msg.Method
+}
+
+// rpcFailed reads the response to check for JSON-RPC errors and replaces the
+// body so the RPC client can still read it.
+func rpcFailed(resp *http.Response) bool {
+	raw, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		// pass the read error on to the RPC client
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), errReader{err}))
+		return true
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(raw))
+
+	var msgs []rpcMessage
+	if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '[' {
+		_ = json.Unmarshal(raw, &msgs)
+	} else {
+		var msg rpcMessage
+		_ = json.Unmarshal(raw, &msg)
+		msgs = append(msgs, msg)
+	}
+
+	for _, msg := range msgs {
+		if len(msg.Error) > 0 && string(msg.Error) != "null" {
+			return true
+		}
+	}
+	return false
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
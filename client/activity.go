@@ -0,0 +1,47 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/url"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NetworkActivity iterates over the activity of the network, newest first.
+// Only the activity with one of the types is returned, or all activity when
+// no types are given. A pageSize of 0 uses the default page size of the API.
+func (c *Client) NetworkActivity(types []string, pageSize int) *Iterator[*apitypes.Activity] {
+	return iterate[*apitypes.Activity](c, "/activity/v1", activityQuery(types), pageSize, "activities")
+}
+
+// OwnerActivity iterates over the activity of the devices the owner owns or
+// has owned and the rewards of the owner, newest first. Only the activity
+// with one of the types is returned, or all activity when no types are given.
+// A pageSize of 0 uses the default page size of the API.
+func (c *Client) OwnerActivity(owner common.Address, types []string, pageSize int) *Iterator[*apitypes.Activity] {
+	return iterate[*apitypes.Activity](c, "/activity/v1/owners/"+owner.Hex(), activityQuery(types), pageSize, "activities")
+}
+
+func activityQuery(types []string) url.Values {
+	query := url.Values{}
+	for _, t := range types {
+		query.Add("type", t)
+	}
+	return query
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client is a typed client for the HTTP API of the data aggregator.
+//
+// Requests are retried with exponential backoff on network errors and
+// temporary server errors, each attempt has its own timeout and retries stop
+// when the context deadline doesn't leave room for another attempt. Replies
+// are decoded into the apitypes the API encodes them from.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+)
+
+const (
+	defaultTimeout        = 15 * time.Second
+	defaultRetries        = 3
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+	// maxErrorMessageLength is the maximum number of bytes read from an
+	// error reply.
+	maxErrorMessageLength = 1024
+)
+
+// ErrNotFound is returned when the requested object doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// Error is returned when the API replies with an error status.
+type Error struct {
+	StatusCode int
+	// Message is the body of the reply.
+	Message string
+
+	retryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("aggregator replied with status %d: %s", e.StatusCode, e.Message)
+}
+
+// Is reports 404 replies as ErrNotFound.
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// temporary reports if a request that failed with this error can be retried.
+func (e *Error) temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Options configure a Client.
+type Options struct {
+	// BaseURL is the URL the API is served on, e.g. https://api.thingsix.com.
+	BaseURL string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Timeout is the time a single attempt can take, defaults to 15s.
+	Timeout time.Duration
+	// Retries is the number of times a failed request is retried, defaults
+	// to 3. Set it to a negative value to disable retries.
+	Retries int
+	// InitialBackoff is the time to wait before the first retry, it doubles
+	// for every retry up to MaxBackoff. Defaults to 250ms and 5s.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// UserAgent is sent with every request.
+	UserAgent string
+	// APIKey is sent with every request when set, requests with a key are
+	// rate limited per key instead of per IP.
+	APIKey string
+}
+
+// Client calls the HTTP API of the data aggregator.
+type Client struct {
+	baseURL        string
+	http           *http.Client
+	timeout        time.Duration
+	retries        int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	userAgent      string
+	apiKey         string
+}
+
+// New creates a Client with the given options.
+func New(opts Options) (*Client, error) {
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", opts.BaseURL)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.Retries == 0 {
+		opts.Retries = defaultRetries
+	} else if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultInitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = "ThingsIX-Aggregator-Client/" + utils.Version()
+	}
+
+	return &Client{
+		baseURL:        strings.TrimSuffix(base.String(), "/"),
+		http:           opts.HTTPClient,
+		timeout:        opts.Timeout,
+		retries:        opts.Retries,
+		initialBackoff: opts.InitialBackoff,
+		maxBackoff:     opts.MaxBackoff,
+		userAgent:      opts.UserAgent,
+		apiKey:         opts.APIKey,
+	}, nil
+}
+
+// get decodes the reply of a GET request into reply.
+func (c *Client) get(ctx context.Context, path string, query url.Values, reply interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, reply, true)
+}
+
+// post sends body as JSON and decodes the reply into reply when it isn't nil.
+// Only idempotent requests are retried.
+func (c *Client) post(ctx context.Context, path string, body, reply interface{}, idempotent bool) error {
+	return c.do(ctx, http.MethodPost, path, nil, body, reply, idempotent)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, reply interface{}, idempotent bool) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("unable to encode request: %w", err)
+		}
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	backoff := c.initialBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := c.attempt(ctx, method, u, payload, reply)
+		if err == nil {
+			return nil
+		}
+		if !retry || !idempotent || attempt >= c.retries || ctx.Err() != nil {
+			return err
+		}
+
+		wait := backoff
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.retryAfter > wait {
+			wait = apiErr.retryAfter
+		}
+		// don't wait for an attempt that can't finish before the deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// attempt executes a single request, it returns if the request can be retried
+// when it failed.
+func (c *Client) attempt(ctx context.Context, method, u string, payload []byte, reply interface{}) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageLength))
+		apiErr := &Error{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			apiErr.retryAfter = time.Duration(secs) * time.Second
+		}
+		return apiErr.temporary(), apiErr
+	}
+
+	if reply == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(reply); err != nil {
+		// the connection can break halfway through the reply
+		return true, fmt.Errorf("unable to decode reply: %w", err)
+	}
+
+	return false, nil
+}
+
+func newBatchRequest(ids []types.ID) *utils.BatchRequest {
+	req := &utils.BatchRequest{IDs: make([]string, len(ids))}
+	for i, id := range ids {
+		req.IDs[i] = id.String()
+	}
+	return req
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OwnedGateways iterates over the gateways of the owner. A pageSize of 0
+// uses the default page size of the API.
+func (c *Client) OwnedGateways(owner common.Address, pageSize int) *Iterator[*types.Gateway] {
+	return iterate[*types.Gateway](c, "/gateways/v1/owned/"+owner.Hex(), nil, pageSize, "gateways")
+}
+
+// SearchGateways iterates over the gateways that match the search. A pageSize
+// of 0 uses the default page size of the API.
+func (c *Client) SearchGateways(search GatewaySearch, pageSize int) *Iterator[*types.Gateway] {
+	q := url.Values{}
+	if search.FrequencyPlan != "" {
+		q.Set("frequencyPlan", search.FrequencyPlan)
+	}
+	if search.MinAntennaGain != nil {
+		q.Set("minAntennaGain", strconv.FormatFloat(float64(*search.MinAntennaGain), 'f', -1, 32))
+	}
+	if search.MaxAntennaGain != nil {
+		q.Set("maxAntennaGain", strconv.FormatFloat(float64(*search.MaxAntennaGain), 'f', -1, 32))
+	}
+	if search.MinAltitude != nil {
+		q.Set("minAltitude", strconv.FormatUint(uint64(*search.MinAltitude), 10))
+	}
+	if search.MaxAltitude != nil {
+		q.Set("maxAltitude", strconv.FormatUint(uint64(*search.MaxAltitude), 10))
+	}
+	if search.Version != nil {
+		q.Set("version", strconv.FormatUint(uint64(*search.Version), 10))
+	}
+	for _, owner := range search.Owners {
+		q.Add("owner", owner.Hex())
+	}
+	if search.Region != nil {
+		q.Set("region", search.Region.String())
+	}
+	if search.OnboardedFrom != nil {
+		q.Set("onboardedFrom", search.OnboardedFrom.Format(time.RFC3339))
+	}
+	if search.OnboardedTo != nil {
+		q.Set("onboardedTo", search.OnboardedTo.Format(time.RFC3339))
+	}
+	if search.EUI != "" {
+		q.Set("eui", search.EUI)
+	}
+	if search.Sort != "" {
+		q.Set("sort", search.Sort)
+	}
+
+	return iterate[*types.Gateway](c, "/gateways/v1/search", q, pageSize, "gateways")
+}
+
+// NearestGateways returns the k gateways nearest to the location within
+// maxRadius meters, nearest first. A k or maxRadius of 0 uses the defaults of
+// the API.
+func (c *Client) NearestGateways(ctx context.Context, nearby Nearby, k int, maxRadius float64) ([]*apitypes.NearbyGateway, error) {
+	q := nearby.query()
+	if k > 0 {
+		q.Set("k", strconv.Itoa(k))
+	}
+	if maxRadius > 0 {
+		q.Set("maxRadius", strconv.FormatFloat(maxRadius, 'f', -1, 64))
+	}
+
+	var reply apitypes.NearbyGatewaysResponse
+	if err := c.get(ctx, "/gateways/v1/nearest", q, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Gateways, nil
+}
+
+// GatewaysInRadius returns the gateways within radius meters from the
+// location, nearest first.
+func (c *Client) GatewaysInRadius(ctx context.Context, nearby Nearby, radius float64) ([]*apitypes.NearbyGateway, error) {
+	q := nearby.query()
+	q.Set("radius", strconv.FormatFloat(radius, 'f', -1, 64))
+
+	var reply apitypes.NearbyGatewaysResponse
+	if err := c.get(ctx, "/gateways/v1/radius", q, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Gateways, nil
+}
+
+func (n Nearby) query() url.Values {
+	q := url.Values{}
+	if n.Cell != nil {
+		q.Set("cell", n.Cell.String())
+	} else {
+		q.Set("lat", strconv.FormatFloat(n.Lat, 'f', -1, 64))
+		q.Set("lon", strconv.FormatFloat(n.Lon, 'f', -1, 64))
+	}
+	if n.FrequencyPlan != "" {
+		q.Set("frequencyPlan", n.FrequencyPlan)
+	}
+	return q
+}
+
+// Gateway returns the gateway, or ErrNotFound when it isn't registered.
+func (c *Client) Gateway(ctx context.Context, id types.ID) (*types.Gateway, error) {
+	var gateway types.Gateway
+	if err := c.get(ctx, "/gateways/v1/"+id.String(), nil, &gateway); err != nil {
+		return nil, err
+	}
+	return &gateway, nil
+}
+
+// GatewayByEUI returns the gateway onboarded with the EUI as local id, or
+// ErrNotFound when there is none.
+func (c *Client) GatewayByEUI(ctx context.Context, eui string) (*types.Gateway, error) {
+	var gateway types.Gateway
+	if err := c.get(ctx, "/gateways/v1/local/"+url.PathEscape(eui), nil, &gateway); err != nil {
+		return nil, err
+	}
+	return &gateway, nil
+}
+
+// GatewaysBatch returns the gateways with the given ids, at most 100 ids
+// can be looked up at once.
+func (c *Client) GatewaysBatch(ctx context.Context, ids []types.ID) (*apitypes.GatewaysBatchResponse, error) {
+	var batch apitypes.GatewaysBatchResponse
+	if err := c.post(ctx, "/gateways/v1/batch", newBatchRequest(ids), &batch, true); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// GatewayEvents iterates over the confirmed events of the gateway, newest
+// first.
+func (c *Client) GatewayEvents(id types.ID, pageSize int) *Iterator[*types.GatewayEvent] {
+	return iterate[*types.GatewayEvent](c, fmt.Sprintf("/gateways/v1/%s/events", id), nil, pageSize, "events")
+}
+
+// PendingGatewayEvents returns the gateway events of the owner that aren't
+// confirmed yet.
+func (c *Client) PendingGatewayEvents(ctx context.Context, owner common.Address) (*apitypes.PendingGatewayEventsResponse, error) {
+	var pending apitypes.PendingGatewayEventsResponse
+	path := fmt.Sprintf("/gateways/v1/events/owner/%s/pending", owner.Hex())
+	if err := c.post(ctx, path, nil, &pending, true); err != nil {
+		return nil, err
+	}
+	return &pending, nil
+}
+
+// FrequencyPlans returns the supported frequency plans.
+func (c *Client) FrequencyPlans(ctx context.Context) ([]types.FrequencyPlan, error) {
+	var plans []types.FrequencyPlan
+	if err := c.get(ctx, "/gateways/v1/frequencyplan/all", nil, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// FrequencyPlansAt returns the frequency plans that are valid in the
+// resolution 10 cell.
+func (c *Client) FrequencyPlansAt(ctx context.Context, cell h3light.Cell) (*apitypes.ValidFrequencyPlansForLocation, error) {
+	var plans apitypes.ValidFrequencyPlansForLocation
+	if err := c.get(ctx, "/gateways/v1/frequencyplan/"+cell.String(), nil, &plans); err != nil {
+		return nil, err
+	}
+	return &plans, nil
+}
+
+// GatewayMapRes0 counts the gateways per resolution 3 cell grouped by
+// resolution 0 cell.
+func (c *Client) GatewayMapRes0(ctx context.Context) (*apitypes.Res0GatewayHex, error) {
+	var m apitypes.Res0GatewayHex
+	if err := c.get(ctx, "/gateways/v1/map/res0", nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GatewayMap counts the gateways per child cell 3 resolutions below the cell,
+// from resolution 7 on the gateways are included.
+func (c *Client) GatewayMap(ctx context.Context, cell h3light.Cell) (*apitypes.GatewayHex, error) {
+	var m apitypes.GatewayHex
+	if err := c.get(ctx, "/gateways/v1/map/"+cell.String(), nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateGatewayOnboard stores a signed onboard message for the gateway.
+func (c *Client) CreateGatewayOnboard(ctx context.Context, onboarder, owner common.Address, req apitypes.CreateGatewayOnboardRequest) error {
+	path := fmt.Sprintf("/gateways/v1/onboards/%s/%s", onboarder.Hex(), owner.Hex())
+	return c.post(ctx, path, req, nil, false)
+}
+
+// GatewayOnboards iterates over the onboard messages of the owner for the
+// onboarder.
+func (c *Client) GatewayOnboards(onboarder, owner common.Address, pageSize int) *Iterator[*apitypes.GatewayOnboard] {
+	path := fmt.Sprintf("/gateways/v1/onboards/%s/%s", onboarder.Hex(), owner.Hex())
+	return iterate[*apitypes.GatewayOnboard](c, path, nil, pageSize, "onboards")
+}
+
+// GatewayOnboard returns the onboard message of the gateway, or ErrNotFound
+// when there is none.
+func (c *Client) GatewayOnboard(ctx context.Context, gatewayID types.ID) (*apitypes.GatewayOnboard, error) {
+	var onboard apitypes.GatewayOnboard
+	if err := c.get(ctx, "/gateways/v1/onboards/"+gatewayID.String(), nil, &onboard); err != nil {
+		return nil, err
+	}
+	return &onboard, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Iterator iterates over the items of a paged endpoint, the next page is
+// fetched when the items of the current page are consumed.
+//
+//	it := c.OwnedGateways(owner, 0)
+//	for it.Next(ctx) {
+//		gateway := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch   func(ctx context.Context, cursor string) ([]T, string, error)
+	items   []T
+	cursor  string
+	started bool
+	value   T
+	err     error
+}
+
+// Next advances to the next item, it returns false when there are no more
+// items or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if it.err != nil || (it.started && it.cursor == "") {
+			return false
+		}
+		it.items, it.cursor, it.err = it.fetch(ctx, it.cursor)
+		it.started = true
+	}
+
+	it.value, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// iterate pages through a paged endpoint that replies the items under key
+// next to the cursor of the next page.
+func iterate[T any](c *Client, path string, query url.Values, pageSize int, key string) *Iterator[T] {
+	return &Iterator[T]{
+		fetch: func(ctx context.Context, cursor string) ([]T, string, error) {
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			if cursor != "" {
+				q.Set("cursor", cursor)
+			}
+			if pageSize > 0 {
+				q.Set("pageSize", strconv.Itoa(pageSize))
+			}
+
+			var page map[string]json.RawMessage
+			if err := c.get(ctx, path, q, &page); err != nil {
+				return nil, "", err
+			}
+
+			var (
+				items []T
+				next  string
+			)
+			if raw, ok := page[key]; ok {
+				if err := json.Unmarshal(raw, &items); err != nil {
+					return nil, "", fmt.Errorf("unable to decode %s: %w", key, err)
+				}
+			}
+			if raw, ok := page["cursor"]; ok {
+				if err := json.Unmarshal(raw, &next); err != nil {
+					return nil, "", fmt.Errorf("unable to decode cursor: %w", err)
+				}
+			}
+
+			return items, next, nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OwnedMappers iterates over the mappers of the owner. A pageSize of 0 uses
+// the default page size of the API.
+func (c *Client) OwnedMappers(owner common.Address, pageSize int) *Iterator[*types.Mapper] {
+	return iterate[*types.Mapper](c, "/mappers/v1/owned/"+owner.Hex(), nil, pageSize, "mappers")
+}
+
+// Mapper returns the mapper, or ErrNotFound when it isn't registered.
+func (c *Client) Mapper(ctx context.Context, id types.ID) (*types.Mapper, error) {
+	var mapper types.Mapper
+	if err := c.get(ctx, "/mappers/v1/"+id.String(), nil, &mapper); err != nil {
+		return nil, err
+	}
+	return &mapper, nil
+}
+
+// MappersBatch returns the mappers with the given ids, at most 100 ids can
+// be looked up at once.
+func (c *Client) MappersBatch(ctx context.Context, ids []types.ID) (*apitypes.MappersBatchResponse, error) {
+	var batch apitypes.MappersBatchResponse
+	if err := c.post(ctx, "/mappers/v1/batch", newBatchRequest(ids), &batch, true); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// MapperEvents iterates over the confirmed events of the mapper, newest
+// first.
+func (c *Client) MapperEvents(id types.ID, pageSize int) *Iterator[*types.MapperEvent] {
+	return iterate[*types.MapperEvent](c, fmt.Sprintf("/mappers/v1/%s/events", id), nil, pageSize, "events")
+}
+
+// PendingMapperEvents returns the mapper events of the owner that aren't
+// confirmed yet.
+func (c *Client) PendingMapperEvents(ctx context.Context, owner common.Address) (*apitypes.PendingMapperEventsResponse, error) {
+	var pending apitypes.PendingMapperEventsResponse
+	path := fmt.Sprintf("/mappers/v1/events/owner/%s/pending", owner.Hex())
+	if err := c.post(ctx, path, nil, &pending, true); err != nil {
+		return nil, err
+	}
+	return &pending, nil
+}
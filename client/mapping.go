@@ -0,0 +1,190 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Mapping returns the mapping record, or ErrNotFound when it doesn't exist.
+func (c *Client) Mapping(ctx context.Context, id types.ID) (*types.MappingRecord, error) {
+	var record *types.MappingRecord
+	if err := c.get(ctx, "/mapping/v1/"+id.String(), nil, &record); err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrNotFound
+	}
+	return record, nil
+}
+
+// RecentMappings iterates over the mapping records of the mapper of the last
+// day. Records of the last hour are only included when code is a mapping
+// code of the owner of the mapper.
+func (c *Client) RecentMappings(mapperID types.ID, code string, pageSize int) *Iterator[*types.MappingRecord] {
+	query := url.Values{}
+	if code != "" {
+		query.Set("code", code)
+	}
+	return iterate[*types.MappingRecord](c, fmt.Sprintf("/mapping/v1/mapper/%s/recent", mapperID), query, pageSize, "mappings")
+}
+
+// CreateMappingChallenge creates a challenge for the owner to sign.
+func (c *Client) CreateMappingChallenge(ctx context.Context, owner common.Address) (*apitypes.ChallengeResponse, error) {
+	var (
+		req       = apitypes.ChallengeRequest{Owner: owner}
+		challenge apitypes.ChallengeResponse
+	)
+	if err := c.post(ctx, "/mapping/v1/auth/challenge", &req, &challenge, false); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// SubmitMappingSignature exchanges the signed challenge for a mapping code.
+func (c *Client) SubmitMappingSignature(ctx context.Context, owner common.Address, challenge, signature string) (*apitypes.SignatureResponse, error) {
+	var (
+		req  = apitypes.SignatureRequest{Owner: owner, Challenge: challenge, Signature: signature}
+		code apitypes.SignatureResponse
+	)
+	if err := c.post(ctx, "/mapping/v1/auth/signature", &req, &code, false); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// CheckMappingCode reports if the code belongs to the owner of the mapper.
+func (c *Client) CheckMappingCode(ctx context.Context, mapperID types.ID, code string) (bool, error) {
+	req := apitypes.CodeCheckRequest{MapperID: mapperID, Code: code}
+
+	err := c.post(ctx, "/mapping/v1/auth/check", &req, nil, true)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CoverageDates returns the first and last date coverage is known for.
+func (c *Client) CoverageDates(ctx context.Context) (time.Time, time.Time, error) {
+	var dates apitypes.MinMaxCoverageDates
+	if err := c.get(ctx, "/coverage/v1/minmaxdate", nil, &dates); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	min, err := time.Parse(time.DateOnly, dates.Min)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid min coverage date: %w", err)
+	}
+	max, err := time.Parse(time.DateOnly, dates.Max)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid max coverage date: %w", err)
+	}
+
+	return min, max, nil
+}
+
+// AssumedCoverageMapRes0 returns the resolution 6 cells with assumed coverage
+// at the date.
+func (c *Client) AssumedCoverageMapRes0(ctx context.Context, date time.Time) ([]h3light.Cell, error) {
+	return c.assumedCoverage(ctx, fmt.Sprintf("/coverage/v1/map/%s/res0/assumed", date.Format(time.DateOnly)))
+}
+
+// AssumedCoverageMap returns the cells with assumed coverage in the cell at
+// the date.
+func (c *Client) AssumedCoverageMap(ctx context.Context, date time.Time, cell h3light.Cell) ([]h3light.Cell, error) {
+	return c.assumedCoverage(ctx, fmt.Sprintf("/coverage/v1/map/%s/%s/assumed", date.Format(time.DateOnly), cell))
+}
+
+// CoverageMap returns the coverage in the cell at the date.
+func (c *Client) CoverageMap(ctx context.Context, date time.Time, cell h3light.Cell) ([]*types.CoverageHistory, error) {
+	return c.coverage(ctx, fmt.Sprintf("/coverage/v1/map/%s/%s/coverage", date.Format(time.DateOnly), cell))
+}
+
+// GatewayAssumedCoverage returns the cells the gateway is assumed to cover
+// at the date.
+func (c *Client) GatewayAssumedCoverage(ctx context.Context, gatewayID types.ID, date time.Time) ([]h3light.Cell, error) {
+	return c.assumedCoverage(ctx, fmt.Sprintf("/coverage/v1/gateway/%s/%s/assumed", gatewayID, date.Format(time.DateOnly)))
+}
+
+// GatewayCoverage returns the coverage of the gateway at the date.
+func (c *Client) GatewayCoverage(ctx context.Context, gatewayID types.ID, date time.Time) ([]*types.CoverageHistory, error) {
+	return c.coverage(ctx, fmt.Sprintf("/coverage/v1/gateway/%s/%s/coverage", gatewayID, date.Format(time.DateOnly)))
+}
+
+// AssumedUnverifiedCoverageMapRes0 returns the resolution 6 cells with
+// assumed unverified coverage.
+func (c *Client) AssumedUnverifiedCoverageMapRes0(ctx context.Context) ([]h3light.Cell, error) {
+	return c.assumedCoverage(ctx, "/unverifiedmapping/v1/map/res0/assumed")
+}
+
+// AssumedUnverifiedCoverageMap returns the cells with assumed unverified
+// coverage in the cell.
+func (c *Client) AssumedUnverifiedCoverageMap(ctx context.Context, cell h3light.Cell) ([]h3light.Cell, error) {
+	return c.assumedCoverage(ctx, fmt.Sprintf("/unverifiedmapping/v1/map/%s/assumed", cell))
+}
+
+// UnverifiedCoverageMap returns the unverified mappings in the cell.
+func (c *Client) UnverifiedCoverageMap(ctx context.Context, cell h3light.Cell) ([]*types.UnverifiedMappingRecord, error) {
+	var cov apitypes.UnverifiedCoverageHexContainer
+	if err := c.get(ctx, fmt.Sprintf("/unverifiedmapping/v1/map/%s/coverage", cell), nil, &cov); err != nil {
+		return nil, err
+	}
+	return cov.Hexes, nil
+}
+
+// UnverifiedMapping returns the unverified mapping record, or ErrNotFound
+// when it doesn't exist.
+func (c *Client) UnverifiedMapping(ctx context.Context, id types.ID) (*types.UnverifiedMappingRecord, error) {
+	var record *types.UnverifiedMappingRecord
+	if err := c.get(ctx, "/unverifiedmapping/v1/"+id.String(), nil, &record); err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrNotFound
+	}
+	return record, nil
+}
+
+func (c *Client) assumedCoverage(ctx context.Context, path string) ([]h3light.Cell, error) {
+	var cov apitypes.AssumedCoverageHexContainer
+	if err := c.get(ctx, path, nil, &cov); err != nil {
+		return nil, err
+	}
+	return cov.Hexes, nil
+}
+
+func (c *Client) coverage(ctx context.Context, path string) ([]*types.CoverageHistory, error) {
+	var cov apitypes.CoverageHexContainer
+	if err := c.get(ctx, path, nil, &cov); err != nil {
+		return nil, err
+	}
+	return cov.Hexes, nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OwnerPortfolio returns the gateways, mappers, pending events and rewards of
+// the owner.
+func (c *Client) OwnerPortfolio(ctx context.Context, owner common.Address) (*apitypes.OwnerResponse, error) {
+	var portfolio apitypes.OwnerResponse
+	if err := c.get(ctx, fmt.Sprintf("/owners/v1/%s", owner.Hex()), nil, &portfolio); err != nil {
+		return nil, err
+	}
+	return &portfolio, nil
+}
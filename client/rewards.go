@@ -0,0 +1,95 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountRewardsHistory returns the daily rewards of the account between
+// start and end, newest first. Zero times use the defaults of the API, the
+// last 30 days.
+func (c *Client) AccountRewardsHistory(ctx context.Context, account common.Address, start, end time.Time) ([]*types.AccountRewardHistory, error) {
+	var history apitypes.AccountRewardsHistoryResponse
+	path := fmt.Sprintf("/rewards/v1/accounts/%s/history", account.Hex())
+	if err := c.get(ctx, path, rewardsPeriod(start, end), &history); err != nil {
+		return nil, err
+	}
+	return history.Rewards, nil
+}
+
+// GatewayRewardsHistory returns the daily rewards of the gateway between
+// start and end, newest first. Zero times use the defaults of the API, the
+// last 30 days.
+func (c *Client) GatewayRewardsHistory(ctx context.Context, gatewayID types.ID, start, end time.Time) ([]*types.GatewayRewardHistory, error) {
+	var history apitypes.GatewayRewardsHistoryResponse
+	path := fmt.Sprintf("/rewards/v1/gateways/%s/history", gatewayID)
+	if err := c.get(ctx, path, rewardsPeriod(start, end), &history); err != nil {
+		return nil, err
+	}
+	return history.Rewards, nil
+}
+
+// MapperRewardsHistory returns the daily rewards of the mapper between start
+// and end, newest first. Zero times use the defaults of the API, the last 30
+// days.
+func (c *Client) MapperRewardsHistory(ctx context.Context, mapperID types.ID, start, end time.Time) ([]*types.MapperRewardHistory, error) {
+	var history apitypes.MapperRewardsHistoryResponse
+	path := fmt.Sprintf("/rewards/v1/mappers/%s/history", mapperID)
+	if err := c.get(ctx, path, rewardsPeriod(start, end), &history); err != nil {
+		return nil, err
+	}
+	return history.Rewards, nil
+}
+
+// LatestAccountRewards returns the latest rewards of the account, or
+// ErrNotFound when it has none.
+func (c *Client) LatestAccountRewards(ctx context.Context, account common.Address) (*types.AccountRewardHistory, error) {
+	var rewards types.AccountRewardHistory
+	if err := c.get(ctx, fmt.Sprintf("/rewards/v1/accounts/%s/latest", account.Hex()), nil, &rewards); err != nil {
+		return nil, err
+	}
+	return &rewards, nil
+}
+
+// LatestCheque returns the latest signed rewards cheque of the account, or
+// ErrNotFound when it has none.
+func (c *Client) LatestCheque(ctx context.Context, account common.Address) (*apitypes.RewardCheque, error) {
+	var cheque apitypes.RewardCheque
+	if err := c.get(ctx, fmt.Sprintf("/rewards/v1/accounts/%s/cheque", account.Hex()), nil, &cheque); err != nil {
+		return nil, err
+	}
+	return &cheque, nil
+}
+
+func rewardsPeriod(start, end time.Time) url.Values {
+	query := url.Values{}
+	if !start.IsZero() {
+		query.Set("start", start.Format(time.DateOnly))
+	}
+	if !end.IsZero() {
+		query.Set("end", end.Format(time.DateOnly))
+	}
+	return query
+}
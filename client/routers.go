@@ -0,0 +1,43 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// RouterSnapshot returns all registered routers.
+func (c *Client) RouterSnapshot(ctx context.Context) (*apitypes.RouterSnapshotResponse, error) {
+	var snapshot apitypes.RouterSnapshotResponse
+	if err := c.get(ctx, "/routers/v1/snapshot", nil, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// RoutersBatch returns the routers with the given ids, at most 100 ids can
+// be looked up at once.
+func (c *Client) RoutersBatch(ctx context.Context, ids []types.ID) (*apitypes.RoutersBatchResponse, error) {
+	var batch apitypes.RoutersBatchResponse
+	if err := c.post(ctx, "/routers/v1/batch", newBatchRequest(ids), &batch, true); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
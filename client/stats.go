@@ -0,0 +1,48 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"time"
+)
+
+// NetworkStats returns the network statistics between start and end per
+// granularity, which is day, week or month, oldest first. Zero times and an
+// empty granularity use the defaults of the API.
+func (c *Client) NetworkStats(ctx context.Context, start, end time.Time, granularity string) ([]*apitypes.NetworkStats, error) {
+	var series apitypes.NetworkStatsResponse
+	query := rewardsPeriod(start, end)
+	if granularity != "" {
+		query.Set("granularity", granularity)
+	}
+	if err := c.get(ctx, "/stats/v1/network", query, &series); err != nil {
+		return nil, err
+	}
+	return series.Stats, nil
+}
+
+// LatestNetworkStats returns the statistics of the last day statistics are
+// computed for.
+func (c *Client) LatestNetworkStats(ctx context.Context) (*apitypes.NetworkStats, error) {
+	var stats apitypes.NetworkStats
+	if err := c.get(ctx, "/stats/v1/network/latest", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
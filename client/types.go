@@ -0,0 +1,56 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"time"
+
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GatewaySearch selects gateways, every criterion that is set must match.
+// Ranges include their bounds except for OnboardedTo.
+type GatewaySearch struct {
+	FrequencyPlan  string
+	MinAntennaGain *float32
+	MaxAntennaGain *float32
+	MinAltitude    *uint
+	MaxAltitude    *uint
+	Version        *uint8
+	Owners         []common.Address
+	// Region is a cell of any resolution the gateways are located in.
+	Region        *h3light.Cell
+	OnboardedFrom *time.Time
+	OnboardedTo   *time.Time
+	// EUI selects the gateway onboarded with the EUI as local id.
+	EUI string
+	// Sort is id, antennaGain, altitude or version, prefixed with - to sort
+	// descending. It defaults to id.
+	Sort string
+}
+
+// Nearby selects the gateways near a location, given by Lat and Lon or by
+// the centre of Cell when it is set.
+type Nearby struct {
+	Lat  float64
+	Lon  float64
+	Cell *h3light.Cell
+	// FrequencyPlan only selects the gateways with this frequency plan when
+	// it is set.
+	FrequencyPlan string
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/option"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/grpc"
+)
+
+// NewClient creates a Cloud Datastore client for the project in the config.
+// The operations of the client are traced and their latency and errors per
+// entity are recorded.
+func NewClient(ctx context.Context) (*datastore.Client, error) {
+	return datastore.NewClient(ctx, viper.GetString(config.CONFIG_STORE_CLOUDDATASTORE_PROJECT),
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(interceptor)))
+}
+
+func interceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var (
+		start         = time.Now()
+		operation     = path.Base(method)
+		entity        = requestEntity(req)
+		spanCtx, span = tracing.Start(ctx, "datastore."+operation,
+			attribute.String("db.system", "clouddatastore"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.entity", entity))
+	)
+
+	err := invoker(spanCtx, method, req, reply, cc, opts...)
+
+	metrics.ObserveStore(ctx, entity, operation, start, err)
+	tracing.End(span, err)
+
+	return err
+}
+
+// requestEntity returns the kind of the entities the request operates on,
+// requests for multiple kinds are labelled with the first kind.
+func requestEntity(req interface{}) string {
+	switch req := req.(type) {
+	case *pb.LookupRequest:
+		return keysKind(req.GetKeys())
+	case *pb.RunQueryRequest:
+		return queryKind(req.GetQuery())
+	case *pb.RunAggregationQueryRequest:
+		return queryKind(req.GetAggregationQuery().GetNestedQuery())
+	case *pb.CommitRequest:
+		for _, m := range req.GetMutations() {
+			for _, key := range []*pb.Key{m.GetInsert().GetKey(), m.GetUpdate().GetKey(), m.GetUpsert().GetKey(), m.GetDelete()} {
+				if kind := keyKind(key); kind != "" {
+					return kind
+				}
+			}
+		}
+	case *pb.AllocateIdsRequest:
+		return keysKind(req.GetKeys())
+	case *pb.ReserveIdsRequest:
+		return keysKind(req.GetKeys())
+	}
+	return ""
+}
+
+func queryKind(query *pb.Query) string {
+	if kinds := query.GetKind(); len(kinds) > 0 {
+		return kinds[0].GetName()
+	}
+	return ""
+}
+
+func keysKind(keys []*pb.Key) string {
+	if len(keys) > 0 {
+		return keyKind(keys[0])
+	}
+	return ""
+}
+
+func keyKind(key *pb.Key) string {
+	if elems := key.GetPath(); len(elems) > 0 {
+		return elems[len(elems)-1].GetKind()
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/apikey"
+	"github.com/ThingsIXFoundation/data-aggregator/apikey/store"
+	"github.com/spf13/cobra"
+)
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage the API keys that get their own rate limits",
+}
+
+var apiKeyIssueCmd = &cobra.Command{
+	Use:   "issue <name>",
+	Short: "Issue an API key",
+	Long:  "Issue an API key, the name identifies the client the key is issued to. The key is only printed once.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  issueAPIKey,
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the issued API keys",
+	Args:  cobra.NoArgs,
+	RunE:  listAPIKeys,
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Long:  "Revoke an API key, the API can take up to the API key cache TTL to reject the key.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  revokeAPIKey,
+}
+
+func init() {
+	apiKeyCmd.AddCommand(apiKeyIssueCmd, apiKeyListCmd, apiKeyRevokeCmd)
+}
+
+func issueAPIKey(cmd *cobra.Command, args []string) error {
+	store, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+
+	key, issued, err := apikey.Issue(cmd.Context(), store, args[0])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Issued API key %s for %s:\n\n  %s\n\n", issued.ID, issued.Name, key)
+	fmt.Fprintln(out, "Store the key safely, it can't be shown again.")
+	return nil
+}
+
+func listAPIKeys(cmd *cobra.Command, args []string) error {
+	store, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+
+	keys, err := store.GetAPIKeys(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tCREATED\tREVOKED")
+	for _, key := range keys {
+		revoked := "-"
+		if key.Revoked() {
+			revoked = key.RevokedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", key.ID, key.Name, key.CreatedAt.Format(time.RFC3339), revoked)
+	}
+	return tw.Flush()
+}
+
+func revokeAPIKey(cmd *cobra.Command, args []string) error {
+	store, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+
+	key, err := apikey.Revoke(cmd.Context(), store, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Revoked API key %s for %s\n", key.ID, key.Name)
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/spf13/cobra"
+)
+
+// validate validates the configuration of the given roles and returns an
+// error listing all problems that were found.
+func validate(roles config.Roles) error {
+	problems := config.Validate(roles)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(problems))
+	for i, p := range problems {
+		msgs[i] = p.String()
+	}
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(msgs, "\n  - "))
+}
+
+func checkAll(cmd *cobra.Command, args []string) error {
+	return validate(config.AllRoles)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Print the resolved configuration and the problems found in it",
+	Long:  "Print the resolved configuration, with secrets masked, and validate it for the given roles.",
+	RunE:  configCheck,
+}
+
+var configCheckRoles []string
+
+func init() {
+	allFlags(configCheckCmd.Flags())
+	configCheckCmd.Flags().StringSliceVar(&configCheckRoles, "roles", []string{"all"}, "the roles to validate the configuration for: all, api, ingest, aggregate or cache")
+
+	configCmd.AddCommand(configCheckCmd)
+}
+
+func configCheck(cmd *cobra.Command, args []string) error {
+	var roles config.Roles
+	for _, role := range configCheckRoles {
+		switch role {
+		case "all":
+			roles = config.AllRoles
+		case "api":
+			roles.API = true
+		case "ingest":
+			roles.Ingestor = true
+		case "aggregate":
+			roles.Aggregator = true
+		case "cache":
+			roles.Cacher = true
+		default:
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "Configuration:")
+	for _, s := range config.Settings() {
+		// command flags are bound to viper too but aren't configuration
+		if s.Key == "roles" || s.Key == "help" {
+			continue
+		}
+		fmt.Fprintf(out, "  %s = %s\n", s.Key, s.Value)
+	}
+	fmt.Fprintln(out)
+
+	problems := config.Validate(roles)
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "No problems found")
+		return nil
+	}
+
+	fmt.Fprintln(out, "Problems:")
+	for _, p := range problems {
+		fmt.Fprintf(out, "  - %s\n", p)
+	}
+
+	return fmt.Errorf("found %d problem(s) in the configuration", len(problems))
+}
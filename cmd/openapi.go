@@ -0,0 +1,48 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/openapi"
+	"github.com/spf13/cobra"
+)
+
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI document of the HTTP API",
+	Long:  "Print the OpenAPI document of the HTTP API, this is the same document as served at /openapi.json.",
+	Args:  cobra.NoArgs,
+	RunE:  printOpenAPI,
+}
+
+func printOpenAPI(cmd *cobra.Command, args []string) error {
+	doc, err := openapi.Document()
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/spf13/cobra"
+)
+
+var serveAPICmd = &cobra.Command{
+	Use:   "serve-api",
+	Short: "Serve the HTTP API",
+	Long:  "Serve the HTTP API. API replicas don't ingest or aggregate and can be scaled horizontally.",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validate(config.Roles{API: true, RequireEnabled: true})
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		run(supervisor.RoleAPI)
+	},
+}
+
+var ingestCmd = &cobra.Command{
+	Use:   "ingest",
+	Short: "Ingest registry events from the chain and verified mappings",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validate(config.Roles{Ingestor: true, RequireEnabled: true})
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		run(supervisor.RoleIngestor)
+	},
+}
+
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate",
+	Short: "Aggregate ingested registry events into the current state",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validate(config.Roles{Aggregator: true, RequireEnabled: true})
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		run(supervisor.RoleAggregator)
+	},
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Cache the registry state in Redis",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validate(config.Roles{Cacher: true, RequireEnabled: true})
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		run(supervisor.RoleCacher)
+	},
+}
+
+var allCmd = &cobra.Command{
+	Use:     "all",
+	Short:   "Run all roles in a single process",
+	PreRunE: checkAll,
+	Run:     runAll,
+}
+
+func init() {
+	config.APIFlags(serveAPICmd.Flags())
+	config.IngestorFlags(ingestCmd.Flags())
+	config.AggregatorFlags(aggregateCmd.Flags())
+	config.CacherFlags(cacheCmd.Flags())
+	allFlags(allCmd.Flags())
+}
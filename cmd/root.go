@@ -17,29 +17,27 @@
 package cmd
 
 import (
-	"context"
-	"errors"
+	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 
-	"github.com/ThingsIXFoundation/data-aggregator/api"
 	"github.com/ThingsIXFoundation/data-aggregator/config"
-	"github.com/ThingsIXFoundation/data-aggregator/gateway"
-	"github.com/ThingsIXFoundation/data-aggregator/mapper"
-	"github.com/ThingsIXFoundation/data-aggregator/mapping"
-	"github.com/ThingsIXFoundation/data-aggregator/router"
-	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "data-aggregator",
-	Short: "Collect, aggregate and serve ThingsIX information",
-	Run:   Run,
+	Use:               "data-aggregator",
+	Short:             "Collect, aggregate and serve ThingsIX information",
+	Long:              "Collect, aggregate and serve ThingsIX information.\n\nWithout a subcommand all roles are started, this is the same as the all subcommand.",
+	PersistentPreRunE: initConfig,
+	PreRunE:           checkAll,
+	Run:               runAll,
+	// configuration errors are reported by the checks, don't bury them under the usage
+	SilenceUsage: true,
 }
 
 func Execute() {
@@ -50,21 +48,29 @@ func Execute() {
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
-
 	config.PersistentFlags(rootCmd.PersistentFlags())
+	allFlags(rootCmd.Flags())
 
-	// bind viper to cobra flags
-	if err := viper.BindPFlags(rootCmd.Flags()); err != nil {
-		logrus.WithError(err).Fatal("could not bind command line flags")
-	}
-	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
-		logrus.WithError(err).Fatal("could not bind command line flags")
-	}
+	rootCmd.AddCommand(serveAPICmd, ingestCmd, aggregateCmd, cacheCmd, allCmd, configCmd, openAPICmd, apiKeyCmd)
 }
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig() {
+// allFlags registers the flags of all roles.
+func allFlags(flags *pflag.FlagSet) {
+	config.APIFlags(flags)
+	config.IngestorFlags(flags)
+	config.AggregatorFlags(flags)
+	config.CacherFlags(flags)
+}
+
+// initConfig binds the flags of the command that is executed and reads in
+// config file and ENV variables if set.
+func initConfig(cmd *cobra.Command, args []string) error {
+	// bind viper to the cobra flags of the executed command only, the
+	// subcommands each have their own role-specific flag set
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("could not bind command line flags: %w", err)
+	}
+
 	viper.SetConfigType("yaml")
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -82,81 +88,16 @@ func initConfig() {
 	} else if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 		logrus.WithError(err).Error("error while reading config-file")
 	}
-}
 
-func Run(cmd *cobra.Command, args []string) {
 	level, err := logrus.ParseLevel(viper.GetString(config.CONFIG_LOG_LEVEL))
 	if err != nil {
-		logrus.Fatalf("invalid level: %s", viper.GetString(config.CONFIG_LOG_LEVEL))
+		return fmt.Errorf("invalid level: %s", viper.GetString(config.CONFIG_LOG_LEVEL))
 	}
 	logrus.SetLevel(level)
 
-	var (
-		ctx, shutdown = context.WithCancel(context.Background())
-		sign          = make(chan os.Signal, 1)
-	)
-
-	gatewayErr := make(chan error)
-	go func() {
-		defer close(gatewayErr)
-		if err := gateway.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("gateway functions failed")
-			gatewayErr <- err
-		}
-	}()
-
-	routerErr := make(chan error)
-	go func() {
-		defer close(routerErr)
-		if err := router.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("router functions failed")
-			routerErr <- err
-		}
-	}()
-
-	mapperErr := make(chan error)
-	go func() {
-		defer close(mapperErr)
-		if err := mapper.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("mapper functions failed")
-			mapperErr <- err
-		}
-	}()
-
-	mappingErr := make(chan error)
-	go func() {
-		defer close(mappingErr)
-		if err := mapping.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("verified mapping functions failed")
-			mapperErr <- err
-		}
-	}()
-
-	apiErr := make(chan error)
-	go func() {
-		defer close(apiErr)
-		if err := api.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("api functions failed")
-			apiErr <- err
-		}
-	}()
-
-	signal.Notify(sign, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-sign:
-		shutdown()
-	case <-gatewayErr:
-		shutdown()
-	case <-routerErr:
-		shutdown()
-	case <-mapperErr:
-		shutdown()
-	case <-mappingErr:
-		shutdown()
-	case <-apiErr:
-		shutdown()
-	}
-
-	utils.WaitForChannelsToClose(gatewayErr, routerErr, mapperErr, apiErr)
+	return nil
+}
 
+func runAll(cmd *cobra.Command, args []string) {
+	run(supervisor.RoleAPI, supervisor.RoleIngestor, supervisor.RoleAggregator, supervisor.RoleCacher)
 }
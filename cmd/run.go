@@ -0,0 +1,130 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	"github.com/ThingsIXFoundation/data-aggregator/mapper"
+	"github.com/ThingsIXFoundation/data-aggregator/mapping"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/router"
+	"github.com/ThingsIXFoundation/data-aggregator/stats"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// components returns the enabled components that have one of the given roles,
+// the components that run under a lease share the elector and the API reports
+// the state of the components run by the supervisor.
+func components(elector *leader.Elector, sup *supervisor.Supervisor, roles ...supervisor.Role) []supervisor.Component {
+	var all []supervisor.Component
+	all = append(all, gateway.Components(elector)...)
+	all = append(all, router.Components(elector)...)
+	all = append(all, mapper.Components(elector)...)
+	all = append(all, mapping.Components()...)
+	all = append(all, api.Components(sup)...)
+	all = append(all, webhook.Components(elector)...)
+	all = append(all, stats.Components(elector)...)
+
+	return utils.Filter(all, func(c supervisor.Component) bool {
+		return utils.In(roles, c.Role)
+	})
+}
+
+// run supervises the enabled components with one of the given roles until the
+// process is signalled to stop.
+func run(roles ...supervisor.Role) {
+	var (
+		ctx, shutdown = context.WithCancel(context.Background())
+		sign          = make(chan os.Signal, 1)
+	)
+
+	elector, err := leader.NewElector()
+	if err != nil {
+		logrus.WithError(err).Fatal("unable to create leader elector")
+	}
+
+	var (
+		sup        = supervisor.NewSupervisor()
+		components = components(elector, sup, roles...)
+	)
+
+	if len(components) == 0 {
+		logrus.Fatalf("no components enabled for roles %v", roles)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		logrus.WithError(err).Fatal("unable to setup tracing")
+	}
+
+	if viper.GetString(config.CONFIG_METRICS_LISTEN_ADDRESS) != "" {
+		components = append(components, supervisor.Component{
+			Name:   "metrics",
+			Run:    metrics.Run,
+			Policy: supervisor.DefaultRestartPolicy(),
+		})
+	}
+
+	for _, c := range components {
+		logrus.WithFields(logrus.Fields{
+			"registry": c.Registry,
+			"role":     c.Role,
+		}).Infof("starting %s", c.Name)
+	}
+
+	sup.Add(components...)
+
+	supervisorErr := make(chan error)
+	go func() {
+		defer close(supervisorErr)
+		if err := sup.Run(ctx); err != nil {
+			supervisorErr <- err
+		}
+	}()
+
+	signal.Notify(sign, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-sign:
+		shutdown()
+	case err := <-supervisorErr:
+		shutdown()
+		logrus.WithError(err).Fatal("stopping data-aggregator")
+	}
+
+	utils.WaitForChannelsToClose(supervisorErr)
+
+	// flush the spans that weren't exported yet
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		logrus.WithError(err).Warn("unable to flush traces")
+	}
+}
@@ -33,6 +33,33 @@ const (
 
 	CONFIG_API_HTTP_LISTEN_ADDRESS         = "api.http-listen-address"
 	CONFIG_API_HTTP_LISTEN_ADDRESS_DEFAULT = "0.0.0.0:8081"
+	CONFIG_API_OPENAPI_VALIDATE_REQUESTS   = "api.openapi.validate-requests"
+	CONFIG_API_OPENAPI_VALIDATE_RESPONSES  = "api.openapi.validate-responses"
+	CONFIG_API_RATELIMIT_ENABLED           = "api.ratelimit.enabled"
+	CONFIG_API_RATELIMIT_STORE             = "api.ratelimit.store"
+	CONFIG_API_RATELIMIT_STORE_DEFAULT     = "memory"
+	CONFIG_API_RATELIMIT_IP_RATE           = "api.ratelimit.ip-rate"
+	CONFIG_API_RATELIMIT_IP_BURST          = "api.ratelimit.ip-burst"
+	CONFIG_API_RATELIMIT_KEY_RATE          = "api.ratelimit.key-rate"
+	CONFIG_API_RATELIMIT_KEY_BURST         = "api.ratelimit.key-burst"
+	CONFIG_API_RATELIMIT_WRITE_IP_RATE     = "api.ratelimit.write-ip-rate"
+	CONFIG_API_RATELIMIT_WRITE_IP_BURST    = "api.ratelimit.write-ip-burst"
+	CONFIG_API_RATELIMIT_WRITE_KEY_RATE    = "api.ratelimit.write-key-rate"
+	CONFIG_API_RATELIMIT_WRITE_KEY_BURST   = "api.ratelimit.write-key-burst"
+	CONFIG_API_RATELIMIT_GROUPS            = "api.ratelimit.groups"
+	CONFIG_API_RATELIMIT_TRUSTED_PROXIES   = "api.ratelimit.trusted-proxies"
+	CONFIG_API_KEY_STORE                   = "api.apikey.store.type"
+	CONFIG_API_KEY_STORE_DEFAULT           = "clouddatastore"
+	CONFIG_API_KEY_CACHE_TTL               = "api.apikey.cache-ttl"
+
+	CONFIG_METRICS_LISTEN_ADDRESS         = "metrics.listen-address"
+	CONFIG_METRICS_LISTEN_ADDRESS_DEFAULT = "0.0.0.0:9090"
+
+	CONFIG_TRACING_EXPORTER         = "tracing.exporter"
+	CONFIG_TRACING_EXPORTER_DEFAULT = "none"
+	CONFIG_TRACING_OTLP_ENDPOINT    = "tracing.otlp.endpoint"
+	CONFIG_TRACING_OTLP_INSECURE    = "tracing.otlp.insecure"
+	CONFIG_TRACING_SAMPLE_RATIO     = "tracing.sample-ratio"
 
 	CONFIG_PUBSUB_PROJECT               = "pubsub.project"
 	CONFIG_STORE_CLOUDDATASTORE_PROJECT = "store.clouddatastore.project"
@@ -40,6 +67,21 @@ const (
 	CONFIG_BLOCK_CACHE_DURATION         = "block-cache-duration"
 	CONFIG_BLOCK_CACHE_DURATION_DEFAULT = 1 * time.Minute
 
+	CONFIG_LEADER_ELECTION_ENABLED        = "leader-election.enabled"
+	CONFIG_LEADER_ELECTION_IDENTITY       = "leader-election.identity"
+	CONFIG_LEADER_ELECTION_LEASE_DURATION = "leader-election.lease-duration"
+	CONFIG_LEADER_ELECTION_RETRY_INTERVAL = "leader-election.retry-interval"
+	CONFIG_LEADER_ELECTION_STORE          = "leader-election.store.type"
+	CONFIG_LEADER_ELECTION_STORE_DEFAULT  = "clouddatastore"
+
+	CONFIG_SUPERVISOR_INITIAL_BACKOFF = "supervisor.initial-backoff"
+	CONFIG_SUPERVISOR_MAX_BACKOFF     = "supervisor.max-backoff"
+	CONFIG_SUPERVISOR_MAX_RESTARTS    = "supervisor.max-restarts"
+	CONFIG_SUPERVISOR_STABLE_AFTER    = "supervisor.stable-after"
+
+	CONFIG_STATUS_STORE         = "status.store.type"
+	CONFIG_STATUS_STORE_DEFAULT = "clouddatastore"
+
 	CONFIG_GATEWAY_CONTRACT                        = "gateway.contract"
 	CONFIG_GATEWAY_API_ENABLED                     = "gateway.api.enabled"
 	CONFIG_GATEWAY_CACHER_ENABLED                  = "gateway.cacher.enabled"
@@ -95,72 +137,227 @@ const (
 	CONFIG_REWARDS_API_ENABLED   = "rewards.api.enabled"
 	CONFIG_REWARDS_STORE         = "rewards.store.type"
 	CONFIG_REWARDS_STORE_DEFAULT = "clouddatastore"
+
+	CONFIG_OWNER_API_ENABLED       = "owner.api.enabled"
+	CONFIG_OWNER_API_FETCH_TIMEOUT = "owner.api.fetch-timeout"
+
+	CONFIG_ACTIVITY_API_ENABLED = "activity.api.enabled"
+
+	CONFIG_STATS_API_ENABLED              = "stats.api.enabled"
+	CONFIG_STATS_AGGREGATOR_ENABLED       = "stats.aggregator.enabled"
+	CONFIG_STATS_AGGREGATOR_POLL_INTERVAL = "stats.aggregator.poll-interval"
+	CONFIG_STATS_STORE                    = "stats.store.type"
+	CONFIG_STATS_STORE_DEFAULT            = "clouddatastore"
+
+	CONFIG_TILES_API_ENABLED    = "tiles.api.enabled"
+	CONFIG_TILES_API_CACHE_SIZE = "tiles.api.cache-size"
+
+	CONFIG_GRAPHQL_API_ENABLED          = "graphql.api.enabled"
+	CONFIG_GRAPHQL_API_MAX_DEPTH        = "graphql.api.max-depth"
+	CONFIG_GRAPHQL_API_MAX_COST         = "graphql.api.max-cost"
+	CONFIG_GRAPHQL_API_MAX_QUERY_LENGTH = "graphql.api.max-query-length"
+
+	CONFIG_GRPC_API_ENABLED                     = "grpc.api.enabled"
+	CONFIG_GRPC_API_LISTEN_ADDRESS              = "grpc.api.listen-address"
+	CONFIG_GRPC_API_LISTEN_ADDRESS_DEFAULT      = "0.0.0.0:9091"
+	CONFIG_GRPC_API_WATCH_POLL_INTERVAL         = "grpc.api.watch-poll-interval"
+	CONFIG_GRPC_API_WATCH_POLL_INTERVAL_DEFAULT = 10 * time.Second
+	CONFIG_GRPC_API_MAX_BACKFILL_BLOCKS         = "grpc.api.max-backfill-blocks"
+
+	CONFIG_EVENTS_REDIS_HOST              = "events.redis-host"
+	CONFIG_EVENTS_REDIS_CHANNEL           = "events.redis-channel"
+	CONFIG_EVENTS_REDIS_CHANNEL_DEFAULT   = "registry-events"
+	CONFIG_EVENTS_API_ENABLED             = "events.api.enabled"
+	CONFIG_EVENTS_API_MAX_BACKFILL_BLOCKS = "events.api.max-backfill-blocks"
+
+	CONFIG_WEBHOOK_ENABLED                    = "webhook.enabled"
+	CONFIG_WEBHOOK_STORE                      = "webhook.store.type"
+	CONFIG_WEBHOOK_STORE_DEFAULT              = "clouddatastore"
+	CONFIG_WEBHOOK_DISPATCHER_POLL_INTERVAL   = "webhook.dispatcher.poll-interval"
+	CONFIG_WEBHOOK_DISPATCHER_TIMEOUT         = "webhook.dispatcher.timeout"
+	CONFIG_WEBHOOK_DISPATCHER_MAX_ATTEMPTS    = "webhook.dispatcher.max-attempts"
+	CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF = "webhook.dispatcher.initial-backoff"
+	CONFIG_WEBHOOK_DISPATCHER_MAX_BACKOFF     = "webhook.dispatcher.max-backoff"
 )
 
+// PersistentFlags registers the flags that are shared by all roles.
 func PersistentFlags(flags *pflag.FlagSet) {
 	flags.String(CONFIG_FILE, "", "config-file to read in")
 	flags.String(CONFIG_LOG_LEVEL, CONFIG_LOG_LEVEL_DEFAULT, "the log-level to use")
-	flags.String(CONFIG_CHAINSYNC_RPC_ENDPOINT, "", "the RPC endpoint to use to get chain data from")
+	flags.String(CONFIG_METRICS_LISTEN_ADDRESS, CONFIG_METRICS_LISTEN_ADDRESS_DEFAULT, "the listen address to serve Prometheus metrics on, metrics aren't served when empty")
+	flags.String(CONFIG_TRACING_EXPORTER, CONFIG_TRACING_EXPORTER_DEFAULT, "the exporter for traces, one of none, otlp or stdout")
+	flags.String(CONFIG_TRACING_OTLP_ENDPOINT, "localhost:4317", "the OTLP gRPC endpoint to export traces to")
+	flags.Bool(CONFIG_TRACING_OTLP_INSECURE, false, "export traces to the OTLP endpoint without TLS")
+	flags.Float64(CONFIG_TRACING_SAMPLE_RATIO, 1, "the ratio of traces to sample, traces continued from a caller follow its sampling decision")
 	flags.Uint64(CONFIG_CHAINSYNC_CHAINID, 80001, "the chain-id of the chain to connect to")
 
-	flags.String(CONFIG_API_HTTP_LISTEN_ADDRESS, CONFIG_API_HTTP_LISTEN_ADDRESS_DEFAULT, "the listen address to listen on")
-
 	flags.Duration(CONFIG_BLOCK_CACHE_DURATION, CONFIG_BLOCK_CACHE_DURATION_DEFAULT, "time to keep synced blocks in read/write cache and don't write them to store")
 
+	flags.Bool(CONFIG_LEADER_ELECTION_ENABLED, true, "only run ingestors, aggregators and cachers when holding their lease")
+	flags.String(CONFIG_LEADER_ELECTION_IDENTITY, "", "the identity used to hold leases, defaults to the hostname with a random suffix")
+	flags.Duration(CONFIG_LEADER_ELECTION_LEASE_DURATION, 30*time.Second, "the time a lease is valid without being renewed")
+	flags.Duration(CONFIG_LEADER_ELECTION_RETRY_INTERVAL, 10*time.Second, "the interval to retry acquiring a lease held by another replica")
+	flags.String(CONFIG_LEADER_ELECTION_STORE, CONFIG_LEADER_ELECTION_STORE_DEFAULT, "the store to use for leases")
+
+	flags.Duration(CONFIG_SUPERVISOR_INITIAL_BACKOFF, 5*time.Second, "the time to wait before restarting a failed component")
+	flags.Duration(CONFIG_SUPERVISOR_MAX_BACKOFF, 5*time.Minute, "the maximum time to wait before restarting a failed component")
+	flags.Int(CONFIG_SUPERVISOR_MAX_RESTARTS, 0, "the number of consecutive restarts after which a component is marked as failed, 0 restarts forever")
+	flags.Duration(CONFIG_SUPERVISOR_STABLE_AFTER, 1*time.Minute, "the time a component must run before it is considered running")
+
 	flags.String(CONFIG_STORE_CLOUDDATASTORE_PROJECT, "", "the project to use for Google Cloud Data Store")
-	flags.String(CONFIG_PUBSUB_PROJECT, "", "the project to use for Google Cloud PubSub")
 
 	flags.String(CONFIG_GATEWAY_CONTRACT, "", "the address of the gateway registry contract")
+	flags.Uint(CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
+	flags.String(CONFIG_GATEWAY_STORE, CONFIG_GATEWAY_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_ROUTER_CONTRACT, "", "the address of the router registry contract")
+	flags.Uint(CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
+	flags.String(CONFIG_ROUTER_STORE, CONFIG_ROUTER_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_MAPPER_CONTRACT, "", "the address of the mapper registry contract")
+	flags.Uint(CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
+	flags.String(CONFIG_MAPPER_STORE, CONFIG_MAPPER_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_MAPPING_STORE, CONFIG_MAPPING_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_REWARDS_STORE, CONFIG_REWARDS_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_STATS_STORE, CONFIG_STATS_STORE_DEFAULT, "the store to use for network statistics")
+
+	flags.String(CONFIG_EVENTS_REDIS_HOST, "", "the redis host ingestors publish events on for the event stream, events aren't published when empty")
+	flags.String(CONFIG_EVENTS_REDIS_CHANNEL, CONFIG_EVENTS_REDIS_CHANNEL_DEFAULT, "the redis pub/sub channel events are published on")
+
+	flags.Bool(CONFIG_WEBHOOK_ENABLED, false, "enable webhooks, ingestors create deliveries and the API serves registration and dispatches deliveries")
+	flags.String(CONFIG_WEBHOOK_STORE, CONFIG_WEBHOOK_STORE_DEFAULT, "the store to use")
+
+	flags.String(CONFIG_API_KEY_STORE, CONFIG_API_KEY_STORE_DEFAULT, "the store to use for API keys")
+	flags.String(CONFIG_STATUS_STORE, CONFIG_STATUS_STORE_DEFAULT, "the store to use for the last polls of ingestors, aggregators and cachers")
+
+	// ingestors sync from the RPC node, the API reports the chain head
+	flags.String(CONFIG_CHAINSYNC_RPC_ENDPOINT, "", "the RPC endpoint to use to get chain data from")
+}
+
+// APIFlags registers the flags used by the API, including the cache flags
+// the gateway and mapper APIs and the redis rate limiter read.
+func APIFlags(flags *pflag.FlagSet) {
+	cacheFlags(flags)
+
+	flags.String(CONFIG_API_HTTP_LISTEN_ADDRESS, CONFIG_API_HTTP_LISTEN_ADDRESS_DEFAULT, "the listen address to listen on")
+	flags.Bool(CONFIG_API_OPENAPI_VALIDATE_REQUESTS, false, "reject requests that don't match the OpenAPI document")
+	flags.Bool(CONFIG_API_OPENAPI_VALIDATE_RESPONSES, false, "replace responses that don't match the OpenAPI document with an error, buffers responses and is meant for testing")
+	flags.Bool(CONFIG_API_RATELIMIT_ENABLED, true, "rate limit requests per IP, or per API key for requests with a key")
+	flags.String(CONFIG_API_RATELIMIT_STORE, CONFIG_API_RATELIMIT_STORE_DEFAULT, "where to keep the rate limit state: memory, per replica, or redis, shared through the gateway cacher redis host")
+	flags.Float64(CONFIG_API_RATELIMIT_IP_RATE, 10, "the number of reads per second an IP can sustain in a route group")
+	flags.Int(CONFIG_API_RATELIMIT_IP_BURST, 50, "the number of reads an IP can make at once in a route group")
+	flags.Float64(CONFIG_API_RATELIMIT_KEY_RATE, 100, "the number of reads per second an API key can sustain in a route group")
+	flags.Int(CONFIG_API_RATELIMIT_KEY_BURST, 200, "the number of reads an API key can make at once in a route group")
+	flags.Float64(CONFIG_API_RATELIMIT_WRITE_IP_RATE, 0.2, "the number of writes per second an IP can sustain in a route group")
+	flags.Int(CONFIG_API_RATELIMIT_WRITE_IP_BURST, 10, "the number of writes an IP can make at once in a route group")
+	flags.Float64(CONFIG_API_RATELIMIT_WRITE_KEY_RATE, 5, "the number of writes per second an API key can sustain in a route group")
+	flags.Int(CONFIG_API_RATELIMIT_WRITE_KEY_BURST, 50, "the number of writes an API key can make at once in a route group")
+	flags.StringSlice(CONFIG_API_RATELIMIT_TRUSTED_PROXIES, nil, "the IPs or CIDR ranges of the proxies in front of the API, the client IP is only taken from the X-Forwarded-For or X-Real-IP header of requests they forward")
+	flags.Duration(CONFIG_API_KEY_CACHE_TTL, 1*time.Minute, "the time verified API keys are cached, revocations take this long to take effect")
+
 	flags.Bool(CONFIG_GATEWAY_API_ENABLED, true, "enable the API for gateways")
-	flags.Bool(CONFIG_GATEWAY_CACHER_ENABLED, false, "enable the cache of gateway state")
-	flags.Duration(CONFIG_GATEWAY_CACHER_UPDATE_INTERVAL, 10*time.Minute, "the time to update the gateway cache in")
-	flags.String(CONFIG_GATEWAY_CACHER_REDIS_HOST, "", "the redis host to use for the cache")
-	flags.Bool(CONFIG_GATEWAY_AGGREGATOR_ENABLED, true, "enable the aggregation of gateway events")
-	flags.Duration(CONFIG_GATEWAY_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
-	flags.Uint64(CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
+	flags.Bool(CONFIG_ROUTER_API_ENABLED, true, "enable the API for routers")
+	flags.Bool(CONFIG_MAPPER_API_ENABLED, true, "enable the API for mappers")
+	flags.Bool(CONFIG_MAPPING_API_ENABLED, false, "enable the API for mapping records")
+	flags.Bool(CONFIG_MAPPING_API_SHOW_RECENT_MAPPINGS, false, "show the recent mappings too")
+	flags.Bool(CONFIG_MAPPING_API_UNVERIFIED_MAPPING_ENABLED, false, "enable the unverified mapping API.")
+
+	flags.Bool(CONFIG_REWARDS_API_ENABLED, true, "enable the API for rewards")
+
+	flags.Bool(CONFIG_OWNER_API_ENABLED, false, "enable the API that combines the gateways, mappers, pending events and rewards of an owner")
+	flags.Duration(CONFIG_OWNER_API_FETCH_TIMEOUT, 5*time.Second, "the time each part of an owner portfolio has to be fetched in, parts that take longer are left out")
+
+	flags.Bool(CONFIG_ACTIVITY_API_ENABLED, false, "enable the activity feeds of owners and the network")
+
+	flags.Bool(CONFIG_STATS_API_ENABLED, false, "enable the API for network statistics")
+
+	flags.Bool(CONFIG_TILES_API_ENABLED, false, "enable the vector tiles of gateways and coverage")
+	flags.Int(CONFIG_TILES_API_CACHE_SIZE, 4096, "the number of vector tiles cached in memory, 0 disables the cache")
+
+	flags.Bool(CONFIG_GRAPHQL_API_ENABLED, false, "enable the GraphQL API")
+	flags.Int(CONFIG_GRAPHQL_API_MAX_DEPTH, 10, "the maximum nesting depth of a GraphQL query")
+	flags.Int(CONFIG_GRAPHQL_API_MAX_COST, 2000, "the maximum estimated number of fields a GraphQL query resolves")
+	flags.Int(CONFIG_GRAPHQL_API_MAX_QUERY_LENGTH, 10000, "the maximum length of a GraphQL query in bytes")
+
+	flags.Bool(CONFIG_GRPC_API_ENABLED, false, "enable the gRPC API for gateways, mappers and routers")
+	flags.String(CONFIG_GRPC_API_LISTEN_ADDRESS, CONFIG_GRPC_API_LISTEN_ADDRESS_DEFAULT, "the listen address of the gRPC API")
+	flags.Duration(CONFIG_GRPC_API_WATCH_POLL_INTERVAL, CONFIG_GRPC_API_WATCH_POLL_INTERVAL_DEFAULT, "the interval gRPC watch streams check for new events")
+	flags.Uint64(CONFIG_GRPC_API_MAX_BACKFILL_BLOCKS, 50_000, "the maximum number of blocks a gRPC watch can resume from behind the aggregator")
+
+	flags.Bool(CONFIG_EVENTS_API_ENABLED, false, "enable the SSE and WebSocket stream of gateway, mapper and router events")
+	flags.Uint64(CONFIG_EVENTS_API_MAX_BACKFILL_BLOCKS, 50_000, "the maximum number of blocks an event stream can resume from behind the ingestors")
+
+	flags.Duration(CONFIG_WEBHOOK_DISPATCHER_POLL_INTERVAL, 10*time.Second, "the interval to check for due webhook deliveries and new rewards")
+	flags.Duration(CONFIG_WEBHOOK_DISPATCHER_TIMEOUT, 10*time.Second, "the time a webhook has to accept a delivery")
+	flags.Int(CONFIG_WEBHOOK_DISPATCHER_MAX_ATTEMPTS, 10, "the number of attempts after which a webhook delivery fails")
+	flags.Duration(CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF, 30*time.Second, "the time to wait before retrying a failed webhook delivery, doubles for every retry")
+	flags.Duration(CONFIG_WEBHOOK_DISPATCHER_MAX_BACKOFF, 6*time.Hour, "the maximum time to wait before retrying a failed webhook delivery")
+}
+
+// IngestorFlags registers the flags used by the ingestors.
+func IngestorFlags(flags *pflag.FlagSet) {
+	flags.String(CONFIG_PUBSUB_PROJECT, "", "the project to use for Google Cloud PubSub")
+
 	flags.Bool(CONFIG_GATEWAY_INGESTOR_ENABLED, true, "enable the ingestion of gateway events")
 	flags.String(CONFIG_GATEWAY_INGESTOR_SOURCE, "chainsync", "the source of the gateway data")
-	flags.Uint(CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
 	flags.Uint64(CONFIG_GATEWAY_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, 10000, "the number of blocks to scan at most at once")
 	flags.Duration(CONFIG_GATEWAY_CHAINSYNC_POLL_INTERVAL, 1*time.Minute, "the interval to poll the RPC node for new transactions")
-	flags.String(CONFIG_GATEWAY_STORE, CONFIG_GATEWAY_STORE_DEFAULT, "the store to use")
 
-	flags.String(CONFIG_ROUTER_CONTRACT, "", "the address of the router registry contract")
-	flags.Bool(CONFIG_ROUTER_API_ENABLED, true, "enable the API for routers")
-	flags.Bool(CONFIG_ROUTER_AGGREGATOR_ENABLED, true, "enable the aggregation of router events")
-	flags.Duration(CONFIG_ROUTER_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
-	flags.Uint64(CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
 	flags.Bool(CONFIG_ROUTER_INGESTOR_ENABLED, true, "enable the ingestion of router events")
 	flags.String(CONFIG_ROUTER_INGESTOR_SOURCE, "chainsync", "the source of the router data")
-	flags.Uint(CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
 	flags.Uint64(CONFIG_ROUTER_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, 10000, "the number of blocks to scan at most at once")
 	flags.Duration(CONFIG_ROUTER_CHAINSYNC_POLL_INTERVAL, 1*time.Minute, "the interval to poll the RPC node for new transactions")
-	flags.String(CONFIG_ROUTER_STORE, CONFIG_ROUTER_STORE_DEFAULT, "the store to use")
 
-	flags.String(CONFIG_MAPPER_CONTRACT, "", "the address of the mapper registry contract")
-	flags.Bool(CONFIG_MAPPER_API_ENABLED, true, "enable the API for mappers")
-	flags.Bool(CONFIG_MAPPER_CACHER_ENABLED, false, "enable the cache of mapper state")
-	flags.Duration(CONFIG_MAPPER_CACHER_UPDATE_INTERVAL, 10*time.Minute, "the time to update the mapper cache in")
-	flags.String(CONFIG_MAPPER_CACHER_REDIS_HOST, "", "the redis host to use for the cache")
-	flags.Bool(CONFIG_MAPPER_AGGREGATOR_ENABLED, true, "enable the aggregation of mapper events")
-	flags.Duration(CONFIG_MAPPER_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
-	flags.Uint64(CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
 	flags.Bool(CONFIG_MAPPER_INGESTOR_ENABLED, true, "enable the ingestion of mapper events")
 	flags.String(CONFIG_MAPPER_INGESTOR_SOURCE, "chainsync", "the source of the mapper data")
-	flags.Uint(CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS, 128, "the number of confirmations required before a transaction is confirmed")
 	flags.Uint64(CONFIG_MAPPER_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, 10000, "the number of blocks to scan at most at once")
 	flags.Duration(CONFIG_MAPPER_CHAINSYNC_POLL_INTERVAL, 1*time.Minute, "the interval to poll the RPC node for new transactions")
-	flags.String(CONFIG_MAPPER_STORE, CONFIG_MAPPER_STORE_DEFAULT, "the store to use")
 
 	flags.Bool(CONFIG_MAPPING_INGESTOR_ENABLED, false, "enable the ingestor for mapping records")
-	flags.Bool(CONFIG_MAPPING_API_ENABLED, false, "enable the API for mapping records")
-	flags.Bool(CONFIG_MAPPING_API_SHOW_RECENT_MAPPINGS, false, "show the recent mappings too")
-	flags.Bool(CONFIG_MAPPING_API_UNVERIFIED_MAPPING_ENABLED, false, "enable the unverified mapping API.")
-	flags.String(CONFIG_MAPPING_STORE, CONFIG_MAPPING_STORE_DEFAULT, "the store to use")
+}
 
-	flags.String(CONFIG_REWARDS_STORE, CONFIG_REWARDS_STORE_DEFAULT, "the store to use")
-	flags.Bool(CONFIG_REWARDS_API_ENABLED, true, "enable the API for rewards")
+// AggregatorFlags registers the flags used by the aggregators.
+func AggregatorFlags(flags *pflag.FlagSet) {
+	flags.Bool(CONFIG_GATEWAY_AGGREGATOR_ENABLED, true, "enable the aggregation of gateway events")
+	flags.Duration(CONFIG_GATEWAY_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
+	flags.Uint64(CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
 
+	flags.Bool(CONFIG_ROUTER_AGGREGATOR_ENABLED, true, "enable the aggregation of router events")
+	flags.Duration(CONFIG_ROUTER_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
+	flags.Uint64(CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
+
+	flags.Bool(CONFIG_MAPPER_AGGREGATOR_ENABLED, true, "enable the aggregation of mapper events")
+	flags.Duration(CONFIG_MAPPER_AGGREGATOR_POLL_INTERVAL, 1*time.Minute, "the interval to poll the store for new events to integrate")
+	flags.Uint64(CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, 100000, "the number of blocks to scan at most at once")
+
+	flags.Bool(CONFIG_STATS_AGGREGATOR_ENABLED, false, "enable the aggregation of daily network statistics")
+	flags.Duration(CONFIG_STATS_AGGREGATOR_POLL_INTERVAL, 1*time.Hour, "the interval to check for completed days to compute statistics for")
+}
+
+// CacherFlags registers the flags used by the cachers.
+func CacherFlags(flags *pflag.FlagSet) {
+	cacheFlags(flags)
+
+	flags.Duration(CONFIG_GATEWAY_CACHER_UPDATE_INTERVAL, 10*time.Minute, "the time to update the gateway cache in")
+	flags.Duration(CONFIG_MAPPER_CACHER_UPDATE_INTERVAL, 10*time.Minute, "the time to update the mapper cache in")
+}
+
+// cacheFlags registers the flags of the caches the cachers fill and the API
+// reads. Flags that are already registered are skipped, so the API and cacher
+// flags can share a flag set.
+func cacheFlags(flags *pflag.FlagSet) {
+	if flags.Lookup(CONFIG_GATEWAY_CACHER_ENABLED) != nil {
+		return
+	}
+
+	flags.Bool(CONFIG_GATEWAY_CACHER_ENABLED, false, "enable the cache of gateway state")
+	flags.String(CONFIG_GATEWAY_CACHER_REDIS_HOST, "", "the redis host to use for the cache")
+
+	flags.Bool(CONFIG_MAPPER_CACHER_ENABLED, false, "enable the cache of mapper state")
+	flags.String(CONFIG_MAPPER_CACHER_REDIS_HOST, "", "the redis host to use for the cache")
 }
 
 func AddressFromConfig(key string) common.Address {
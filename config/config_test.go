@@ -0,0 +1,50 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestRoleFlagsRegisterCacheFlags(t *testing.T) {
+	all := func(flags *pflag.FlagSet) {
+		APIFlags(flags)
+		CacherFlags(flags)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		register func(*pflag.FlagSet)
+	}{
+		{"api", APIFlags},
+		{"cacher", CacherFlags},
+		{"all", all},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			flags := pflag.NewFlagSet(tc.name, pflag.ContinueOnError)
+			tc.register(flags)
+
+			for _, key := range []string{CONFIG_GATEWAY_CACHER_ENABLED, CONFIG_GATEWAY_CACHER_REDIS_HOST, CONFIG_MAPPER_CACHER_ENABLED, CONFIG_MAPPER_CACHER_REDIS_HOST} {
+				if flags.Lookup(key) == nil {
+					t.Errorf("flag %s not registered", key)
+				}
+			}
+		})
+	}
+}
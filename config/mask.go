@@ -0,0 +1,108 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const maskedValue = "****"
+
+// secretKeys are settings that can contain credentials, e.g. an API key in
+// the RPC endpoint or a password in the Redis address.
+var secretKeys = map[string]bool{
+	CONFIG_CHAINSYNC_RPC_ENDPOINT:    true,
+	CONFIG_GATEWAY_CACHER_REDIS_HOST: true,
+	CONFIG_MAPPER_CACHER_REDIS_HOST:  true,
+	CONFIG_EVENTS_REDIS_HOST:         true,
+}
+
+// IsSecret returns true if the value of the setting can contain credentials.
+func IsSecret(key string) bool {
+	if secretKeys[key] {
+		return true
+	}
+
+	parts := strings.Split(key, ".")
+	last := parts[len(parts)-1]
+	return strings.Contains(last, "secret") || strings.Contains(last, "password") || strings.Contains(last, "token")
+}
+
+// Setting is a resolved configuration setting.
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Settings returns all resolved settings sorted by key with the credentials
+// in secret settings masked.
+func Settings() []Setting {
+	keys := viper.AllKeys()
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		value := fmt.Sprint(viper.Get(key))
+		if IsSecret(key) {
+			value = Mask(value)
+		}
+		settings = append(settings, Setting{Key: key, Value: value})
+	}
+	return settings
+}
+
+// Mask masks the credentials in value. For URLs the scheme and host are kept
+// so the value can still be recognized.
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return maskedValue
+		}
+
+		masked := u.Scheme + "://"
+		if u.User != nil {
+			masked += maskedValue + "@"
+		}
+		masked += u.Host
+		if (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			masked += "/" + maskedValue
+		}
+		return masked
+	}
+
+	// host:port addresses can carry credentials as user:password@host:port
+	if i := strings.LastIndex(value, "@"); i >= 0 {
+		return maskedValue + value[i:]
+	}
+
+	// values without any structure are masked entirely unless they are a plain
+	// host:port address
+	if strings.Contains(value, ":") && !strings.ContainsAny(value, "/?") {
+		return value
+	}
+	return maskedValue
+}
@@ -0,0 +1,485 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const STORE_TYPE_CLOUDDATASTORE = "clouddatastore"
+
+// Problem is an issue found while validating the configuration.
+type Problem struct {
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Key, p.Message)
+}
+
+// Roles selects the roles whose settings are validated.
+type Roles struct {
+	API        bool
+	Ingestor   bool
+	Aggregator bool
+	Cacher     bool
+	// RequireEnabled reports a problem for every selected role that has no
+	// enabled component.
+	RequireEnabled bool
+}
+
+// AllRoles selects all roles without requiring that any of them is enabled.
+var AllRoles = Roles{API: true, Ingestor: true, Aggregator: true, Cacher: true}
+
+type validator struct {
+	problems []Problem
+	// stores are the store type keys that are used by enabled components
+	stores map[string]bool
+	// rpc is set when the RPC endpoint is checked
+	rpc bool
+}
+
+func (v *validator) problem(key, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks the settings of every enabled component of the selected
+// roles, and the settings they depend on. It returns all problems found.
+func Validate(roles Roles) []Problem {
+	v := &validator{stores: make(map[string]bool)}
+
+	v.common()
+	if roles.API {
+		v.api(roles.RequireEnabled)
+	}
+	if roles.Ingestor {
+		v.ingestor(roles.RequireEnabled)
+	}
+	if roles.Aggregator {
+		v.aggregator(roles.RequireEnabled)
+	}
+	if roles.Cacher {
+		v.cacher(roles.RequireEnabled)
+	}
+	v.storesUsed()
+
+	return v.problems
+}
+
+func (v *validator) common() {
+	if _, err := logrus.ParseLevel(viper.GetString(CONFIG_LOG_LEVEL)); err != nil {
+		v.problem(CONFIG_LOG_LEVEL, "invalid log level %q", viper.GetString(CONFIG_LOG_LEVEL))
+	}
+	if viper.GetUint64(CONFIG_CHAINSYNC_CHAINID) == 0 {
+		v.problem(CONFIG_CHAINSYNC_CHAINID, "must be set")
+	}
+	v.positiveDuration(CONFIG_BLOCK_CACHE_DURATION)
+	if addr := viper.GetString(CONFIG_METRICS_LISTEN_ADDRESS); addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			v.problem(CONFIG_METRICS_LISTEN_ADDRESS, "invalid listen address %q", addr)
+		}
+	}
+	switch exporter := viper.GetString(CONFIG_TRACING_EXPORTER); exporter {
+	case "none", "stdout":
+	case "otlp":
+		if viper.GetString(CONFIG_TRACING_OTLP_ENDPOINT) == "" {
+			v.problem(CONFIG_TRACING_OTLP_ENDPOINT, "must be set when traces are exported over OTLP")
+		}
+	default:
+		v.problem(CONFIG_TRACING_EXPORTER, "invalid exporter %q, must be none, otlp or stdout", exporter)
+	}
+	if ratio := viper.GetFloat64(CONFIG_TRACING_SAMPLE_RATIO); ratio < 0 || ratio > 1 {
+		v.problem(CONFIG_TRACING_SAMPLE_RATIO, "must be between 0 and 1")
+	}
+
+	v.positiveDuration(CONFIG_SUPERVISOR_INITIAL_BACKOFF)
+	if viper.GetDuration(CONFIG_SUPERVISOR_MAX_BACKOFF) < viper.GetDuration(CONFIG_SUPERVISOR_INITIAL_BACKOFF) {
+		v.problem(CONFIG_SUPERVISOR_MAX_BACKOFF, "must not be smaller than %s", CONFIG_SUPERVISOR_INITIAL_BACKOFF)
+	}
+	if viper.GetInt(CONFIG_SUPERVISOR_MAX_RESTARTS) < 0 {
+		v.problem(CONFIG_SUPERVISOR_MAX_RESTARTS, "must not be negative")
+	}
+	v.positiveDuration(CONFIG_SUPERVISOR_STABLE_AFTER)
+}
+
+// leaderElection validates the leader election settings, they are only used
+// when a component that runs under a lease is enabled.
+func (v *validator) leaderElection() {
+	if !viper.GetBool(CONFIG_LEADER_ELECTION_ENABLED) || v.stores[CONFIG_LEADER_ELECTION_STORE] {
+		return
+	}
+	v.stores[CONFIG_LEADER_ELECTION_STORE] = true
+
+	if viper.GetDuration(CONFIG_LEADER_ELECTION_LEASE_DURATION) < 3*time.Second {
+		v.problem(CONFIG_LEADER_ELECTION_LEASE_DURATION, "must be at least 3s")
+	}
+	v.positiveDuration(CONFIG_LEADER_ELECTION_RETRY_INTERVAL)
+}
+
+func (v *validator) api(required bool) {
+	if _, _, err := net.SplitHostPort(viper.GetString(CONFIG_API_HTTP_LISTEN_ADDRESS)); err != nil {
+		v.problem(CONFIG_API_HTTP_LISTEN_ADDRESS, "invalid listen address %q", viper.GetString(CONFIG_API_HTTP_LISTEN_ADDRESS))
+	}
+
+	if viper.GetBool(CONFIG_API_RATELIMIT_ENABLED) {
+		v.rateLimit()
+	}
+	// the status endpoint reports the last polls
+	v.stores[CONFIG_STATUS_STORE] = true
+
+	if viper.GetBool(CONFIG_GATEWAY_API_ENABLED) {
+		v.contract(CONFIG_GATEWAY_API_ENABLED, CONFIG_GATEWAY_CONTRACT)
+		v.stores[CONFIG_GATEWAY_STORE] = true
+		// the onboard purger runs with the gateway API
+		v.leaderElection()
+	}
+	if viper.GetBool(CONFIG_ROUTER_API_ENABLED) {
+		v.contract(CONFIG_ROUTER_API_ENABLED, CONFIG_ROUTER_CONTRACT)
+		v.stores[CONFIG_ROUTER_STORE] = true
+	}
+	if viper.GetBool(CONFIG_MAPPER_API_ENABLED) {
+		v.contract(CONFIG_MAPPER_API_ENABLED, CONFIG_MAPPER_CONTRACT)
+		v.stores[CONFIG_MAPPER_STORE] = true
+	}
+	if viper.GetBool(CONFIG_MAPPING_API_ENABLED) {
+		v.contract(CONFIG_MAPPING_API_ENABLED, CONFIG_MAPPER_CONTRACT)
+		v.stores[CONFIG_MAPPING_STORE] = true
+		v.stores[CONFIG_MAPPER_STORE] = true
+		v.stores[CONFIG_REWARDS_STORE] = true
+	}
+	if viper.GetBool(CONFIG_REWARDS_API_ENABLED) {
+		v.stores[CONFIG_REWARDS_STORE] = true
+	}
+	if viper.GetBool(CONFIG_OWNER_API_ENABLED) {
+		for _, store := range []string{CONFIG_GATEWAY_STORE, CONFIG_MAPPER_STORE, CONFIG_MAPPING_STORE, CONFIG_REWARDS_STORE} {
+			v.stores[store] = true
+		}
+		v.positiveDuration(CONFIG_OWNER_API_FETCH_TIMEOUT)
+	}
+	if viper.GetBool(CONFIG_ACTIVITY_API_ENABLED) {
+		for _, store := range []string{CONFIG_GATEWAY_STORE, CONFIG_MAPPER_STORE, CONFIG_ROUTER_STORE, CONFIG_REWARDS_STORE} {
+			v.stores[store] = true
+		}
+	}
+	if viper.GetBool(CONFIG_STATS_API_ENABLED) {
+		v.stores[CONFIG_STATS_STORE] = true
+	}
+	if viper.GetBool(CONFIG_TILES_API_ENABLED) {
+		v.stores[CONFIG_GATEWAY_STORE] = true
+		v.stores[CONFIG_MAPPING_STORE] = true
+		v.stores[CONFIG_REWARDS_STORE] = true
+		if viper.GetInt(CONFIG_TILES_API_CACHE_SIZE) < 0 {
+			v.problem(CONFIG_TILES_API_CACHE_SIZE, "must not be negative")
+		}
+	}
+	if viper.GetBool(CONFIG_GRAPHQL_API_ENABLED) {
+		v.contract(CONFIG_GRAPHQL_API_ENABLED, CONFIG_GATEWAY_CONTRACT)
+		v.contract(CONFIG_GRAPHQL_API_ENABLED, CONFIG_ROUTER_CONTRACT)
+		v.contract(CONFIG_GRAPHQL_API_ENABLED, CONFIG_MAPPER_CONTRACT)
+		for _, store := range []string{CONFIG_GATEWAY_STORE, CONFIG_ROUTER_STORE, CONFIG_MAPPER_STORE, CONFIG_MAPPING_STORE, CONFIG_REWARDS_STORE} {
+			v.stores[store] = true
+		}
+		for _, key := range []string{CONFIG_GRAPHQL_API_MAX_DEPTH, CONFIG_GRAPHQL_API_MAX_COST, CONFIG_GRAPHQL_API_MAX_QUERY_LENGTH} {
+			if viper.GetInt(key) <= 0 {
+				v.problem(key, "must be larger than 0")
+			}
+		}
+	}
+	if viper.GetBool(CONFIG_GRPC_API_ENABLED) {
+		if _, _, err := net.SplitHostPort(viper.GetString(CONFIG_GRPC_API_LISTEN_ADDRESS)); err != nil {
+			v.problem(CONFIG_GRPC_API_LISTEN_ADDRESS, "invalid listen address %q", viper.GetString(CONFIG_GRPC_API_LISTEN_ADDRESS))
+		}
+		v.contract(CONFIG_GRPC_API_ENABLED, CONFIG_GATEWAY_CONTRACT)
+		v.contract(CONFIG_GRPC_API_ENABLED, CONFIG_ROUTER_CONTRACT)
+		v.contract(CONFIG_GRPC_API_ENABLED, CONFIG_MAPPER_CONTRACT)
+		v.stores[CONFIG_GATEWAY_STORE] = true
+		v.stores[CONFIG_ROUTER_STORE] = true
+		v.stores[CONFIG_MAPPER_STORE] = true
+		v.positiveDuration(CONFIG_GRPC_API_WATCH_POLL_INTERVAL)
+		if viper.GetUint64(CONFIG_GRPC_API_MAX_BACKFILL_BLOCKS) == 0 {
+			v.problem(CONFIG_GRPC_API_MAX_BACKFILL_BLOCKS, "must be larger than 0")
+		}
+	}
+	if viper.GetBool(CONFIG_EVENTS_API_ENABLED) {
+		if viper.GetString(CONFIG_EVENTS_REDIS_HOST) == "" {
+			v.problem(CONFIG_EVENTS_REDIS_HOST, "must be set when %s is enabled", CONFIG_EVENTS_API_ENABLED)
+		}
+		if viper.GetString(CONFIG_EVENTS_REDIS_CHANNEL) == "" {
+			v.problem(CONFIG_EVENTS_REDIS_CHANNEL, "must be set when %s is enabled", CONFIG_EVENTS_API_ENABLED)
+		}
+		v.contract(CONFIG_EVENTS_API_ENABLED, CONFIG_GATEWAY_CONTRACT)
+		v.contract(CONFIG_EVENTS_API_ENABLED, CONFIG_ROUTER_CONTRACT)
+		v.contract(CONFIG_EVENTS_API_ENABLED, CONFIG_MAPPER_CONTRACT)
+		if viper.GetUint64(CONFIG_EVENTS_API_MAX_BACKFILL_BLOCKS) == 0 {
+			v.problem(CONFIG_EVENTS_API_MAX_BACKFILL_BLOCKS, "must be larger than 0")
+		}
+		v.stores[CONFIG_GATEWAY_STORE] = true
+		v.stores[CONFIG_ROUTER_STORE] = true
+		v.stores[CONFIG_MAPPER_STORE] = true
+	}
+	if viper.GetBool(CONFIG_WEBHOOK_ENABLED) {
+		v.stores[CONFIG_WEBHOOK_STORE] = true
+		v.stores[CONFIG_REWARDS_STORE] = true
+		v.positiveDuration(CONFIG_WEBHOOK_DISPATCHER_POLL_INTERVAL)
+		v.positiveDuration(CONFIG_WEBHOOK_DISPATCHER_TIMEOUT)
+		v.positiveDuration(CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF)
+		if viper.GetDuration(CONFIG_WEBHOOK_DISPATCHER_MAX_BACKOFF) < viper.GetDuration(CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF) {
+			v.problem(CONFIG_WEBHOOK_DISPATCHER_MAX_BACKOFF, "must not be smaller than %s", CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF)
+		}
+		if viper.GetInt(CONFIG_WEBHOOK_DISPATCHER_MAX_ATTEMPTS) <= 0 {
+			v.problem(CONFIG_WEBHOOK_DISPATCHER_MAX_ATTEMPTS, "must be larger than 0")
+		}
+		// the dispatcher runs with the API
+		v.leaderElection()
+	}
+
+	if required {
+		v.anyEnabled("API", CONFIG_GATEWAY_API_ENABLED, CONFIG_ROUTER_API_ENABLED, CONFIG_MAPPER_API_ENABLED,
+			CONFIG_MAPPING_API_ENABLED, CONFIG_REWARDS_API_ENABLED, CONFIG_OWNER_API_ENABLED, CONFIG_ACTIVITY_API_ENABLED, CONFIG_STATS_API_ENABLED, CONFIG_TILES_API_ENABLED, CONFIG_GRAPHQL_API_ENABLED, CONFIG_GRPC_API_ENABLED, CONFIG_EVENTS_API_ENABLED, CONFIG_WEBHOOK_ENABLED)
+	}
+}
+
+// rateLimit validates the rate limit settings, per route group overrides are
+// validated when the rate limiter is created.
+func (v *validator) rateLimit() {
+	switch store := viper.GetString(CONFIG_API_RATELIMIT_STORE); store {
+	case "memory":
+	case "redis":
+		if viper.GetString(CONFIG_GATEWAY_CACHER_REDIS_HOST) == "" {
+			v.problem(CONFIG_GATEWAY_CACHER_REDIS_HOST, "must be set when %s is redis", CONFIG_API_RATELIMIT_STORE)
+		}
+	default:
+		v.problem(CONFIG_API_RATELIMIT_STORE, "invalid rate limit store %q", store)
+	}
+
+	for _, key := range []string{CONFIG_API_RATELIMIT_IP_RATE, CONFIG_API_RATELIMIT_KEY_RATE, CONFIG_API_RATELIMIT_WRITE_IP_RATE, CONFIG_API_RATELIMIT_WRITE_KEY_RATE} {
+		if viper.GetFloat64(key) <= 0 {
+			v.problem(key, "must be larger than 0")
+		}
+	}
+	for _, key := range []string{CONFIG_API_RATELIMIT_IP_BURST, CONFIG_API_RATELIMIT_KEY_BURST, CONFIG_API_RATELIMIT_WRITE_IP_BURST, CONFIG_API_RATELIMIT_WRITE_KEY_BURST} {
+		if viper.GetInt(key) <= 0 {
+			v.problem(key, "must be larger than 0")
+		}
+	}
+
+	for _, proxy := range viper.GetStringSlice(CONFIG_API_RATELIMIT_TRUSTED_PROXIES) {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			v.problem(CONFIG_API_RATELIMIT_TRUSTED_PROXIES, "invalid IP or CIDR range %q", proxy)
+		}
+	}
+
+	v.positiveDuration(CONFIG_API_KEY_CACHE_TTL)
+	v.stores[CONFIG_API_KEY_STORE] = true
+}
+
+func (v *validator) ingestor(required bool) {
+	chain := false
+	for _, c := range []struct{ enabled, contract, source, scanRange, pollInterval, store string }{
+		{CONFIG_GATEWAY_INGESTOR_ENABLED, CONFIG_GATEWAY_CONTRACT, CONFIG_GATEWAY_INGESTOR_SOURCE, CONFIG_GATEWAY_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, CONFIG_GATEWAY_CHAINSYNC_POLL_INTERVAL, CONFIG_GATEWAY_STORE},
+		{CONFIG_ROUTER_INGESTOR_ENABLED, CONFIG_ROUTER_CONTRACT, CONFIG_ROUTER_INGESTOR_SOURCE, CONFIG_ROUTER_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, CONFIG_ROUTER_CHAINSYNC_POLL_INTERVAL, CONFIG_ROUTER_STORE},
+		{CONFIG_MAPPER_INGESTOR_ENABLED, CONFIG_MAPPER_CONTRACT, CONFIG_MAPPER_INGESTOR_SOURCE, CONFIG_MAPPER_CHAINSYNC_MAX_BLOCK_SCAN_RANGE, CONFIG_MAPPER_CHAINSYNC_POLL_INTERVAL, CONFIG_MAPPER_STORE},
+	} {
+		if !viper.GetBool(c.enabled) {
+			continue
+		}
+		chain = true
+
+		v.contract(c.enabled, c.contract)
+		if source := viper.GetString(c.source); source != "chainsync" {
+			v.problem(c.source, "unsupported source %q, only chainsync is supported", source)
+		}
+		if viper.GetUint64(c.scanRange) == 0 {
+			v.problem(c.scanRange, "must be larger than 0")
+		}
+		v.positiveDuration(c.pollInterval)
+		v.stores[c.store] = true
+		v.stores[CONFIG_STATUS_STORE] = true
+		v.leaderElection()
+	}
+
+	if chain {
+		v.rpcEndpoint()
+		if viper.GetString(CONFIG_EVENTS_REDIS_HOST) != "" && viper.GetString(CONFIG_EVENTS_REDIS_CHANNEL) == "" {
+			v.problem(CONFIG_EVENTS_REDIS_CHANNEL, "must be set when %s is set", CONFIG_EVENTS_REDIS_HOST)
+		}
+		if viper.GetBool(CONFIG_WEBHOOK_ENABLED) {
+			// the registry stores resolve the owner of events without one
+			for _, store := range []string{CONFIG_WEBHOOK_STORE, CONFIG_GATEWAY_STORE, CONFIG_ROUTER_STORE, CONFIG_MAPPER_STORE} {
+				v.stores[store] = true
+			}
+		}
+	}
+
+	if viper.GetBool(CONFIG_MAPPING_INGESTOR_ENABLED) {
+		if viper.GetString(CONFIG_PUBSUB_PROJECT) == "" {
+			v.problem(CONFIG_PUBSUB_PROJECT, "must be set when %s is enabled", CONFIG_MAPPING_INGESTOR_ENABLED)
+		}
+		v.stores[CONFIG_MAPPING_STORE] = true
+	}
+
+	if required {
+		v.anyEnabled("ingestor", CONFIG_GATEWAY_INGESTOR_ENABLED, CONFIG_ROUTER_INGESTOR_ENABLED,
+			CONFIG_MAPPER_INGESTOR_ENABLED, CONFIG_MAPPING_INGESTOR_ENABLED)
+	}
+}
+
+func (v *validator) aggregator(required bool) {
+	for _, c := range []struct{ enabled, contract, scanRange, pollInterval, store string }{
+		{CONFIG_GATEWAY_AGGREGATOR_ENABLED, CONFIG_GATEWAY_CONTRACT, CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, CONFIG_GATEWAY_AGGREGATOR_POLL_INTERVAL, CONFIG_GATEWAY_STORE},
+		{CONFIG_ROUTER_AGGREGATOR_ENABLED, CONFIG_ROUTER_CONTRACT, CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, CONFIG_ROUTER_AGGREGATOR_POLL_INTERVAL, CONFIG_ROUTER_STORE},
+		{CONFIG_MAPPER_AGGREGATOR_ENABLED, CONFIG_MAPPER_CONTRACT, CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE, CONFIG_MAPPER_AGGREGATOR_POLL_INTERVAL, CONFIG_MAPPER_STORE},
+	} {
+		if !viper.GetBool(c.enabled) {
+			continue
+		}
+
+		v.contract(c.enabled, c.contract)
+		if viper.GetUint64(c.scanRange) == 0 {
+			v.problem(c.scanRange, "must be larger than 0")
+		}
+		v.positiveDuration(c.pollInterval)
+		v.stores[c.store] = true
+		v.stores[CONFIG_STATUS_STORE] = true
+		v.leaderElection()
+	}
+
+	if viper.GetBool(CONFIG_GATEWAY_AGGREGATOR_ENABLED) {
+		// the local ids of gateways are taken from the onboard transactions
+		v.rpcEndpoint()
+	}
+
+	if viper.GetBool(CONFIG_STATS_AGGREGATOR_ENABLED) {
+		// statistics are computed from the events of all registries
+		for _, store := range []string{CONFIG_STATS_STORE, CONFIG_GATEWAY_STORE, CONFIG_ROUTER_STORE, CONFIG_MAPPER_STORE} {
+			v.stores[store] = true
+		}
+		v.positiveDuration(CONFIG_STATS_AGGREGATOR_POLL_INTERVAL)
+		// days are closed once the ingestors synced past their end
+		v.rpcEndpoint()
+		v.leaderElection()
+	}
+
+	if required {
+		v.anyEnabled("aggregator", CONFIG_GATEWAY_AGGREGATOR_ENABLED, CONFIG_ROUTER_AGGREGATOR_ENABLED, CONFIG_MAPPER_AGGREGATOR_ENABLED, CONFIG_STATS_AGGREGATOR_ENABLED)
+	}
+}
+
+func (v *validator) cacher(required bool) {
+	for _, c := range []struct{ enabled, contract, redisHost, updateInterval, store string }{
+		{CONFIG_GATEWAY_CACHER_ENABLED, CONFIG_GATEWAY_CONTRACT, CONFIG_GATEWAY_CACHER_REDIS_HOST, CONFIG_GATEWAY_CACHER_UPDATE_INTERVAL, CONFIG_GATEWAY_STORE},
+		{CONFIG_MAPPER_CACHER_ENABLED, CONFIG_MAPPER_CONTRACT, CONFIG_MAPPER_CACHER_REDIS_HOST, CONFIG_MAPPER_CACHER_UPDATE_INTERVAL, CONFIG_MAPPER_STORE},
+	} {
+		if !viper.GetBool(c.enabled) {
+			continue
+		}
+
+		v.contract(c.enabled, c.contract)
+		if viper.GetString(c.redisHost) == "" {
+			v.problem(c.redisHost, "must be set when %s is enabled", c.enabled)
+		}
+		v.positiveDuration(c.updateInterval)
+		v.stores[c.store] = true
+		v.stores[CONFIG_STATUS_STORE] = true
+		v.leaderElection()
+	}
+
+	if required {
+		v.anyEnabled("cacher", CONFIG_GATEWAY_CACHER_ENABLED, CONFIG_MAPPER_CACHER_ENABLED)
+	}
+}
+
+// storesUsed validates the store settings of the stores that are used by the
+// enabled components.
+func (v *validator) storesUsed() {
+	keys := make([]string, 0, len(v.stores))
+	for key := range v.stores {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cloudDataStore := false
+	for _, key := range keys {
+		switch storeType := viper.GetString(key); storeType {
+		case STORE_TYPE_CLOUDDATASTORE:
+			cloudDataStore = true
+		default:
+			v.problem(key, "invalid store type %q", storeType)
+		}
+	}
+
+	if cloudDataStore && viper.GetString(CONFIG_STORE_CLOUDDATASTORE_PROJECT) == "" {
+		v.problem(CONFIG_STORE_CLOUDDATASTORE_PROJECT, "must be set when a %s store is used", STORE_TYPE_CLOUDDATASTORE)
+	}
+}
+
+func (v *validator) contract(enabledKey, contractKey string) {
+	contract := viper.GetString(contractKey)
+	if !common.IsHexAddress(contract) || common.HexToAddress(contract) == (common.Address{}) {
+		v.problem(contractKey, "must be a valid contract address when %s is enabled", enabledKey)
+	}
+}
+
+func (v *validator) rpcEndpoint() {
+	if v.rpc {
+		return
+	}
+	v.rpc = true
+
+	endpoint := viper.GetString(CONFIG_CHAINSYNC_RPC_ENDPOINT)
+	if endpoint == "" {
+		v.problem(CONFIG_CHAINSYNC_RPC_ENDPOINT, "must be set to follow the chain")
+		return
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		v.problem(CONFIG_CHAINSYNC_RPC_ENDPOINT, "invalid URL")
+		return
+	}
+
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		v.problem(CONFIG_CHAINSYNC_RPC_ENDPOINT, "unsupported scheme %q, expected http, https, ws or wss", u.Scheme)
+	}
+}
+
+func (v *validator) positiveDuration(key string) {
+	if viper.GetDuration(key) <= 0 {
+		v.problem(key, "must be a positive duration")
+	}
+}
+
+func (v *validator) anyEnabled(role string, keys ...string) {
+	for _, key := range keys {
+		if viper.GetBool(key) {
+			return
+		}
+	}
+	v.problem(keys[0], "no %s enabled, enable at least one of %s", role, strings.Join(keys, ", "))
+}
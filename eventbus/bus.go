@@ -0,0 +1,185 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Publisher publishes events to all subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+// Subscriber subscribes to the published events.
+type Subscriber interface {
+	// Subscribe returns a channel that receives the published events until
+	// ctx expires, after which the channel is closed.
+	Subscribe(ctx context.Context) (<-chan *Event, error)
+}
+
+// Multi returns a Publisher that publishes to all non-nil publishers, or nil
+// when there are none.
+func Multi(publishers ...Publisher) Publisher {
+	var multi multiPublisher
+	for _, publisher := range publishers {
+		if publisher != nil {
+			multi = append(multi, publisher)
+		}
+	}
+
+	switch len(multi) {
+	case 0:
+		return nil
+	case 1:
+		return multi[0]
+	}
+	return multi
+}
+
+type multiPublisher []Publisher
+
+func (m multiPublisher) Publish(ctx context.Context, event *Event) error {
+	var errs []error
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Options configure a Bus.
+type Options struct {
+	Redis redis.UniversalClient
+	// Channel is the Redis pub/sub channel events are published on.
+	Channel string
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Bus distributes events over Redis pub/sub. Delivery is best effort,
+// subscribers that need all events catch up from the store.
+type Bus struct {
+	redis   redis.UniversalClient
+	channel string
+	log     logrus.FieldLogger
+}
+
+var (
+	_ Publisher  = (*Bus)(nil)
+	_ Subscriber = (*Bus)(nil)
+)
+
+// New creates a Bus with the given options.
+func New(opts Options) *Bus {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Bus{
+		redis:   opts.Redis,
+		channel: opts.Channel,
+		log:     opts.Logger,
+	}
+}
+
+// NewBus creates a Bus from the config.
+func NewBus() (*Bus, error) {
+	host := viper.GetString(config.CONFIG_EVENTS_REDIS_HOST)
+	if host == "" {
+		return nil, fmt.Errorf("%s not set", config.CONFIG_EVENTS_REDIS_HOST)
+	}
+
+	return New(Options{
+		Redis:   redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{host}}),
+		Channel: viper.GetString(config.CONFIG_EVENTS_REDIS_CHANNEL),
+	}), nil
+}
+
+// NewPublisher returns the Bus from the config as Publisher, or nil when no
+// Redis host is configured and events aren't published.
+func NewPublisher() (Publisher, error) {
+	if viper.GetString(config.CONFIG_EVENTS_REDIS_HOST) == "" {
+		return nil, nil
+	}
+
+	bus, err := NewBus()
+	if err != nil {
+		return nil, err
+	}
+	return bus, nil
+}
+
+func (b *Bus) Publish(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.redis.Publish(ctx, b.channel, payload).Err()
+}
+
+func (b *Bus) Subscribe(ctx context.Context) (<-chan *Event, error) {
+	sub := b.redis.Subscribe(ctx, b.channel)
+	// wait for the subscription to be confirmed, otherwise events published
+	// directly after Subscribe returns can be missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	events := make(chan *Event, 64)
+	go func() {
+		defer close(events)
+		defer sub.Close()
+
+		// the channel reconnects when the connection to Redis is lost
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event Event
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					b.log.WithError(err).Warn("dropping invalid event from bus")
+					continue
+				}
+
+				select {
+				case events <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventbus distributes gateway, mapper and router events from the
+// ingestors to the API replicas the moment they are stored.
+package eventbus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The registries events originate from.
+const (
+	RegistryGateway = "gateway"
+	RegistryMapper  = "mapper"
+	RegistryRouter  = "router"
+)
+
+// Event is a pending or confirmed event of one of the registries, exactly
+// one of Gateway, Mapper and Router is set.
+type Event struct {
+	Registry string `json:"registry"`
+	// Pending is true for events that don't have enough confirmations yet.
+	Pending bool `json:"pending"`
+	// Cursor is the position of a confirmed event, it is empty for pending
+	// events as they can still be dropped from the chain.
+	Cursor string `json:"cursor,omitempty"`
+
+	Gateway *types.GatewayEvent `json:"gateway,omitempty"`
+	Mapper  *types.MapperEvent  `json:"mapper,omitempty"`
+	Router  *types.RouterEvent  `json:"router,omitempty"`
+}
+
+// FromGatewayEvent wraps a gateway event.
+func FromGatewayEvent(event *types.GatewayEvent, pending bool) *Event {
+	return newEvent(&Event{Registry: RegistryGateway, Pending: pending, Gateway: event})
+}
+
+// FromMapperEvent wraps a mapper event.
+func FromMapperEvent(event *types.MapperEvent, pending bool) *Event {
+	return newEvent(&Event{Registry: RegistryMapper, Pending: pending, Mapper: event})
+}
+
+// FromRouterEvent wraps a router event.
+func FromRouterEvent(event *types.RouterEvent, pending bool) *Event {
+	return newEvent(&Event{Registry: RegistryRouter, Pending: pending, Router: event})
+}
+
+func newEvent(e *Event) *Event {
+	if !e.Pending {
+		e.Cursor = e.Position().String()
+	}
+	return e
+}
+
+// Position returns the position of the event on chain.
+func (e *Event) Position() Cursor {
+	switch {
+	case e.Gateway != nil:
+		return Cursor{BlockNumber: e.Gateway.BlockNumber, LogIndex: e.Gateway.LogIndex}
+	case e.Mapper != nil:
+		return Cursor{BlockNumber: e.Mapper.BlockNumber, LogIndex: e.Mapper.LogIndex}
+	case e.Router != nil:
+		return Cursor{BlockNumber: e.Router.BlockNumber, LogIndex: e.Router.LogIndex}
+	}
+	return Cursor{}
+}
+
+// Type returns the event type, e.g. onboard or transfer.
+func (e *Event) Type() string {
+	switch {
+	case e.Gateway != nil:
+		return string(e.Gateway.Type)
+	case e.Mapper != nil:
+		return string(e.Mapper.Type)
+	case e.Router != nil:
+		return string(e.Router.Type)
+	}
+	return ""
+}
+
+// ID returns the id of the gateway, mapper or router the event is about.
+func (e *Event) ID() types.ID {
+	switch {
+	case e.Gateway != nil:
+		return e.Gateway.ID
+	case e.Mapper != nil:
+		return e.Mapper.ID
+	case e.Router != nil:
+		return e.Router.ID
+	}
+	return types.ID{}
+}
+
+// Owners returns the owners involved in the event, for a transfer both the
+// old and the new owner.
+func (e *Event) Owners() []common.Address {
+	var owners []common.Address
+	for _, owner := range []*common.Address{e.newOwner(), e.oldOwner()} {
+		if owner != nil {
+			owners = append(owners, *owner)
+		}
+	}
+	return owners
+}
+
+func (e *Event) newOwner() *common.Address {
+	switch {
+	case e.Gateway != nil:
+		return e.Gateway.NewOwner
+	case e.Mapper != nil:
+		return e.Mapper.NewOwner
+	case e.Router != nil:
+		return e.Router.Owner
+	}
+	return nil
+}
+
+func (e *Event) oldOwner() *common.Address {
+	switch {
+	case e.Gateway != nil:
+		return e.Gateway.OldOwner
+	case e.Mapper != nil:
+		return e.Mapper.OldOwner
+	}
+	return nil
+}
+
+// Locations returns the locations involved in the event, only gateways have
+// a location.
+func (e *Event) Locations() []h3light.Cell {
+	if e.Gateway == nil {
+		return nil
+	}
+
+	var locations []h3light.Cell
+	for _, location := range []*h3light.Cell{e.Gateway.NewLocation, e.Gateway.OldLocation} {
+		if location != nil {
+			locations = append(locations, *location)
+		}
+	}
+	return locations
+}
+
+// Cursor is the position of a confirmed event on chain. It is encoded as
+// "<block number>-<log index>".
+type Cursor struct {
+	BlockNumber uint64
+	LogIndex    uint
+}
+
+// ParseCursor parses a cursor encoded by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	block, index, ok := strings.Cut(s, "-")
+	if !ok {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	blockNumber, err := strconv.ParseUint(block, 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	logIndex, err := strconv.ParseUint(index, 10, 0)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	return Cursor{BlockNumber: blockNumber, LogIndex: uint(logIndex)}, nil
+}
+
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d-%d", c.BlockNumber, c.LogIndex)
+}
+
+// Before returns true if c is positioned before o.
+func (c Cursor) Before(o Cursor) bool {
+	return c.BlockNumber < o.BlockNumber || (c.BlockNumber == o.BlockNumber && c.LogIndex < o.LogIndex)
+}
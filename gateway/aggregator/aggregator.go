@@ -17,37 +17,107 @@
 package aggregator
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// txLookupTimeout bounds the time the onboard transaction lookup takes, a slow
+// RPC node doesn't stall the aggregation.
+const txLookupTimeout = 10 * time.Second
+
+// errTransactionUnavailable is returned when the onboard transaction can't be
+// looked up, the local id is stored on retry.
+var errTransactionUnavailable = errors.New("onboard transaction unavailable")
+
+// Options configure a GatewayAggregator.
+type Options struct {
+	// Contract is the address of the gateway registry.
+	Contract common.Address
+	Store    store.Store
+	// PollInterval is the interval to poll the store for new events.
+	PollInterval time.Duration
+	// MaxBlockScanRange is the number of blocks to aggregate at most at once.
+	MaxBlockScanRange uint64
+	// Polls records the successful polls, optional.
+	Polls *status.Polls
+	// Dialer connects to the RPC node to get the onboard transactions.
+	Dialer chainsync.Dialer
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type GatewayAggregator struct {
-	store           store.Store
-	contractAddress common.Address
+	store             store.Store
+	contractAddress   common.Address
+	pollInterval      time.Duration
+	maxBlockScanRange uint64
+	polls             *status.Polls
+	dialer            chainsync.Dialer
+	client            *ethclient.Client
+	log               logrus.FieldLogger
 }
 
+// New creates a GatewayAggregator with the given options.
+func New(opts Options) *GatewayAggregator {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &GatewayAggregator{
+		contractAddress:   opts.Contract,
+		store:             opts.Store,
+		pollInterval:      opts.PollInterval,
+		maxBlockScanRange: opts.MaxBlockScanRange,
+		polls:             opts.Polls,
+		dialer:            opts.Dialer,
+		log:               opts.Logger,
+	}
+}
+
+// NewGatewayAggregator creates a GatewayAggregator from the config.
 func NewGatewayAggregator() (*GatewayAggregator, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	return &GatewayAggregator{
-		contractAddress: common.HexToAddress(viper.GetString(config.CONFIG_GATEWAY_CONTRACT)),
-		store:           store,
-	}, nil
+	contract := config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)
+
+	polls, err := status.NewPolls("GatewayAggregator", contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Contract:          contract,
+		Store:             store,
+		PollInterval:      viper.GetDuration(config.CONFIG_GATEWAY_AGGREGATOR_POLL_INTERVAL),
+		MaxBlockScanRange: viper.GetUint64(config.CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE),
+		Polls:             polls,
+		Dialer:            chainsync.DialerFromConfig(),
+	}), nil
 }
 
 func (ga *GatewayAggregator) Run(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"gateway-registry": ga.contractAddress,
 	}).Info("aggregating gateway events")
 
@@ -57,14 +127,20 @@ func (ga *GatewayAggregator) Run(ctx context.Context) error {
 	for {
 		select {
 		case <-time.After(pollInterval):
+			if err := ga.retryMissingLocalIDs(ctx); err != nil {
+				ga.log.WithError(err).Warn("unable to retry storing missing local ids")
+			}
 			for {
+				start := time.Now()
 				synced, err := ga.aggregate(ctx)
+				metrics.ObserveAggregation(ctx, start, err)
 				if err != nil {
-					logrus.WithError(err).Warn("unable to aggregate gateway events")
+					ga.log.WithError(err).Warn("unable to aggregate gateway events")
 					break
 				}
 				if synced {
-					pollInterval = viper.GetDuration(config.CONFIG_GATEWAY_AGGREGATOR_POLL_INTERVAL)
+					ga.polls.Succeeded(ctx)
+					pollInterval = ga.pollInterval
 					break
 				}
 			}
@@ -74,7 +150,11 @@ func (ga *GatewayAggregator) Run(ctx context.Context) error {
 	}
 }
 
-func (ga *GatewayAggregator) aggregate(ctx context.Context) (bool, error) {
+func (ga *GatewayAggregator) aggregate(ctx context.Context) (synced bool, err error) {
+	ctx, span := tracing.Start(ctx, "aggregator.Aggregate",
+		attribute.String("registry.contract", ga.contractAddress.Hex()))
+	defer func() { tracing.End(span, err) }()
+
 	from, err := ga.aggregateFrom(ctx)
 	if err != nil {
 		return false, err
@@ -89,7 +169,7 @@ func (ga *GatewayAggregator) aggregate(ctx context.Context) (bool, error) {
 		return synced, nil
 	}
 
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"from":     from,
 		"to":       to,
 		"contract": ga.contractAddress,
@@ -99,6 +179,10 @@ func (ga *GatewayAggregator) aggregate(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	span.SetAttributes(
+		attribute.Int64("block.from", int64(from)),
+		attribute.Int64("block.to", int64(to)),
+		attribute.Int("events", len(events)))
 
 	for _, event := range events {
 		err := ga.processEvent(ctx, event)
@@ -108,6 +192,7 @@ func (ga *GatewayAggregator) aggregate(ctx context.Context) (bool, error) {
 	}
 
 	ga.store.StoreCurrentBlock(ctx, "GatewayAggregator", to)
+	metrics.SyncedBlock(ctx, to)
 
 	return synced, nil
 
@@ -155,7 +240,7 @@ func (ga *GatewayAggregator) aggregateTo(ctx context.Context, from uint64) (uint
 	}
 
 	if iblock == 0 && gblock == 0 || from == 0 {
-		logrus.Infof("no gateway-events found, waiting for first events")
+		ga.log.Infof("no gateway-events found, waiting for first events")
 		return 0, true, nil
 	}
 
@@ -163,8 +248,8 @@ func (ga *GatewayAggregator) aggregateTo(ctx context.Context, from uint64) (uint
 		return 0, false, fmt.Errorf("GatewayIntegrator (%d) is behind on GatewayAggregator (%d), this should not happen", iblock, gblock)
 	} else if iblock == gblock {
 		return gblock, true, nil
-	} else if iblock-from > viper.GetUint64(config.CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE) {
-		return from + viper.GetUint64(config.CONFIG_GATEWAY_AGGREGATOR_MAX_BLOCK_SCAN_RANGE), false, nil
+	} else if iblock-from > ga.maxBlockScanRange {
+		return from + ga.maxBlockScanRange, false, nil
 	} else {
 		return iblock, true, nil
 	}
@@ -172,7 +257,7 @@ func (ga *GatewayAggregator) aggregateTo(ctx context.Context, from uint64) (uint
 }
 
 func (ga *GatewayAggregator) processEvent(ctx context.Context, event *types.GatewayEvent) error {
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"contract": event.ContractAddress,
 		"gateway":  event.ID,
 		"type":     event.Type,
@@ -196,6 +281,18 @@ func (ga *GatewayAggregator) processEvent(ctx context.Context, event *types.Gate
 		}
 		gatewayHistory.Owner = event.NewOwner
 		gatewayHistory.Version = event.Version
+
+		err := ga.storeLocalID(ctx, event)
+		if errors.Is(err, errTransactionUnavailable) {
+			ga.log.WithError(err).WithFields(logrus.Fields{
+				"gateway":     event.ID,
+				"transaction": event.Transaction,
+			}).Warn("unable to get onboard transaction, retrying to store local id later")
+			err = ga.store.StoreMissingLocalID(ctx, event)
+		}
+		if err != nil {
+			return err
+		}
 	case types.GatewayTransferredEvent:
 		gatewayHistory.Owner = event.NewOwner
 	case types.GatewayUpdatedEvent:
@@ -235,3 +332,111 @@ func (ga *GatewayAggregator) processEvent(ctx context.Context, event *types.Gate
 
 	return nil
 }
+
+// storeLocalID maps the local id of the onboard message of the gateway that
+// is onboarded by event to the gateway, the gateway can be found by its EUI
+// after the onboard message is purged. The local id isn't signed, it is only
+// taken from the onboard message with the signature that the onboard
+// transaction carries, and a local id that maps to another gateway is kept.
+// It returns errTransactionUnavailable when the transaction can't be looked
+// up.
+func (ga *GatewayAggregator) storeLocalID(ctx context.Context, event *types.GatewayEvent) error {
+	if event.NewOwner == nil {
+		return nil
+	}
+
+	onboards, err := ga.store.GetGatewayOnboardsForGateway(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	if len(onboards) == 0 {
+		return nil
+	}
+
+	input, err := ga.transactionInput(ctx, event.Transaction)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errTransactionUnavailable, err)
+	}
+
+	var onboard *models.GatewayOnboard
+	for _, o := range onboards {
+		signature := common.FromHex(o.Signature)
+		if o.Owner == utils.AddressToString(*event.NewOwner) && len(signature) > 0 && bytes.Contains(input, signature) {
+			onboard = o
+			break
+		}
+	}
+	if onboard == nil {
+		ga.log.WithField("gateway", event.ID).Info("no onboard message with the signature of the onboard transaction, gateway can't be found by its local id")
+		return nil
+	}
+	if onboard.LocalID == "" {
+		return nil
+	}
+
+	localID, err := utils.ParseEUI(onboard.LocalID)
+	if err != nil {
+		ga.log.WithError(err).WithField("gateway", event.ID).Warn("local id of onboard message isn't an EUI, gateway can't be found by it")
+		return nil
+	}
+
+	err = ga.store.StoreLocalID(ctx, localID, event.ID, common.HexToAddress(onboard.Onboarder), event.Time)
+	if errors.Is(err, models.ErrLocalIDInUse) {
+		ga.log.WithFields(logrus.Fields{
+			"gateway": event.ID,
+			"localId": localID,
+		}).Warn("local id is in use by another gateway, gateway can't be found by it")
+		return nil
+	}
+	return err
+}
+
+// retryMissingLocalIDs stores the local ids of the gateways of which the
+// onboard transaction couldn't be looked up when they were aggregated. It
+// stops at the first transaction that still can't be looked up.
+func (ga *GatewayAggregator) retryMissingLocalIDs(ctx context.Context) error {
+	events, err := ga.store.MissingLocalIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		err := ga.storeLocalID(ctx, event)
+		if errors.Is(err, errTransactionUnavailable) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := ga.store.DeleteMissingLocalID(ctx, event.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transactionInput returns the input data of the transaction. The client is
+// kept for the next transactions until a call fails.
+func (ga *GatewayAggregator) transactionInput(ctx context.Context, hash common.Hash) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, txLookupTimeout)
+	defer cancel()
+
+	if ga.client == nil {
+		client, err := ga.dialer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ga.client = client
+	}
+
+	tx, _, err := ga.client.TransactionByHash(ctx, hash)
+	if err != nil {
+		ga.client.Close()
+		ga.client = nil
+		return nil, err
+	}
+
+	return tx.Data(), nil
+}
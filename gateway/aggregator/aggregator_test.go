@@ -0,0 +1,194 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// gatewayStore is embedded under another name, store.Store has a Store method.
+type gatewayStore = store.Store
+
+// localIDStore holds the onboard messages of gateways and records the local
+// ids and the gateways that are stored, the other store methods aren't
+// implemented.
+type localIDStore struct {
+	gatewayStore
+	onboards []*models.GatewayOnboard
+	stored   []string
+	missing  map[types.ID]*types.GatewayEvent
+	gateways map[types.ID]*types.Gateway
+}
+
+func (s *localIDStore) GetGatewayOnboardsForGateway(ctx context.Context, gatewayID types.ID) ([]*models.GatewayOnboard, error) {
+	return s.onboards, nil
+}
+
+func (s *localIDStore) StoreLocalID(ctx context.Context, localID string, gatewayID types.ID, onboarder common.Address, at time.Time) error {
+	s.stored = append(s.stored, localID)
+	return nil
+}
+
+func (s *localIDStore) StoreMissingLocalID(ctx context.Context, event *types.GatewayEvent) error {
+	s.missing[event.ID] = event
+	return nil
+}
+
+func (s *localIDStore) MissingLocalIDs(ctx context.Context) ([]*types.GatewayEvent, error) {
+	var events []*types.GatewayEvent
+	for _, event := range s.missing {
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *localIDStore) DeleteMissingLocalID(ctx context.Context, gatewayID types.ID) error {
+	delete(s.missing, gatewayID)
+	return nil
+}
+
+func (s *localIDStore) GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.GatewayHistory, error) {
+	return nil, nil
+}
+
+func (s *localIDStore) StoreHistory(ctx context.Context, history *types.GatewayHistory) error {
+	return nil
+}
+
+func (s *localIDStore) Store(ctx context.Context, gateway *types.Gateway) error {
+	s.gateways[gateway.ID] = gateway
+	return nil
+}
+
+// transactionServer serves the transaction over JSON-RPC for every
+// eth_getTransactionByHash call.
+func transactionServer(t *testing.T, tx *gethtypes.Transaction) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  tx,
+		})
+	}))
+}
+
+func TestMissingLocalIDIsRetried(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		owner     = common.HexToAddress("0x782cF1a1b8F10e7Ea1B83BcE8C4B7b4bd5C1cE9B")
+		signature = common.FromHex("0x01020304")
+		gatewayID = types.ID(common.HexToHash("0x8f1b4a5c9d2e3f40112233445566778899aabbccddeeff00112233445566aa01"))
+		st        = &localIDStore{
+			onboards: []*models.GatewayOnboard{{
+				Owner:     utils.AddressToString(owner),
+				Signature: "0x01020304",
+				LocalID:   "0016c001ff10a235",
+				Onboarder: "0x8E7a2E1E3bF6cD5D1d7C0E2A4f1B4cA2d9E1F001",
+			}},
+			missing:  make(map[types.ID]*types.GatewayEvent),
+			gateways: make(map[types.ID]*types.Gateway),
+		}
+		available bool
+	)
+
+	// the onboard transaction carries the signature of the onboard message
+	tx, err := gethtypes.SignTx(gethtypes.NewTransaction(0, common.Address{}, nil, 100000, nil, append([]byte{0xaa, 0xbb}, signature...)), gethtypes.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := transactionServer(t, tx)
+	defer srv.Close()
+
+	ga := New(Options{
+		Store: st,
+		Dialer: func(ctx context.Context) (*ethclient.Client, error) {
+			if !available {
+				return nil, errors.New("rpc node unavailable")
+			}
+			return ethclient.DialContext(ctx, srv.URL)
+		},
+	})
+	event := &types.GatewayEvent{
+		Type:        types.GatewayOnboardedEvent,
+		ID:          gatewayID,
+		NewOwner:    &owner,
+		Transaction: tx.Hash(),
+		Time:        time.Now(),
+	}
+
+	// the gateway is aggregated without its local id when the transaction
+	// can't be looked up
+	if err := ga.processEvent(context.Background(), event); err != nil {
+		t.Fatalf("processEvent returned %v, want nil", err)
+	}
+	if st.gateways[gatewayID] == nil {
+		t.Errorf("gateway not stored")
+	}
+	if len(st.stored) != 0 {
+		t.Errorf("stored local ids %v, want none", st.stored)
+	}
+	if st.missing[gatewayID] == nil {
+		t.Fatalf("missing local id not recorded")
+	}
+
+	// a retry while the transaction still can't be looked up keeps it
+	if err := ga.retryMissingLocalIDs(context.Background()); err != nil {
+		t.Fatalf("retryMissingLocalIDs returned %v, want nil", err)
+	}
+	if len(st.stored) != 0 || st.missing[gatewayID] == nil {
+		t.Errorf("stored local ids %v and missing %v, want none stored and still missing", st.stored, st.missing)
+	}
+
+	available = true
+	if err := ga.retryMissingLocalIDs(context.Background()); err != nil {
+		t.Fatalf("retryMissingLocalIDs returned %v, want nil", err)
+	}
+	if len(st.stored) != 1 || st.stored[0] != "0016c001ff10a235" {
+		t.Errorf("stored local ids %v, want [0016c001ff10a235]", st.stored)
+	}
+	if len(st.missing) != 0 {
+		t.Errorf("missing local ids %v, want none", st.missing)
+	}
+}
@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_GATEWAY_AGGREGATOR_ENABLED) {
-		ga, err := NewGatewayAggregator()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating gateway aggregator")
-			return err
-		}
+		// Only the replica holding the lease runs the gateway aggregator, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "GatewayAggregator", config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT), func(ctx context.Context) error {
+			ga, err := NewGatewayAggregator()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating gateway aggregator")
+				return err
+			}
 
-		err = ga.Run(ctx)
-		if err != nil {
-			return err
-		}
+			return ga.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
@@ -18,34 +18,93 @@ package api
 
 import (
 	"context"
+	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/cacher"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/store"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
+// Options configure a GatewayAPI.
+type Options struct {
+	Store store.Store
+	// Contract is the address of the gateway registry.
+	Contract common.Address
+	// Confirmations is the number of blocks after which a pending event is
+	// confirmed.
+	Confirmations uint64
+	// Elector runs the onboard purger under a lease, when nil the purger
+	// always runs.
+	Elector *leader.Elector
+	// Cache serves batch lookups when set.
+	Cache *cacher.GatewayCacheClient
+}
+
 type GatewayAPI struct {
-	store store.Store
+	store         store.Store
+	contract      common.Address
+	confirmations uint64
+	elector       *leader.Elector
+	cache         *cacher.GatewayCacheClient
+}
+
+// New creates a GatewayAPI with the given options.
+func New(opts Options) *GatewayAPI {
+	return &GatewayAPI{
+		store:         opts.Store,
+		contract:      opts.Contract,
+		confirmations: opts.Confirmations,
+		elector:       opts.Elector,
+		cache:         opts.Cache,
+	}
 }
 
+// NewGatewayAPI creates a GatewayAPI from the config.
 func NewGatewayAPI() (*GatewayAPI, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	return &GatewayAPI{
-		store: store,
-	}, nil
+	opts := Options{
+		Store:         store,
+		Contract:      config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT),
+		Confirmations: viper.GetUint64(config.CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS),
+	}
+
+	if viper.GetBool(config.CONFIG_GATEWAY_CACHER_ENABLED) {
+		redis := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{viper.GetString(config.CONFIG_GATEWAY_CACHER_REDIS_HOST)}})
+		cache, err := cacher.NewGatewayCacheClient(redis)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cache = cache
+	}
+
+	return New(opts), nil
 }
 
 func (gapi *GatewayAPI) Bind(root *chi.Mux) error {
 	root.Route("/gateways", func(r chi.Router) {
 		r.Route("/v1", func(r chi.Router) {
 			r.Get("/owned/{owner:(?i)(0x)?[0-9a-f]{40}}", gapi.OwnedGateways)
+			r.Get("/search", gapi.SearchGateways)
+			r.Get("/nearest", gapi.NearestGateways)
+			r.Post("/batch", gapi.GatewaysBatch)
+			r.Get("/radius", gapi.GatewaysInRadius)
+			r.Get("/local/{eui}", gapi.GatewayByLocalID)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}", gapi.GatewayDetailsByID)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}/list", gapi.GatewayListByID)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}/events", gapi.GatewayEventsByID)
@@ -66,7 +125,29 @@ func (gapi *GatewayAPI) Bind(root *chi.Mux) error {
 	return nil
 }
 
-func (gapi *GatewayAPI) Run(ctx context.Context) {
+// Run periodically purges expired gateway onboards while this replica holds
+// the onboard purger lease.
+func (gapi *GatewayAPI) Run(ctx context.Context) error {
+	return gapi.elector.Run(ctx, "GatewayOnboardPurger", gapi.contract, gapi.purgeExpiredOnboards)
+}
+
+// RunOnboardPurger runs the purger for expired gateway onboards under the
+// lease of the elector.
+func RunOnboardPurger(ctx context.Context, elector *leader.Elector) error {
+	gapi, err := NewGatewayAPI()
+	if err != nil {
+		logrus.WithError(err).Error("error while creating gateway onboard purger")
+		return err
+	}
+
+	// only the purger needs the lease, API replicas that just serve requests
+	// don't use it
+	gapi.elector = elector
+
+	return gapi.Run(ctx)
+}
+
+func (gapi *GatewayAPI) purgeExpiredOnboards(ctx context.Context) error {
 	for {
 		if err := gapi.store.PurgeExpiredOnboards(ctx, 7*24*time.Hour); err != nil {
 			logrus.WithError(err).Error("unable to purge expired gateway onboard")
@@ -75,15 +156,31 @@ func (gapi *GatewayAPI) Run(ctx context.Context) {
 		case <-time.After(5 * time.Minute):
 			continue
 		case <-ctx.Done():
-			return
+			return ctx.Err()
 		}
 	}
 }
 
+// notModified derives the ETag for the request from the block the given
+// process synced to. It returns true when the handler is done because the
+// client copy is still current or the block could not be determined.
+func (gapi *GatewayAPI) notModified(ctx context.Context, w http.ResponseWriter, r *http.Request, process string, cacheControl string) (utils.ETag, bool) {
+	currentBlock, err := gapi.store.CurrentBlock(ctx, process)
+	if err != nil {
+		logging.WithContext(r.Context()).WithError(err).Error("error while getting sync state")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return "", true
+	}
+
+	etag := utils.NewETag(r, currentBlock)
+	return etag, etag.NotModified(w, r, cacheControl)
+}
+
 var (
 	emptyGatewaysSlice        = make([]*types.Gateway, 0)
 	emptyGatewayEventsSlice   = make([]*types.GatewayEvent, 0)
 	emptyGatewayOnboardsSlice = make([]*models.GatewayOnboard, 0)
+	emptyNearbyGatewaysSlice  = make([]*apitypes.NearbyGateway, 0)
 )
 
 func gatewaysOrEmptySlice(gateways []*types.Gateway) []*types.Gateway {
@@ -106,3 +203,10 @@ func onboardsOrEmptySlice(onboards []*models.GatewayOnboard) []*models.GatewayOn
 	}
 	return onboards
 }
+
+func nearbyGatewaysOrEmptySlice(gateways []*apitypes.NearbyGateway) []*apitypes.NearbyGateway {
+	if gateways == nil {
+		return emptyNearbyGatewaysSlice
+	}
+	return gateways
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// gatewayHexFeatures is a GatewayHex that can be replied as GeoJSON.
+type gatewayHexFeatures apitypes.GatewayHex
+
+// res0GatewayHexFeatures is a Res0GatewayHex that can be replied as GeoJSON.
+type res0GatewayHexFeatures apitypes.Res0GatewayHex
+
+// GeoJSON returns the gateways as points and the cells that only have a
+// gateway count as polygons.
+func (gh *gatewayHexFeatures) GeoJSON() *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	addGatewayHexFeatures(fc, (*apitypes.GatewayHex)(gh), nil)
+	return fc
+}
+
+// GeoJSON returns the cells with their gateway count as polygons.
+func (gh *res0GatewayHexFeatures) GeoJSON() *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	for res0, hexes := range gh.Hexes {
+		hexes := hexes
+		addGatewayHexFeatures(fc, &hexes, map[string]interface{}{"res0": res0})
+	}
+	return fc
+}
+
+func addGatewayHexFeatures(fc *geo.FeatureCollection, gh *apitypes.GatewayHex, extra map[string]interface{}) {
+	for hex, info := range gh.Hexes {
+		if len(info.Gateways) == 0 {
+			cell, err := h3light.CellFromString(hex)
+			if err != nil {
+				continue
+			}
+			properties := map[string]interface{}{"count": info.Count}
+			for k, v := range extra {
+				properties[k] = v
+			}
+			fc.AddCell(cell, properties)
+			continue
+		}
+
+		for i := range info.Gateways {
+			addGatewayFeature(fc, &info.Gateways[i])
+		}
+	}
+}
+
+func addGatewayFeature(fc *geo.FeatureCollection, gw *types.Gateway) {
+	if gw.Location == nil {
+		return
+	}
+
+	properties := map[string]interface{}{
+		"owner":   gw.Owner,
+		"version": gw.Version,
+	}
+	if gw.FrequencyPlan != nil {
+		properties["frequencyPlan"] = *gw.FrequencyPlan
+	}
+	if gw.AntennaGain != nil {
+		properties["antennaGain"] = *gw.AntennaGain
+	}
+	if gw.Altitude != nil {
+		properties["altitude"] = *gw.Altitude
+	}
+
+	lat, lon := gw.Location.LatLon()
+	fc.AddPoint(gw.ID.String(), lat, lon, *gw.Location, properties)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// GatewaysBatch replies the gateways with the ids in the request, the ids of
+// gateways that don't exist are listed as missing. At most utils.MaxBatchSize
+// ids are looked up at once.
+//
+// The gateways are looked up in the cache when it's enabled, gateways that
+// aren't cached yet are looked up in the store.
+func (gapi *GatewayAPI) GatewaysBatch(w http.ResponseWriter, r *http.Request) {
+	utils.ReplyBatch(w, r, "gateways", gapi.getMulti, func(found []*types.Gateway, missing []types.ID) interface{} {
+		return &apitypes.GatewaysBatchResponse{Gateways: found, Missing: missing}
+	})
+}
+
+// getMulti returns the gateways with the ids in the same order, gateways that
+// don't exist are nil.
+func (gapi *GatewayAPI) getMulti(ctx context.Context, ids []types.ID) ([]*types.Gateway, error) {
+	var cache utils.GetMultiFunc[types.Gateway]
+	if gapi.cache != nil {
+		cache = gapi.cache.GetMulti
+	}
+	return utils.GetMultiCached(ctx, ids, cache, gapi.store.GetMulti)
+}
@@ -19,12 +19,12 @@ package api
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
@@ -37,16 +37,10 @@ func replyEventsCursor(events []*types.GatewayEvent, cursor string, pageSize int
 		events = events[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor": cursor,
-			"events": gatewayEventsOrEmptySlice(events),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"events": gatewayEventsOrEmptySlice(events),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.GatewayEventsResponse{
+		Cursor: cursor,
+		Events: gatewayEventsOrEmptySlice(events),
+	})
 }
 
 func replyGatewaysCursor(gateways []*types.Gateway, cursor string, pageSize int, w http.ResponseWriter, r *http.Request) {
@@ -56,16 +50,10 @@ func replyGatewaysCursor(gateways []*types.Gateway, cursor string, pageSize int,
 		gateways = gateways[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor":   cursor,
-			"gateways": gatewaysOrEmptySlice(gateways),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"gateways": gatewaysOrEmptySlice(gateways),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.GatewaysResponse{
+		Cursor:   cursor,
+		Gateways: gatewaysOrEmptySlice(gateways),
+	})
 }
 
 func (gapi *GatewayAPI) OwnedGateways(w http.ResponseWriter, r *http.Request) {
@@ -73,13 +61,14 @@ func (gapi *GatewayAPI) OwnedGateways(w http.ResponseWriter, r *http.Request) {
 		log         = logging.WithContext(r.Context())
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
 	}
 
 	gateways, cursor, err := gapi.store.GetByOwner(ctx, owner, pageSize, cursor)
@@ -89,6 +78,7 @@ func (gapi *GatewayAPI) OwnedGateways(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	replyGatewaysCursor(gateways, cursor, pageSize, w, r)
 }
 
@@ -100,6 +90,11 @@ func (gapi *GatewayAPI) GatewayDetailsByID(w http.ResponseWriter, r *http.Reques
 	)
 	defer cancel()
 
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
 	gateway, err := gapi.store.Get(ctx, gatewayID)
 	if err != nil {
 		log.WithError(err).Error("error while getting gateway details")
@@ -112,6 +107,43 @@ func (gapi *GatewayAPI) GatewayDetailsByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, gateway)
+}
+
+// GatewayByLocalID returns the gateway with the local id, the EUI of the
+// gateway, from its onboard message.
+func (gapi *GatewayAPI) GatewayByLocalID(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+	)
+	defer cancel()
+
+	localID, err := utils.ParseEUI(chi.URLParam(r, "eui"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
+	gateway, err := gapi.store.GetByLocalID(ctx, localID)
+	if err != nil {
+		log.WithError(err).WithField("eui", localID).Error("error while getting gateway by local id")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if gateway == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, utils.Revalidate)
 	encoding.ReplyJSON(w, r, http.StatusOK, gateway)
 }
 
@@ -123,6 +155,11 @@ func (gapi *GatewayAPI) GatewayListByID(w http.ResponseWriter, r *http.Request)
 	)
 	defer cancel()
 
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
 	gateway, err := gapi.store.Get(ctx, gatewayID)
 	if err != nil {
 		log.WithError(err).Error("error while getting gateway details")
@@ -130,6 +167,7 @@ func (gapi *GatewayAPI) GatewayListByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	if gateway == nil {
 		replyGatewaysCursor([]*types.Gateway{}, "", 1, w, r)
 		return
@@ -143,13 +181,14 @@ func (gapi *GatewayAPI) GatewayEventsByID(w http.ResponseWriter, r *http.Request
 		log         = logging.WithContext(r.Context())
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		gatewayID   = utils.IDFromRequest(r, "id")
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
+	etag, done := gapi.notModified(ctx, w, r, "GatewayIngestor", utils.Revalidate)
+	if done {
+		return
 	}
 
 	events, cursor, err := gapi.store.GetEvents(ctx, gatewayID, pageSize, cursor)
@@ -159,5 +198,6 @@ func (gapi *GatewayAPI) GatewayEventsByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	replyEventsCursor(events, cursor, pageSize, w, r)
 }
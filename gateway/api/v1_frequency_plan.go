@@ -19,10 +19,12 @@ package api
 import (
 	"net/http"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
 	h3light "github.com/ThingsIXFoundation/h3-light"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/go-chi/chi/v5"
 )
@@ -43,7 +45,13 @@ func init() {
 }
 
 func (gapi *GatewayAPI) SupportedFrequencyPlans(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Cache-Control", "public, max-age=300")
+	// frequency plans only change with a new release
+	etag := utils.NewETag(r)
+	if etag.NotModified(w, r, "public, max-age=300") {
+		return
+	}
+
+	etag.Set(w, "public, max-age=300")
 	encoding.ReplyJSON(w, r, http.StatusOK, supportedFrequencyPlans)
 }
 
@@ -66,7 +74,12 @@ func (gapi *GatewayAPI) FrequencyPlansAtLocation(w http.ResponseWriter, r *http.
 		return
 	}
 
-	resp := &ValidFrequencyPlansForLocation{}
+	etag := utils.NewETag(r)
+	if etag.NotModified(w, r, "public, max-age=900") {
+		return
+	}
+
+	resp := &apitypes.ValidFrequencyPlansForLocation{}
 
 	for _, band := range frequency_plan.AllBands {
 		if valid := frequency_plan.IsValidBandForHex(band, cell); valid {
@@ -75,6 +88,6 @@ func (gapi *GatewayAPI) FrequencyPlansAtLocation(w http.ResponseWriter, r *http.
 		}
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age=900")
+	etag.Set(w, "public, max-age=900")
 	encoding.ReplyJSON(w, r, http.StatusOK, resp)
 }
@@ -21,11 +21,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
 	h3light "github.com/ThingsIXFoundation/h3-light"
 	"github.com/sirupsen/logrus"
 
-	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -33,6 +34,11 @@ const MAP_MAX_RES = 7
 const MAP_DETAIL_RES = 7
 const MAP_OFFSET_RES = 3
 
+const (
+	res0MapCacheControl = "public, max-age=60"
+	mapCacheControl     = "public, max-age=30"
+)
+
 func (gapi *GatewayAPI) GatewayMapRes0(w http.ResponseWriter, r *http.Request) {
 	var (
 		//log         = logging.WithContext(r.Context())
@@ -40,6 +46,11 @@ func (gapi *GatewayAPI) GatewayMapRes0(w http.ResponseWriter, r *http.Request) {
 	)
 	defer cancel()
 
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", res0MapCacheControl)
+	if done {
+		return
+	}
+
 	counts, err := gapi.store.GetRes3CountPerRes0(ctx)
 	if err != nil {
 		logrus.WithError(err).Error("error while getting res3 counts per res0")
@@ -47,25 +58,25 @@ func (gapi *GatewayAPI) GatewayMapRes0(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ret := Res0GatewayHex{
-		Hexes: make(map[string]GatewayHex),
+	ret := apitypes.Res0GatewayHex{
+		Hexes: make(map[string]apitypes.GatewayHex),
 	}
 	for res0, res0_counts := range counts {
 		if len(res0_counts) == 0 {
 			continue
 		}
-		ret.Hexes[res0.String()] = GatewayHex{
-			Hexes: make(map[string]GatewayHexInfo),
+		ret.Hexes[res0.String()] = apitypes.GatewayHex{
+			Hexes: make(map[string]apitypes.GatewayHexInfo),
 		}
 		for res3, res3_counts := range res0_counts {
-			ret.Hexes[res0.String()].Hexes[res3.String()] = GatewayHexInfo{
+			ret.Hexes[res0.String()].Hexes[res3.String()] = apitypes.GatewayHexInfo{
 				Count: int(res3_counts),
 			}
 		}
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age=60")
-	encoding.ReplyJSON(w, r, http.StatusOK, &ret)
+	etag.Set(w, res0MapCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*res0GatewayHexFeatures)(&ret))
 }
 
 func (gapi *GatewayAPI) GatewayMap(w http.ResponseWriter, r *http.Request) {
@@ -90,8 +101,13 @@ func (gapi *GatewayAPI) GatewayMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gh := GatewayHex{
-		Hexes: make(map[string]GatewayHexInfo),
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", mapCacheControl)
+	if done {
+		return
+	}
+
+	gh := apitypes.GatewayHex{
+		Hexes: make(map[string]apitypes.GatewayHexInfo),
 	}
 
 	if res < MAP_DETAIL_RES {
@@ -107,7 +123,7 @@ func (gapi *GatewayAPI) GatewayMap(w http.ResponseWriter, r *http.Request) {
 		}
 
 		for cell, count := range counts {
-			gh.Hexes[cell.String()] = GatewayHexInfo{Count: int(count)}
+			gh.Hexes[cell.String()] = apitypes.GatewayHexInfo{Count: int(count)}
 		}
 	} else {
 		gateways, err := gapi.store.GetInCell(ctx, h3light.Cell(hexCell))
@@ -131,6 +147,6 @@ func (gapi *GatewayAPI) GatewayMap(w http.ResponseWriter, r *http.Request) {
 
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age=30")
-	encoding.ReplyJSON(w, r, http.StatusOK, &gh)
+	etag.Set(w, mapCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*gatewayHexFeatures)(&gh))
 }
@@ -0,0 +1,275 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ThingsIXFoundation/types"
+)
+
+const (
+	// DefaultNearestCount is the number of gateways returned by the nearest
+	// endpoint when the request doesn't set k.
+	DefaultNearestCount = 10
+	// MaxNearestCount is the largest number of gateways the nearest endpoint
+	// returns.
+	MaxNearestCount = 100
+	// DefaultNearestRadius is the distance in meters the nearest endpoint
+	// searches within when the request doesn't set maxRadius.
+	DefaultNearestRadius = 50_000
+	// MaxNearbyRadius is the largest distance in meters the nearest and radius
+	// endpoints search within.
+	MaxNearbyRadius = 200_000
+
+	// nearbyMaxRings is the number of rings around the cell of the location
+	// that are searched, the resolution of the cells is chosen such that
+	// these rings cover the radius.
+	nearbyMaxRings = 3
+	// nearestInitialRadius is the radius in meters of the first search of
+	// the nearest endpoint, it's quadrupled until enough gateways are found.
+	nearestInitialRadius = 2_000
+	// nearestMaxSearches bounds the number of times the nearest endpoint
+	// widens the radius, it reaches MaxNearbyRadius well before that.
+	nearestMaxSearches = 8
+)
+
+func (gapi *GatewayAPI) NearestGateways(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		query       = r.URL.Query()
+	)
+	defer cancel()
+
+	lat, lon, plan, err := nearbyFromQuery(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	k, err := intFromQuery(query, "k", DefaultNearestCount, 1, MaxNearestCount)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxRadius, err := radiusFromQuery(query, "maxRadius", DefaultNearestRadius)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
+	// all gateways nearer than the k-th gateway found within a radius are
+	// within that radius too, widen the radius until k gateways are found
+	var gateways []*apitypes.NearbyGateway
+	for i, radius := 0, float64(nearestInitialRadius); i < nearestMaxSearches; i, radius = i+1, radius*4 {
+		if radius > maxRadius {
+			radius = maxRadius
+		}
+
+		gateways, err = gapi.gatewaysWithin(ctx, lat, lon, radius, plan)
+		if err != nil {
+			log.WithError(err).Error("error while getting nearest gateways")
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		if len(gateways) >= k || radius >= maxRadius {
+			break
+		}
+	}
+
+	if len(gateways) > k {
+		gateways = gateways[:k]
+	}
+
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.NearbyGatewaysResponse{
+		Gateways: nearbyGatewaysOrEmptySlice(gateways),
+	})
+}
+
+func (gapi *GatewayAPI) GatewaysInRadius(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		query       = r.URL.Query()
+	)
+	defer cancel()
+
+	lat, lon, plan, err := nearbyFromQuery(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if query.Get("radius") == "" {
+		http.Error(w, "missing radius", http.StatusBadRequest)
+		return
+	}
+	radius, err := radiusFromQuery(query, "radius", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
+	gateways, err := gapi.gatewaysWithin(ctx, lat, lon, radius, plan)
+	if err != nil {
+		log.WithError(err).Error("error while getting gateways in radius")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.NearbyGatewaysResponse{
+		Gateways: nearbyGatewaysOrEmptySlice(gateways),
+	})
+}
+
+// gatewaysWithin returns the gateways within radius meters from the location
+// nearest first. When plan is set only gateways with that frequency plan are
+// returned. The gateways are looked up in the rings of cells around the cell
+// of the location, the distance is measured to the centre of the cell the
+// gateway is located in.
+func (gapi *GatewayAPI) gatewaysWithin(ctx context.Context, lat, lon, radius float64, plan *frequency_plan.BandName) ([]*apitypes.NearbyGateway, error) {
+	var (
+		res   = geo.ResolutionForRadius(radius, nearbyMaxRings)
+		cells = geo.Disk(h3light.LatLonToCell(lat, lon, res), geo.RingsForRadius(radius, res))
+		found = make([][]*types.Gateway, len(cells))
+		errs  = make([]error, len(cells))
+		wg    sync.WaitGroup
+	)
+
+	for i, cell := range cells {
+		wg.Add(1)
+		go func(i int, cell h3light.Cell) {
+			defer wg.Done()
+			found[i], errs[i] = gapi.store.GetInCell(ctx, cell)
+		}(i, cell)
+	}
+	wg.Wait()
+
+	var nearby []*apitypes.NearbyGateway
+	for i := range cells {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		for _, gateway := range found[i] {
+			if gateway.Location == nil {
+				continue
+			}
+			if plan != nil && (gateway.FrequencyPlan == nil || *gateway.FrequencyPlan != *plan) {
+				continue
+			}
+
+			gwLat, gwLon := gateway.Location.LatLon()
+			distance := geo.Distance(lat, lon, gwLat, gwLon)
+			if distance <= radius {
+				nearby = append(nearby, &apitypes.NearbyGateway{Gateway: gateway, Distance: distance})
+			}
+		}
+	}
+
+	sort.Slice(nearby, func(i, j int) bool {
+		return nearby[i].Distance < nearby[j].Distance
+	})
+
+	return nearby, nil
+}
+
+// nearbyFromQuery parses the location, given as lat and lon or as the centre
+// of an h3 cell, and the optional frequency plan from the query parameters.
+func nearbyFromQuery(query url.Values) (float64, float64, *frequency_plan.BandName, error) {
+	var (
+		lat, lon float64
+		err      error
+	)
+
+	if hex := query.Get("cell"); hex != "" {
+		cell, err := h3light.CellFromString(hex)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid cell: %s", hex)
+		}
+		lat, lon = cell.LatLon()
+	} else {
+		if lat, err = strconv.ParseFloat(query.Get("lat"), 64); err != nil || !(lat >= -90 && lat <= 90) {
+			return 0, 0, nil, fmt.Errorf("invalid or missing lat: %s", query.Get("lat"))
+		}
+		if lon, err = strconv.ParseFloat(query.Get("lon"), 64); err != nil || !(lon >= -180 && lon <= 180) {
+			return 0, 0, nil, fmt.Errorf("invalid or missing lon: %s", query.Get("lon"))
+		}
+	}
+
+	plan, err := frequencyPlanFromQuery(query)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return lat, lon, plan, nil
+}
+
+// radiusFromQuery parses a radius in meters, bounded to MaxNearbyRadius. The
+// bounds are checked negated so NaN is rejected too.
+func radiusFromQuery(query url.Values, key string, def float64) (float64, error) {
+	val := query.Get(key)
+	if val == "" {
+		return def, nil
+	}
+	radius, err := strconv.ParseFloat(val, 64)
+	if err != nil || !(radius > 0 && radius <= MaxNearbyRadius) {
+		return 0, fmt.Errorf("invalid %s, it must be between 0 and %d meters: %s", key, MaxNearbyRadius, val)
+	}
+	return radius, nil
+}
+
+func intFromQuery(query url.Values, key string, def, min, max int) (int, error) {
+	val := query.Get(key)
+	if val == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(val)
+	if err != nil || i < min || i > max {
+		return 0, fmt.Errorf("invalid %s, it must be between %d and %d: %s", key, min, max, val)
+	}
+	return i, nil
+}
@@ -17,15 +17,16 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
-	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
 )
@@ -57,16 +58,12 @@ func (gapi *GatewayAPI) GatewayOnboardsByOwner(w http.ResponseWriter, r *http.Re
 		log         = logging.WithContext(r.Context())
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
 		onboarder   = common.HexToAddress(chi.URLParam(r, "onboarder"))
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
-	}
-
 	onboards, cursor, err := gapi.store.GetGatewayOnboardsByOwner(ctx, onboarder, owner, pageSize, cursor)
 	if err != nil {
 		log.WithError(err).WithField("owner", owner).Error("unable to retrieve gateway onboards")
@@ -80,16 +77,10 @@ func (gapi *GatewayAPI) GatewayOnboardsByOwner(w http.ResponseWriter, r *http.Re
 		onboards = onboards[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor":   cursor,
-			"onboards": onboardsOrEmptySlice(onboards),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"onboards": onboardsOrEmptySlice(onboards),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.GatewayOnboardsResponse{
+		Cursor:   cursor,
+		Onboards: onboardsOrEmptySlice(onboards),
+	})
 }
 
 func (gapi *GatewayAPI) CreateGatewayOnboard(w http.ResponseWriter, r *http.Request) {
@@ -98,12 +89,7 @@ func (gapi *GatewayAPI) CreateGatewayOnboard(w http.ResponseWriter, r *http.Requ
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		onboarder   = common.HexToAddress(chi.URLParam(r, "onboarder"))
 		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
-		req         = struct {
-			GatewayID types.ID `json:"gatewayId"`
-			Signature string   `json:"gatewayOnboardSignature"`
-			Version   uint8    `json:"version"`
-			LocalID   string   `json:"localId"`
-		}{}
+		req         apitypes.CreateGatewayOnboardRequest
 	)
 	defer cancel()
 
@@ -113,6 +99,21 @@ func (gapi *GatewayAPI) CreateGatewayOnboard(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// the local id isn't signed, it is bound to the signature it is first
+	// given with so a copied signature can't change it
+	onboards, err := gapi.store.GetGatewayOnboardsForGateway(ctx, req.GatewayID)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve gateway onboard messages")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	for _, onboard := range onboards {
+		if bytes.Equal(common.FromHex(onboard.Signature), common.FromHex(req.Signature)) && onboard.LocalID != req.LocalID {
+			http.Error(w, "signature is already given with another local id", http.StatusConflict)
+			return
+		}
+	}
+
 	if err := gapi.store.StoreGatewayOnboard(ctx, onboarder, req.GatewayID, owner, req.Signature, req.Version, req.LocalID); err != nil {
 		log.WithError(err).Error("unable to store gateway onboard message")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
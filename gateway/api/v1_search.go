@@ -0,0 +1,206 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func (gapi *GatewayAPI) SearchGateways(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		cursor      = r.URL.Query().Get("cursor")
+		pageSize    = utils.PageSizeFromRequest(r)
+	)
+	defer cancel()
+
+	search, err := searchFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	etag, done := gapi.notModified(ctx, w, r, "GatewayAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
+	gateways, cursor, err := gapi.store.Search(ctx, search, pageSize, cursor)
+	if errors.Is(err, models.ErrSearchTooBroad) {
+		http.Error(w, "search too broad, narrow the onboard time range", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("unable to search gateways in DB")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.GatewaysResponse{
+		Cursor:   cursor,
+		Gateways: gatewaysOrEmptySlice(gateways),
+	})
+}
+
+// searchFromQuery parses the search criteria from the query parameters.
+func searchFromQuery(query url.Values) (*models.GatewaySearch, error) {
+	var (
+		search models.GatewaySearch
+		err    error
+	)
+
+	if search.FrequencyPlan, err = frequencyPlanFromQuery(query); err != nil {
+		return nil, err
+	}
+	if search.MinAntennaGain, err = parseAntennaGain(query, "minAntennaGain"); err != nil {
+		return nil, err
+	}
+	if search.MaxAntennaGain, err = parseAntennaGain(query, "maxAntennaGain"); err != nil {
+		return nil, err
+	}
+	if search.MinAltitude, err = parseAltitude(query, "minAltitude"); err != nil {
+		return nil, err
+	}
+	if search.MaxAltitude, err = parseAltitude(query, "maxAltitude"); err != nil {
+		return nil, err
+	}
+
+	if version := query.Get("version"); version != "" {
+		v, err := strconv.ParseUint(version, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		search.Version = utils.Ptr(uint8(v))
+	}
+
+	for _, owner := range query["owner"] {
+		if !common.IsHexAddress(owner) {
+			return nil, fmt.Errorf("invalid owner: %s", owner)
+		}
+		search.Owners = append(search.Owners, common.HexToAddress(owner))
+	}
+
+	if region := query.Get("region"); region != "" {
+		cell, err := h3light.CellFromString(region)
+		if err != nil {
+			return nil, fmt.Errorf("invalid region: %s", region)
+		}
+		search.Region = &cell
+	}
+
+	if search.OnboardedFrom, err = parseOnboardTime(query, "onboardedFrom"); err != nil {
+		return nil, err
+	}
+	if search.OnboardedTo, err = parseOnboardTime(query, "onboardedTo"); err != nil {
+		return nil, err
+	}
+
+	if eui := query.Get("eui"); eui != "" {
+		localID, err := utils.ParseEUI(eui)
+		if err != nil {
+			return nil, err
+		}
+		search.LocalID = &localID
+	}
+
+	sort := query.Get("sort")
+	search.Descending = strings.HasPrefix(sort, "-")
+	switch sort := models.GatewaySort(strings.TrimPrefix(sort, "-")); sort {
+	case "":
+		search.Sort = models.GatewaySortID
+	case models.GatewaySortID, models.GatewaySortAntennaGain, models.GatewaySortAltitude, models.GatewaySortVersion:
+		search.Sort = sort
+	default:
+		return nil, fmt.Errorf("invalid sort: %s", sort)
+	}
+
+	return &search, nil
+}
+
+func frequencyPlanFromQuery(query url.Values) (*frequency_plan.BandName, error) {
+	val := query.Get("frequencyPlan")
+	if val == "" {
+		return nil, nil
+	}
+	var plan frequency_plan.BandName
+	if err := plan.UnmarshalText([]byte(val)); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// parseAntennaGain parses an antenna gain in dBi, NaN and infinite gains are
+// rejected.
+func parseAntennaGain(query url.Values, key string) (*float32, error) {
+	val := query.Get(key)
+	if val == "" {
+		return nil, nil
+	}
+	gain, err := strconv.ParseFloat(val, 32)
+	if err != nil || math.IsNaN(gain) || math.IsInf(gain, 0) {
+		return nil, fmt.Errorf("invalid %s: %s", key, val)
+	}
+	return utils.Ptr(float32(gain)), nil
+}
+
+func parseAltitude(query url.Values, key string) (*uint, error) {
+	val := query.Get(key)
+	if val == "" {
+		return nil, nil
+	}
+	altitude, err := strconv.ParseUint(val, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", key, val)
+	}
+	return utils.Ptr(uint(altitude)), nil
+}
+
+// parseOnboardTime accepts a date formatted as YYYY-MM-DD or an RFC 3339
+// timestamp.
+func parseOnboardTime(query url.Values, key string) (*time.Time, error) {
+	val := query.Get(key)
+	if val == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, val)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", key, val)
+		}
+	}
+	return &t, nil
+}
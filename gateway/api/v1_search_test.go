@@ -0,0 +1,45 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchRejectsNonFiniteAntennaGain(t *testing.T) {
+	gapi := &GatewayAPI{}
+
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{"min NaN", "minAntennaGain=NaN"},
+		{"min Inf", "minAntennaGain=Inf"},
+		{"max -Inf", "maxAntennaGain=-Inf"},
+		{"max out of range", "maxAntennaGain=1e300"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			gapi.SearchGateways(w, httptest.NewRequest(http.MethodGet, "/gateways/search?"+tc.query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
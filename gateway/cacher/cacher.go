@@ -25,16 +25,49 @@ import (
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// Options configure a GatewayCacher.
+type Options struct {
+	Store store.Store
+	Redis redis.UniversalClient
+	// UpdateInterval is the interval to refresh the cache in.
+	UpdateInterval time.Duration
+	// Polls records the successful refreshes, optional.
+	Polls *status.Polls
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type GatewayCacher struct {
-	redis redis.UniversalClient
-	store store.Store
+	redis          redis.UniversalClient
+	store          store.Store
+	updateInterval time.Duration
+	polls          *status.Polls
+	log            logrus.FieldLogger
+}
+
+// New creates a GatewayCacher with the given options.
+func New(opts Options) *GatewayCacher {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &GatewayCacher{
+		store:          opts.Store,
+		redis:          opts.Redis,
+		updateInterval: opts.UpdateInterval,
+		polls:          opts.Polls,
+		log:            opts.Logger,
+	}
 }
 
+// NewGatewayCacher creates a GatewayCacher from the config.
 func NewGatewayCacher() (*GatewayCacher, error) {
 	store, err := store.NewStore()
 	if err != nil {
@@ -43,43 +76,55 @@ func NewGatewayCacher() (*GatewayCacher, error) {
 
 	redis := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{viper.GetString(config.CONFIG_GATEWAY_CACHER_REDIS_HOST)}})
 
-	gc := &GatewayCacher{
-		store: store,
-		redis: redis,
+	polls, err := status.NewPolls("GatewayCacher", config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT))
+	if err != nil {
+		return nil, err
 	}
 
-	return gc, nil
+	return New(Options{
+		Store:          store,
+		Redis:          redis,
+		UpdateInterval: viper.GetDuration(config.CONFIG_GATEWAY_CACHER_UPDATE_INTERVAL),
+		Polls:          polls,
+	}), nil
 }
 
 func (gc *GatewayCacher) Run(ctx context.Context) error {
-	pollInterval := viper.GetDuration(config.CONFIG_GATEWAY_CACHER_UPDATE_INTERVAL)
+	pollInterval := gc.updateInterval
 
-	logrus.Info("caching gateway state")
+	gc.log.Info("caching gateway state")
 
-	err := gc.cache(ctx)
-	if err != nil {
-		logrus.WithError(err).Warn("unable to cache gateway state")
-	}
+	gc.refresh(ctx)
 
 	// periodically update the gateway cache
 	for {
 		select {
 		case <-time.After(pollInterval):
-			err := gc.cache(ctx)
-			if err != nil {
-				logrus.WithError(err).Warn("unable to cache gateway state")
-			}
+			gc.refresh(ctx)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
-func (gc *GatewayCacher) cache(ctx context.Context) error {
-	logrus.Info("caching gateway state")
+// refresh caches the gateway state and records the outcome.
+func (gc *GatewayCacher) refresh(ctx context.Context) {
+	start := time.Now()
+	entries, err := gc.cache(ctx)
+	metrics.ObserveCacheRefresh(ctx, start, entries, err)
+	if err != nil {
+		gc.log.WithError(err).Warn("unable to cache gateway state")
+		return
+	}
+
+	gc.polls.Succeeded(ctx)
+}
+
+func (gc *GatewayCacher) cache(ctx context.Context) (int, error) {
+	gc.log.Info("caching gateway state")
 	gateways, err := gc.store.GetAll(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	ids := make(map[string]bool)
@@ -88,7 +133,7 @@ func (gc *GatewayCacher) cache(ctx context.Context) error {
 	for _, gateway := range gateways {
 		b, err := json.Marshal(&gateway)
 		if err != nil {
-			return nil
+			return 0, nil
 		}
 		pipe.Set(ctx, fmt.Sprintf("Gateway.%s", gateway.ID.String()), string(b), 0)
 		ids[gateway.ID.String()] = true
@@ -96,7 +141,7 @@ func (gc *GatewayCacher) cache(ctx context.Context) error {
 
 	_, err = pipe.Exec(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	it := gc.redis.Scan(ctx, 0, "Gateway.*", 0).Iterator()
@@ -104,16 +149,16 @@ func (gc *GatewayCacher) cache(ctx context.Context) error {
 		key := it.Val()
 		parts := strings.Split(key, ".")
 		if len(parts) < 2 {
-			logrus.Warnf("got invalid key while deleting gateways from cache: %s", key)
+			gc.log.Warnf("got invalid key while deleting gateways from cache: %s", key)
 			continue
 		}
 
 		id := parts[1]
 		if _, ok := ids[id]; !ok {
-			logrus.Infof("deleting gateway from cache as it's not in the store anymore: %s", id)
+			gc.log.Infof("deleting gateway from cache as it's not in the store anymore: %s", id)
 			gc.redis.Del(ctx, key)
 		}
 	}
 
-	return nil
+	return len(gateways), nil
 }
@@ -53,3 +53,36 @@ func (gcc *GatewayCacheClient) Get(ctx context.Context, gatewayID types.ID) (*ty
 
 	return &gateway, nil
 }
+
+// GetMulti returns the cached gateways with the ids in the same order, gateways that
+// aren't cached are nil. The lookups are pipelined so they take a single round
+// trip.
+func (gcc *GatewayCacheClient) GetMulti(ctx context.Context, ids []types.ID) ([]*types.Gateway, error) {
+	pipe := gcc.redis.Pipeline()
+	cmds := make([]*redis.StringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.Get(ctx, fmt.Sprintf("Gateway.%s", id.String()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	gateways := make([]*types.Gateway, len(ids))
+	for i, cmd := range cmds {
+		gjson, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var gateway types.Gateway
+		if err := json.Unmarshal([]byte(gjson), &gateway); err != nil {
+			return nil, err
+		}
+		gateways[i] = &gateway
+	}
+
+	return gateways, nil
+}
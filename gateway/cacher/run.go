@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_GATEWAY_CACHER_ENABLED) {
-		gc, err := NewGatewayCacher()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating gateway cacher")
-			return err
-		}
+		// Only the replica holding the lease runs the gateway cacher, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "GatewayCacher", config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT), func(ctx context.Context) error {
+			gc, err := NewGatewayCacher()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating gateway cacher")
+				return err
+			}
 
-		err = gc.Run(ctx)
-		if err != nil {
-			return nil
-		}
+			return gc.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
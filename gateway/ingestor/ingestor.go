@@ -19,61 +19,122 @@ package ingestor
 import (
 	"context"
 
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/source/chainsync"
 	source_interface "github.com/ThingsIXFoundation/data-aggregator/gateway/source/interfac"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Options configure a GatewayIngestor.
+type Options struct {
+	// Source provides the gateway events, the ingestor registers its
+	// funcs on it.
+	Source source_interface.Source
+	Store  store.Store
+	// Publisher is optional, when set stored pending and confirmed events
+	// are published on it.
+	Publisher eventbus.Publisher
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type GatewayIngestor struct {
-	source source_interface.Source
-	store  store.Store
+	source    source_interface.Source
+	store     store.Store
+	publisher eventbus.Publisher
+	log       logrus.FieldLogger
 
 	lastPendingEventCleanHeight uint64
 }
 
+// New creates a GatewayIngestor with the given options.
+func New(opts Options) *GatewayIngestor {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	gi := &GatewayIngestor{
+		source:    opts.Source,
+		store:     opts.Store,
+		publisher: opts.Publisher,
+		log:       opts.Logger,
+	}
+	gi.source.SetFuncs(gi.PendingEventFunc, gi.EventsFunc, gi.SetCurrentBlockFunc, gi.CurrentBlockFunc)
+
+	return gi
+}
+
+// NewGatewayIngestor creates a GatewayIngestor from the config.
 func NewGatewayIngestor() (*GatewayIngestor, error) {
-	gi := &GatewayIngestor{}
 	source, err := chainsync.NewChainSync()
 	if err != nil {
 		return nil, err
 	}
-	source.SetFuncs(gi.PendingEventFunc, gi.EventsFunc, gi.SetCurrentBlockFunc, gi.CurrentBlockFunc)
-	gi.source = source
 
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	gi.store = store
-	return gi, nil
+	publisher, err := eventbus.NewPublisher()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := webhook.NewEventPublisher()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Source:    source,
+		Store:     store,
+		Publisher: eventbus.Multi(publisher, webhooks),
+	}), nil
 }
 
 func (gi *GatewayIngestor) Run(ctx context.Context) error {
 	return gi.source.Run(ctx)
 }
 
-func (gi *GatewayIngestor) PendingEventFunc(ctx context.Context, pendingEvent *types.GatewayEvent) error {
-	logrus.WithFields(logrus.Fields{
+func (gi *GatewayIngestor) PendingEventFunc(ctx context.Context, pendingEvent *types.GatewayEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "ingestor.PendingEvent",
+		attribute.String("gateway", pendingEvent.ID.String()),
+		attribute.String("event.type", string(pendingEvent.Type)))
+	defer func() { tracing.End(span, err) }()
+
+	gi.log.WithFields(logrus.Fields{
 		"contract": pendingEvent.ContractAddress,
 		"gateway":  pendingEvent.ID,
 		"type":     pendingEvent.Type,
 		"block":    pendingEvent.BlockNumber,
 	}).Info("ingesting pending gateway event")
-	return gi.store.StorePendingEvent(ctx, pendingEvent)
+	if err = gi.store.StorePendingEvent(ctx, pendingEvent); err != nil {
+		return err
+	}
+
+	gi.publish(ctx, eventbus.FromGatewayEvent(pendingEvent, true))
+	return nil
 }
 
-func (gi *GatewayIngestor) EventsFunc(ctx context.Context, events []*types.GatewayEvent) error {
+func (gi *GatewayIngestor) EventsFunc(ctx context.Context, events []*types.GatewayEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "ingestor.Events", attribute.Int("events", len(events)))
+	defer func() { tracing.End(span, err) }()
+
 	for _, event := range events {
-		logrus.WithFields(logrus.Fields{
+		gi.log.WithFields(logrus.Fields{
 			"contract": event.ContractAddress,
 			"gateway":  event.ID,
 			"type":     event.Type,
 			"block":    event.BlockNumber,
 		}).Info("ingesting gateway event")
-		err := gi.store.StoreEvent(ctx, event)
+		err = gi.store.StoreEvent(ctx, event)
 		if err != nil {
 			return err
 		}
@@ -84,16 +145,31 @@ func (gi *GatewayIngestor) EventsFunc(ctx context.Context, events []*types.Gatew
 			return err
 		}
 
+		gi.publish(ctx, eventbus.FromGatewayEvent(event, false))
+		metrics.EventIngested(ctx, string(event.Type))
 	}
 
 	return nil
 }
 
+// publish publishes a stored event. Failures are only logged so a failing
+// subscriber never stalls the ingestion, subscribers that can't miss events,
+// like the webhook dispatcher, read the confirmed events from the store.
+func (gi *GatewayIngestor) publish(ctx context.Context, event *eventbus.Event) {
+	if gi.publisher == nil {
+		return
+	}
+
+	if err := gi.publisher.Publish(ctx, event); err != nil {
+		gi.log.WithError(err).WithField("cursor", event.Cursor).Warn("unable to publish gateway event")
+	}
+}
+
 func (gi *GatewayIngestor) SetCurrentBlockFunc(ctx context.Context, height uint64) error {
 	if height-gi.lastPendingEventCleanHeight > 500 {
 		err := gi.store.CleanOldPendingEvents(ctx, height)
 		if err != nil {
-			logrus.WithError(err).Warn("error while cleaning old pending events, continuing as these will be cleaned up anyway")
+			gi.log.WithError(err).Warn("error while cleaning old pending events, continuing as these will be cleaned up anyway")
 		}
 		gi.lastPendingEventCleanHeight = height
 	}
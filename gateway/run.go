@@ -18,48 +18,57 @@ package gateway
 
 import (
 	"context"
-	"errors"
 
+	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/aggregator"
+	"github.com/ThingsIXFoundation/data-aggregator/gateway/api"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/cacher"
 	"github.com/ThingsIXFoundation/data-aggregator/gateway/ingestor"
-	"github.com/sirupsen/logrus"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
-	ingestorErr := make(chan error)
-	go func() {
-		defer close(ingestorErr)
-		if err := ingestor.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("gateway ingestor failed")
-			ingestorErr <- err
-		}
-	}()
+// Components returns the enabled gateway components.
+func Components(elector *leader.Elector) []supervisor.Component {
+	var components []supervisor.Component
 
-	aggregatorErr := make(chan error)
-	go func() {
-		defer close(aggregatorErr)
-		if err := aggregator.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("gateway aggregator failed")
-			aggregatorErr <- err
-		}
-	}()
-
-	cacherErr := make(chan error)
-	go func() {
-		defer close(cacherErr)
-		if err := cacher.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("gateway cacher failed")
-			cacherErr <- err
-		}
-	}()
-
-	select {
-	case err := <-ingestorErr:
-		return err
-	case err := <-aggregatorErr:
-		return err
-	case err := <-cacherErr:
-		return err
+	if viper.GetBool(config.CONFIG_GATEWAY_INGESTOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "gateway-ingestor",
+			Registry: "gateway",
+			Role:     supervisor.RoleIngestor,
+			Run:      func(ctx context.Context) error { return ingestor.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+	if viper.GetBool(config.CONFIG_GATEWAY_AGGREGATOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "gateway-aggregator",
+			Registry: "gateway",
+			Role:     supervisor.RoleAggregator,
+			Run:      func(ctx context.Context) error { return aggregator.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
 	}
+	if viper.GetBool(config.CONFIG_GATEWAY_CACHER_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "gateway-cacher",
+			Registry: "gateway",
+			Role:     supervisor.RoleCacher,
+			Run:      func(ctx context.Context) error { return cacher.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+	if viper.GetBool(config.CONFIG_GATEWAY_API_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "gateway-onboard-purger",
+			Registry: "gateway",
+			Role:     supervisor.RoleAPI,
+			Run:      func(ctx context.Context) error { return api.RunOnboardPurger(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+
+	return components
 }
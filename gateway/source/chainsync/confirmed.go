@@ -23,34 +23,23 @@ import (
 	"time"
 
 	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
-	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	gateway_registry "github.com/ThingsIXFoundation/gateway-registry-go"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-var (
-	syncedBlockGauge = prometheus.NewGauge(prometheus.GaugeOpts{
-		Name: "synced_block",
-		Help: "Synced to blockchain block",
-	})
-)
-
-func init() {
-	prometheus.MustRegister(syncedBlockGauge)
-}
-
 func (cs *ChainSync) runConfirmedSync(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	cs.log.WithFields(logrus.Fields{
 		"registry":             cs.contractAddress,
-		"poll-interval":        viper.GetDuration(config.CONFIG_GATEWAY_CHAINSYNC_POLL_INTERVAL),
-		"max-block-scan-range": viper.GetUint(config.CONFIG_GATEWAY_CHAINSYNC_MAX_BLOCK_SCAN_RANGE),
-		"confirmations":        viper.GetUint(config.CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS),
+		"poll-interval":        cs.pollInterval,
+		"max-block-scan-range": cs.maxBlockScanRange,
+		"confirmations":        cs.confirmations,
 	}).Info("integrate gateways from smart contract")
 
 	pollInterval := time.Duration(time.Second) // first run almost instant
@@ -62,11 +51,12 @@ func (cs *ChainSync) runConfirmedSync(ctx context.Context) error {
 			for {
 				synced, err := cs.syncConfirmed(ctx)
 				if err != nil {
-					logrus.WithError(err).Warn("unable to integrate gateway events")
+					cs.log.WithError(err).Warn("unable to integrate gateway events")
 					break
 				}
 				if synced {
-					pollInterval = viper.GetDuration(config.CONFIG_GATEWAY_CHAINSYNC_POLL_INTERVAL)
+					cs.polls.Succeeded(ctx)
+					pollInterval = cs.pollInterval
 					break
 				}
 			}
@@ -76,9 +66,13 @@ func (cs *ChainSync) runConfirmedSync(ctx context.Context) error {
 	}
 }
 
-func (cs *ChainSync) syncConfirmed(ctx context.Context) (bool, error) {
+func (cs *ChainSync) syncConfirmed(ctx context.Context) (synced bool, err error) {
+	ctx, span := tracing.Start(ctx, "chainsync.SyncConfirmed",
+		attribute.String("registry.contract", cs.contractAddress.Hex()))
+	defer func() { tracing.End(span, err) }()
+
 	// dial RPC node
-	client, err := chainsync.DialRpc(ctx)
+	client, err := cs.dial(ctx)
 	if err != nil {
 		return false, fmt.Errorf("unable to dial RPC node: %w", err)
 	}
@@ -91,7 +85,7 @@ func (cs *ChainSync) syncConfirmed(ctx context.Context) (bool, error) {
 	}
 
 	// determine to sync to
-	syncTo, capped, err := chainsync.GetSyncToBlock(ctx, client, syncFrom.Uint64(), viper.GetUint64(config.CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS), viper.GetUint64(config.CONFIG_GATEWAY_CHAINSYNC_MAX_BLOCK_SCAN_RANGE))
+	syncTo, capped, err := chainsync.GetSyncToBlock(ctx, client, syncFrom.Uint64(), cs.confirmations, cs.maxBlockScanRange)
 	if err != nil {
 		return false, fmt.Errorf("unable to determine sync to block: %w", err)
 	}
@@ -101,7 +95,11 @@ func (cs *ChainSync) syncConfirmed(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
-	logrus.WithFields(logrus.Fields{
+	span.SetAttributes(
+		attribute.Int64("block.from", syncFrom.Int64()),
+		attribute.Int64("block.to", syncTo.Int64()))
+
+	cs.log.WithFields(logrus.Fields{
 		"from":     syncFrom,
 		"to":       syncTo,
 		"contract": cs.contractAddress,
@@ -123,12 +121,13 @@ func (cs *ChainSync) syncConfirmed(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	metrics.SyncedBlock(ctx, syncTo.Uint64())
 
 	return !capped, nil
 }
 
 func (cs *ChainSync) getEvents(ctx context.Context, client *ethclient.Client, from, to *big.Int) ([]*types.GatewayEvent, error) {
-	logrus.WithFields(logrus.Fields{
+	cs.log.WithFields(logrus.Fields{
 		"fromBlock": from,
 		"to":        to,
 		"address":   cs.contractAddress,
@@ -139,11 +138,11 @@ func (cs *ChainSync) getEvents(ctx context.Context, client *ethclient.Client, fr
 		Addresses: []common.Address{cs.contractAddress},
 	})
 	if err != nil {
-		logrus.WithError(err).Error("error while getting gateway events")
+		cs.log.WithError(err).Error("error while getting gateway events")
 		return nil, err
 	}
 
-	logrus.WithFields(logrus.Fields{
+	cs.log.WithFields(logrus.Fields{
 		"fromBlock": from,
 		"to":        to,
 		"address":   cs.contractAddress,
@@ -152,7 +151,7 @@ func (cs *ChainSync) getEvents(ctx context.Context, client *ethclient.Client, fr
 
 	gatewayRegistry, err := gateway_registry.NewGatewayRegistryCaller(cs.contractAddress, client)
 	if err != nil {
-		logrus.WithError(err).Error("error while creating gateway-registry caller")
+		cs.log.WithError(err).Error("error while creating gateway-registry caller")
 		return nil, err
 	}
 
@@ -161,15 +160,17 @@ func (cs *ChainSync) getEvents(ctx context.Context, client *ethclient.Client, fr
 		events []*types.GatewayEvent
 	)
 
+	ctx, span := tracing.Start(ctx, "chainsync.DecodeEvents", attribute.Int("logs", len(logs)))
 	for _, log := range logs {
-		logrus.WithFields(logrus.Fields{
+		cs.log.WithFields(logrus.Fields{
 			"block": log.BlockHash,
 			"tx":    log.TxHash,
 			"type":  log.Topics[0],
 		}).Trace("event")
-		event, err := decodeLogToGatewayEvent(ctx, &log, client, gatewayRegistry, cs.contractAddress)
+		event, err := cs.decodeLogToGatewayEvent(ctx, &log, client, gatewayRegistry, cs.contractAddress)
 		if err != nil {
-			logrus.WithError(err).Error("error while processing gateway logs")
+			cs.log.WithError(err).Error("error while processing gateway logs")
+			tracing.End(span, err)
 			return nil, err
 		}
 		if event == nil {
@@ -179,5 +180,8 @@ func (cs *ChainSync) getEvents(ctx context.Context, client *ethclient.Client, fr
 		events = append(events, event)
 	}
 
+	span.SetAttributes(attribute.Int("events", len(events)))
+	tracing.End(span, nil)
+
 	return events, nil
 }
@@ -34,7 +34,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, gatewayRegistry *gateway_registry.GatewayRegistryCaller, contractAddress common.Address) (*types.GatewayEvent, error) {
+func (cs *ChainSync) decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, gatewayRegistry *gateway_registry.GatewayRegistryCaller, contractAddress common.Address) (*types.GatewayEvent, error) {
 	event := &types.GatewayEvent{
 		Block:            log.BlockHash,
 		BlockNumber:      log.BlockNumber,
@@ -50,7 +50,7 @@ func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethcl
 		event.NewOwner = utils.Ptr(common.BytesToAddress(log.Topics[2].Bytes()))
 		gateway, err := gatewayDetails(gatewayRegistry, contractAddress, log.BlockNumber, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting added gateway details")
+			cs.log.WithError(err).Error("error while getting added gateway details")
 			return nil, err
 		}
 		event.Version = gateway.Version
@@ -60,7 +60,7 @@ func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethcl
 		event.ID = types.ID(log.Topics[1])
 		gatewayBefore, err := gatewayDetails(gatewayRegistry, contractAddress, log.BlockNumber-1, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting before-offboard gateway details")
+			cs.log.WithError(err).Error("error while getting before-offboard gateway details")
 			return nil, err
 		}
 		event.OldOwner = utils.Ptr(gatewayBefore.Owner)
@@ -74,12 +74,12 @@ func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethcl
 		event.ID = types.ID(log.Topics[1])
 		gatewayBefore, err := gatewayDetails(gatewayRegistry, contractAddress, log.BlockNumber-1, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting before-update gateway details")
+			cs.log.WithError(err).Error("error while getting before-update gateway details")
 			return nil, err
 		}
 		gatewayAfter, err := gatewayDetails(gatewayRegistry, contractAddress, log.BlockNumber, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting updated gateway details")
+			cs.log.WithError(err).Error("error while getting updated gateway details")
 			return nil, err
 		}
 
@@ -102,7 +102,7 @@ func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethcl
 		event.NewOwner = utils.Ptr(common.BytesToAddress(log.Topics[3].Bytes()))
 
 	default:
-		logrus.WithFields(logrus.Fields{
+		cs.log.WithFields(logrus.Fields{
 			"block":    log.BlockHash,
 			"tx":       log.TxHash,
 			"txindex":  log.TxIndex,
@@ -114,7 +114,7 @@ func decodeLogToGatewayEvent(ctx context.Context, log *etypes.Log, client *ethcl
 
 	eventTime, err := chainsync.BlockTime(ctx, client, event.BlockNumber)
 	if err != nil {
-		logrus.WithError(err).Error("error while getting time of block")
+		cs.log.WithError(err).Error("error while getting time of block")
 		return nil, err
 	}
 	event.Time = eventTime
@@ -0,0 +1,58 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrLocalIDInUse is returned when a local id is mapped to another gateway
+// that is still onboarded.
+var ErrLocalIDInUse = errors.New("local id in use")
+
+// DBGatewayLocalID maps the local id of a gateway, its EUI, to the id of the
+// gateway. It is taken from the onboard message when the onboard is confirmed
+// on chain and outlives the onboard message.
+type DBGatewayLocalID struct {
+	LocalID   string
+	GatewayID string
+	Onboarder string
+	// Time is the time of the onboard event.
+	Time time.Time
+}
+
+func (e *DBGatewayLocalID) Entity() string {
+	return "GatewayLocalID"
+}
+
+func (e *DBGatewayLocalID) Key() string {
+	return e.LocalID
+}
+
+func NewDBGatewayLocalID(localID string, gatewayID types.ID, onboarder common.Address, at time.Time) *DBGatewayLocalID {
+	return &DBGatewayLocalID{
+		LocalID:   localID,
+		GatewayID: gatewayID.String(),
+		Onboarder: utils.AddressToString(onboarder),
+		Time:      at,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DBGatewayMissingLocalID records an onboarded gateway of which the local id
+// isn't stored yet because the onboard transaction couldn't be looked up. It
+// holds the fields of the onboard event the local id is taken with, the event
+// must have a new owner.
+type DBGatewayMissingLocalID struct {
+	GatewayID   string
+	Owner       string
+	Transaction string
+	// Time is the time of the onboard event.
+	Time time.Time
+}
+
+func (e *DBGatewayMissingLocalID) Entity() string {
+	return "GatewayMissingLocalID"
+}
+
+func (e *DBGatewayMissingLocalID) Key() string {
+	return e.GatewayID
+}
+
+// GatewayEvent returns the onboard event of the gateway.
+func (e *DBGatewayMissingLocalID) GatewayEvent() *types.GatewayEvent {
+	owner := common.HexToAddress(e.Owner)
+	return &types.GatewayEvent{
+		Type:        types.GatewayOnboardedEvent,
+		ID:          types.IDFromString(e.GatewayID),
+		NewOwner:    &owner,
+		Transaction: common.HexToHash(e.Transaction),
+		Time:        e.Time,
+	}
+}
+
+func NewDBGatewayMissingLocalID(event *types.GatewayEvent) *DBGatewayMissingLocalID {
+	return &DBGatewayMissingLocalID{
+		GatewayID:   event.ID.String(),
+		Owner:       utils.AddressToString(*event.NewOwner),
+		Transaction: event.Transaction.Hex(),
+		Time:        event.Time,
+	}
+}
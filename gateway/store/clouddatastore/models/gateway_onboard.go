@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -67,12 +68,5 @@ func NewDBGatewayOnboard(gatewayID types.ID, owner common.Address, signature str
 	}
 }
 
-type GatewayOnboard struct {
-	GatewayID string    `json:"gatewayId"`
-	Owner     string    `json:"owner"`
-	Signature string    `json:"signature"`
-	Version   int       `json:"version"`
-	LocalID   string    `json:"localId"`
-	Onboarder string    `json:"onboarder"`
-	CreatedAt time.Time `json:"createdAt"`
-}
+// GatewayOnboard is the onboard message as the API returns it.
+type GatewayOnboard = apitypes.GatewayOnboard
@@ -0,0 +1,120 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrSearchTooBroad is returned when a search selects more gateways than can
+// be matched in a single request.
+var ErrSearchTooBroad = errors.New("search too broad")
+
+// GatewaySort is the gateway property search results are sorted on.
+type GatewaySort string
+
+const (
+	GatewaySortID          GatewaySort = "id"
+	GatewaySortAntennaGain GatewaySort = "antennaGain"
+	GatewaySortAltitude    GatewaySort = "altitude"
+	GatewaySortVersion     GatewaySort = "version"
+)
+
+// Property returns the DBGateway property the sort maps to.
+func (s GatewaySort) Property() string {
+	switch s {
+	case GatewaySortAntennaGain:
+		return "AntennaGain"
+	case GatewaySortAltitude:
+		return "Altitude"
+	case GatewaySortVersion:
+		return "Version"
+	default:
+		return "__key__"
+	}
+}
+
+// GatewaySearch selects gateways. Every criterion that is set must match,
+// ranges include their bounds except for OnboardedTo.
+type GatewaySearch struct {
+	FrequencyPlan  *frequency_plan.BandName
+	MinAntennaGain *float32
+	MaxAntennaGain *float32
+	MinAltitude    *uint
+	MaxAltitude    *uint
+	// Version is the version of the gateway registry contract the gateway
+	// was onboarded with.
+	Version *uint8
+	// Owners selects the gateways owned by one of the addresses.
+	Owners []common.Address
+	// Region selects the gateways located in the cell, it can be of any
+	// resolution. Region searches sorted on id are ordered by location
+	// first.
+	Region *h3light.Cell
+	// OnboardedFrom and OnboardedTo select the gateways by the time of their
+	// onboard event.
+	OnboardedFrom *time.Time
+	OnboardedTo   *time.Time
+	// LocalID selects the gateway with the local id, the EUI of the gateway.
+	LocalID *string
+
+	Sort       GatewaySort
+	Descending bool
+}
+
+// Onboarded returns true when the search selects gateways by onboard time.
+func (s *GatewaySearch) Onboarded() bool {
+	return s.OnboardedFrom != nil || s.OnboardedTo != nil
+}
+
+// Matches returns true when the gateway matches the search. The onboard time
+// and local id aren't part of the gateway and therefore not checked.
+func (s *GatewaySearch) Matches(gw *types.Gateway) bool {
+	if s.FrequencyPlan != nil && (gw.FrequencyPlan == nil || *gw.FrequencyPlan != *s.FrequencyPlan) {
+		return false
+	}
+	if s.MinAntennaGain != nil && (gw.AntennaGain == nil || *gw.AntennaGain < *s.MinAntennaGain) {
+		return false
+	}
+	if s.MaxAntennaGain != nil && (gw.AntennaGain == nil || *gw.AntennaGain > *s.MaxAntennaGain) {
+		return false
+	}
+	if s.MinAltitude != nil && (gw.Altitude == nil || *gw.Altitude < *s.MinAltitude) {
+		return false
+	}
+	if s.MaxAltitude != nil && (gw.Altitude == nil || *gw.Altitude > *s.MaxAltitude) {
+		return false
+	}
+	if s.Version != nil && gw.Version != *s.Version {
+		return false
+	}
+	if len(s.Owners) > 0 && !utils.In(s.Owners, gw.Owner) {
+		return false
+	}
+	if s.Region != nil && (gw.Location == nil || gw.Location.Resolution() < s.Region.Resolution() ||
+		gw.Location.Parent(s.Region.Resolution()) != *s.Region) {
+		return false
+	}
+	return true
+}
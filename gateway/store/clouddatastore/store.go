@@ -19,6 +19,8 @@ package clouddatastore
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"cloud.google.com/go/datastore"
@@ -35,49 +37,106 @@ import (
 	"google.golang.org/api/iterator"
 )
 
+// maxInFilterValues is the maximum number of values datastore accepts in an
+// in filter.
+const maxInFilterValues = 10
+
+// maxSearchScan is the maximum number of gateways a search reads to fill a
+// page, criteria that can't be part of the query otherwise scan the kind.
+const maxSearchScan = 2000
+
+// maxOnboardedGateways is the maximum number of gateways a search on the
+// onboard time can select.
+const maxOnboardedGateways = 1000
+
 type currentBlockCacheItem struct {
 	StoredHeight  uint64
 	CurrentHeight uint64
 	StoredTime    time.Time
+	// StoredWrites is the number of state writes when the block was stored.
+	StoredWrites uint64
 }
 
-type Store struct {
-	client *datastore.Client
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Contract is the address of the gateway registry the data belongs to.
+	Contract common.Address
+	// BlockCacheDuration is the time to keep the current block in cache
+	// before it's written to the store, it's written right away when the
+	// state changed.
+	BlockCacheDuration time.Duration
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
 
-	currentblockCache map[string]*currentBlockCacheItem
+type Store struct {
+	client             *datastore.Client
+	contract           common.Address
+	blockCacheDuration time.Duration
+	log                logrus.FieldLogger
+
+	currentblockCacheMu sync.Mutex
+	currentblockCache   map[string]*currentBlockCacheItem
+	// writes counts the writes that change the state, the APIs derive their
+	// ETags from the current block so it's stored right away after a change.
+	writes atomic.Uint64
 }
 
-func NewStore(ctx context.Context) (*Store, error) {
-	client, err := datastore.NewClient(ctx, viper.GetString(config.CONFIG_STORE_CLOUDDATASTORE_PROJECT))
-	if err != nil {
-		return nil, err
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
 	}
 
-	s := &Store{
-		client: client,
+	return &Store{
+		client:             opts.Client,
+		contract:           opts.Contract,
+		blockCacheDuration: opts.BlockCacheDuration,
+		log:                opts.Logger,
 
 		currentblockCache: make(map[string]*currentBlockCacheItem),
 	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return s, nil
+	return New(Options{
+		Client:             client,
+		Contract:           config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT),
+		BlockCacheDuration: viper.GetDuration(config.CONFIG_BLOCK_CACHE_DURATION),
+	}), nil
 }
 
 func (s *Store) currentBlockCacheLookup(pksk string) *currentBlockCacheItem {
+	s.currentblockCacheMu.Lock()
+	defer s.currentblockCacheMu.Unlock()
+
 	bc, ok := s.currentblockCache[pksk]
 	if !ok {
 		return nil
-	} else {
-		return bc
 	}
+
+	// Return a copy so callers can't modify the cached item without holding the lock
+	ci := *bc
+	return &ci
 }
 
 func (s *Store) currentBlockCacheStore(pksk string, ci *currentBlockCacheItem) {
+	s.currentblockCacheMu.Lock()
+	defer s.currentblockCacheMu.Unlock()
+
 	s.currentblockCache[pksk] = ci
 }
 
 // CurrentBlock implements store.Store
 func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error) {
-	contract := config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)
+	contract := s.contract
 	cb := daclouddatastore.DBCurrentBlock{
 		Process:         process,
 		ContractAddress: utils.AddressToString(contract),
@@ -92,7 +151,7 @@ func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error
 		return 0, nil
 	}
 	if err != nil {
-		logrus.WithError(err).Errorf("error while getting current block for contract %s from Cloud DataStore", contract)
+		s.log.WithError(err).Errorf("error while getting current block for contract %s from Cloud DataStore", contract)
 		return 0, err
 	}
 
@@ -102,7 +161,7 @@ func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error
 
 // StoreCurrentBlock implements store.Store
 func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height uint64) error {
-	contract := config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)
+	contract := s.contract
 	cb := daclouddatastore.DBCurrentBlock{
 		Process:         process,
 		ContractAddress: utils.AddressToString(contract),
@@ -112,8 +171,10 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 	// Try to lookup the block cache
 	bci := s.currentBlockCacheLookup(cb.Key())
 
-	// If an item is available and it isn't too old or too far away cache it and dont' hit the database
-	if bci != nil && time.Since(bci.StoredTime) < viper.GetDuration(config.CONFIG_BLOCK_CACHE_DURATION) && height-bci.StoredHeight < 10000 {
+	writes := s.writes.Load()
+
+	// If an item is available, the state didn't change and it isn't too old or too far away cache it and dont' hit the database
+	if bci != nil && bci.StoredWrites == writes && time.Since(bci.StoredTime) < s.blockCacheDuration && height-bci.StoredHeight < 10000 {
 		bci.CurrentHeight = height
 		s.currentBlockCacheStore(cb.Key(), bci)
 		return nil
@@ -121,7 +182,7 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&cb), &cb)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing current block for contract %s in CloudDataStore", contract)
+		s.log.WithError(err).Errorf("error while storing current block for contract %s in CloudDataStore", contract)
 		return err
 	}
 
@@ -134,6 +195,7 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 	bci.CurrentHeight = height
 	bci.StoredHeight = height
 	bci.StoredTime = time.Now()
+	bci.StoredWrites = writes
 	s.currentBlockCacheStore(cb.Key(), bci)
 
 	return nil
@@ -237,13 +299,72 @@ func (s *Store) GetEvents(ctx context.Context, gatewayID types.ID, limit int, cu
 
 }
 
+// GetLatestEvents implements store.Store
+func (s *Store) GetLatestEvents(ctx context.Context, limit int, cursor string) ([]*types.GatewayEvent, []string, error) {
+	q := datastore.NewQuery((&models.DBGatewayEvent{}).Entity()).Limit(limit).Order("-Time")
+
+	return s.eventsWithCursors(ctx, q, cursor)
+}
+
+// GetEventsByOwner implements store.Store
+func (s *Store) GetEventsByOwner(ctx context.Context, owner common.Address, oldOwner bool, limit int, cursor string) ([]*types.GatewayEvent, []string, error) {
+	field := "NewOwner"
+	if oldOwner {
+		field = "OldOwner"
+	}
+
+	q := datastore.NewQuery((&models.DBGatewayEvent{}).Entity()).FilterField(field, "=", utils.AddressToString(owner)).Limit(limit).Order("-Time")
+
+	return s.eventsWithCursors(ctx, q, cursor)
+}
+
+// eventsWithCursors runs q from cursor and returns the events together with
+// the cursor after each event.
+func (s *Store) eventsWithCursors(ctx context.Context, q *datastore.Query, cursor string) ([]*types.GatewayEvent, []string, error) {
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		q = q.Start(cursorObj)
+	}
+
+	var (
+		events  []*types.GatewayEvent
+		cursors []string
+		it      = s.client.Run(ctx, q)
+	)
+
+	var dbEvent models.DBGatewayEvent
+	_, err := it.Next(&dbEvent)
+	for err == nil {
+		cursorObj, cerr := it.Cursor()
+		if cerr != nil {
+			return nil, nil, cerr
+		}
+
+		events = append(events, dbEvent.GatewayEvent())
+		cursors = append(cursors, cursorObj.String())
+
+		dbEvent = models.DBGatewayEvent{}
+		_, err = it.Next(&dbEvent)
+	}
+	if err != iterator.Done {
+		return nil, nil, err
+	}
+
+	return events, cursors, nil
+}
+
 func (s *Store) StoreEvent(ctx context.Context, event *types.GatewayEvent) error {
+	s.writes.Add(1)
 	dbevent := *models.NewDBGatewayEvent(event)
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbevent), &dbevent)
 
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing gateway event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing gateway event in gcloud datastore")
 		return err
 	}
 
@@ -257,7 +378,7 @@ func (s *Store) StorePendingEvent(ctx context.Context, pendingEvent *types.Gatew
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbevent), &dbevent)
 
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing pending gateway event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing pending gateway event in gcloud datastore")
 		return err
 	}
 
@@ -277,7 +398,7 @@ func (s *Store) DeletePendingEvent(ctx context.Context, pendingEvent *types.Gate
 
 	err := s.client.Delete(ctx, clouddatastore.GetKey(dbevent))
 	if err != nil {
-		logrus.WithError(err).Errorf("error while deleting pending gateway event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while deleting pending gateway event in gcloud datastore")
 		return err
 	}
 
@@ -330,11 +451,12 @@ func (s *Store) PendingEventsForOwner(ctx context.Context, owner common.Address)
 }
 
 func (s *Store) StoreHistory(ctx context.Context, history *types.GatewayHistory) error {
+	s.writes.Add(1)
 	dbhistory := *models.NewDBGatewayHistory(history)
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbhistory), &dbhistory)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing gateway history in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing gateway history in gcloud datastore")
 		return err
 	}
 
@@ -343,7 +465,7 @@ func (s *Store) StoreHistory(ctx context.Context, history *types.GatewayHistory)
 func (s *Store) GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.GatewayHistory, error) {
 	dbhistory := &models.DBGatewayHistory{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 		Time:            at,
 	}
 
@@ -369,7 +491,7 @@ func (s *Store) GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*t
 func (s *Store) Get(ctx context.Context, id types.ID) (*types.Gateway, error) {
 	dbgateway := models.DBGateway{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 	}
 
 	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbgateway), &dbgateway)
@@ -380,6 +502,40 @@ func (s *Store) Get(ctx context.Context, id types.ID) (*types.Gateway, error) {
 	return dbgateway.Gateway(), nil
 }
 
+// GetMulti implements store.Store
+func (s *Store) GetMulti(ctx context.Context, ids []types.ID) ([]*types.Gateway, error) {
+	var (
+		gateways   = make([]*types.Gateway, len(ids))
+		keys       = make([]*datastore.Key, len(ids))
+		dbgateways = make([]models.DBGateway, len(ids))
+	)
+	for i, id := range ids {
+		dbgateways[i] = models.DBGateway{
+			ID:              id.String(),
+			ContractAddress: utils.AddressToString(s.contract),
+		}
+		keys[i] = daclouddatastore.GetKey(&dbgateways[i])
+	}
+
+	// missing entities are reported per key in a MultiError
+	err := s.client.GetMulti(ctx, keys, dbgateways)
+	var errs datastore.MultiError
+	if err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+	for i := range dbgateways {
+		if errs != nil && errs[i] != nil {
+			if errors.Is(errs[i], datastore.ErrNoSuchEntity) {
+				continue
+			}
+			return nil, errs[i]
+		}
+		gateways[i] = dbgateways[i].Gateway()
+	}
+
+	return gateways, nil
+}
+
 func (s *Store) GetAll(ctx context.Context) ([]*types.Gateway, error) {
 	q := datastore.NewQuery((&models.DBGateway{}).Entity())
 
@@ -440,7 +596,167 @@ func (s *Store) GetByOwner(ctx context.Context, owner common.Address, limit int,
 	return gateways, cursorObj.String(), nil
 }
 
+func (s *Store) Search(ctx context.Context, search *models.GatewaySearch, limit int, cursor string) ([]*types.Gateway, string, error) {
+	var onboarded map[string]bool
+	if search.Onboarded() {
+		var err error
+		onboarded, err = s.onboardedBetween(ctx, search.OnboardedFrom, search.OnboardedTo)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(onboarded) == 0 {
+			return nil, "", nil
+		}
+	}
+
+	// the local id selects at most one gateway, there is nothing to page
+	if search.LocalID != nil {
+		gateway, err := s.GetByLocalID(ctx, *search.LocalID)
+		if err != nil {
+			return nil, "", err
+		}
+		if gateway == nil || !search.Matches(gateway) || (onboarded != nil && !onboarded[gateway.ID.String()]) {
+			return nil, "", nil
+		}
+		return []*types.Gateway{gateway}, "", nil
+	}
+
+	// datastore allows a range filter only on the property that is sorted on
+	// first, the remaining criteria are matched while iterating over the
+	// results
+	q := datastore.NewQuery((&models.DBGateway{}).Entity())
+	if search.FrequencyPlan != nil {
+		q = q.FilterField("FrequencyPlan", "=", string(*search.FrequencyPlan))
+	}
+	if search.Version != nil {
+		q = q.FilterField("Version", "=", int(*search.Version))
+	}
+	if len(search.Owners) == 1 {
+		q = q.FilterField("Owner", "=", utils.AddressToString(search.Owners[0]))
+	} else if len(search.Owners) > 1 && len(search.Owners) <= maxInFilterValues {
+		owners := make([]interface{}, len(search.Owners))
+		for i, owner := range search.Owners {
+			owners[i] = utils.AddressToString(owner)
+		}
+		q = q.FilterField("Owner", "in", owners)
+	}
+
+	order := search.Sort.Property()
+	switch search.Sort {
+	case models.GatewaySortAntennaGain:
+		if search.MinAntennaGain != nil {
+			q = q.FilterField("AntennaGain", ">=", *search.MinAntennaGain)
+		}
+		if search.MaxAntennaGain != nil {
+			q = q.FilterField("AntennaGain", "<=", *search.MaxAntennaGain)
+		}
+	case models.GatewaySortAltitude:
+		if search.MinAltitude != nil {
+			q = q.FilterField("Altitude", ">=", int(*search.MinAltitude))
+		}
+		if search.MaxAltitude != nil {
+			q = q.FilterField("Altitude", "<=", int(*search.MaxAltitude))
+		}
+	case models.GatewaySortID:
+		// the gateways in a region are a prefix range on the location, the
+		// id ordering then only applies within a location
+		if search.Region != nil {
+			q = daclouddatastore.QueryBeginsWith(q, "Location", string(search.Region.DatabaseCell()))
+			order = "Location"
+		}
+	}
+
+	if search.Descending {
+		q = q.Order("-" + order)
+	} else {
+		q = q.Order(order)
+	}
+	if order != "__key__" {
+		q = q.Order("__key__")
+	}
+
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		q = q.Start(cursorObj)
+	}
+
+	var gateways []*types.Gateway
+	var dbGateway models.DBGateway
+
+	scanned := 0
+	var cursorObj datastore.Cursor
+	it := s.client.Run(ctx, q)
+	_, err := it.Next(&dbGateway)
+	for err == nil {
+		gateway := dbGateway.Gateway()
+		if search.Matches(gateway) && (onboarded == nil || onboarded[dbGateway.ID]) {
+			// one more match than the limit tells there is a next page, it
+			// starts after the limit-th match
+			if len(gateways) == limit {
+				return gateways, cursorObj.String(), nil
+			}
+			gateways = append(gateways, gateway)
+			if len(gateways) == limit {
+				if cursorObj, err = it.Cursor(); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+
+		// stop at the scan limit, a short page continues from the last
+		// scanned gateway
+		if scanned++; scanned == maxSearchScan {
+			if len(gateways) < limit {
+				if cursorObj, err = it.Cursor(); err != nil {
+					return nil, "", err
+				}
+			}
+			return gateways, cursorObj.String(), nil
+		}
+
+		_, err = it.Next(&dbGateway)
+	}
+	if err != iterator.Done {
+		return nil, "", err
+	}
+
+	return gateways, "", nil
+}
+
+// onboardedBetween returns the ids of the gateways with an onboard event in
+// the given time range. It returns models.ErrSearchTooBroad when there are
+// more than maxOnboardedGateways of them.
+func (s *Store) onboardedBetween(ctx context.Context, from, to *time.Time) (map[string]bool, error) {
+	q := datastore.NewQuery((&models.DBGatewayEvent{}).Entity()).FilterField("Type", "=", string(types.GatewayOnboardedEvent))
+	if from != nil {
+		q = q.FilterField("Time", ">=", *from)
+	}
+	if to != nil {
+		q = q.FilterField("Time", "<", *to)
+	}
+	q = q.Limit(maxOnboardedGateways + 1)
+
+	var dbEvents []*models.DBGatewayEvent
+	if _, err := s.client.GetAll(ctx, q, &dbEvents); err != nil {
+		return nil, err
+	}
+	if len(dbEvents) > maxOnboardedGateways {
+		return nil, models.ErrSearchTooBroad
+	}
+
+	ids := make(map[string]bool, len(dbEvents))
+	for _, dbEvent := range dbEvents {
+		ids[dbEvent.ID] = true
+	}
+
+	return ids, nil
+}
+
 func (s *Store) Store(ctx context.Context, gateway *types.Gateway) error {
+	s.writes.Add(1)
 	dbgateway := *models.NewDBGateway(gateway)
 
 	_, err := s.client.Put(ctx, daclouddatastore.GetKey(&dbgateway), &dbgateway)
@@ -451,9 +767,10 @@ func (s *Store) Store(ctx context.Context, gateway *types.Gateway) error {
 	return nil
 }
 func (s *Store) Delete(ctx context.Context, id types.ID) error {
+	s.writes.Add(1)
 	dbgateway := &models.DBGateway{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_GATEWAY_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 	}
 
 	err := s.client.Delete(ctx, daclouddatastore.GetKey(dbgateway))
@@ -562,6 +879,21 @@ func (s *Store) GetGatewayOnboardByGatewayID(ctx context.Context, gatewayID stri
 	return dbGatewayOnboard.GatewayOnboard(), nil
 }
 
+func (s *Store) GetGatewayOnboardsForGateway(ctx context.Context, gatewayID types.ID) ([]*models.GatewayOnboard, error) {
+	q := datastore.NewQuery((&models.DBGatewayOnboard{}).Entity()).FilterField("GatewayID", "=", gatewayID.String())
+
+	var dbGatewayOnboards []*models.DBGatewayOnboard
+	if _, err := s.client.GetAll(ctx, q, &dbGatewayOnboards); err != nil {
+		return nil, err
+	}
+
+	onboards := make([]*models.GatewayOnboard, len(dbGatewayOnboards))
+	for i, dbGatewayOnboard := range dbGatewayOnboards {
+		onboards[i] = dbGatewayOnboard.GatewayOnboard()
+	}
+	return onboards, nil
+}
+
 func (s *Store) GetGatewayOnboardsByOwner(ctx context.Context, onboarder common.Address, owner common.Address, limit int, cursor string) ([]*models.GatewayOnboard, string, error) {
 	q := datastore.NewQuery((&models.DBGatewayOnboard{}).Entity()).
 		FilterField("Owner", "=", utils.AddressToString(owner)).
@@ -604,6 +936,84 @@ func (s *Store) GetGatewayOnboardsByOwner(ctx context.Context, onboarder common.
 	return dbGatewayOnboards, cursorObj.String(), nil
 }
 
+func (s *Store) StoreLocalID(ctx context.Context, localID string, gatewayID types.ID, onboarder common.Address, at time.Time) error {
+	s.writes.Add(1)
+	dbLocalID := models.NewDBGatewayLocalID(localID, gatewayID, onboarder, at)
+	key := daclouddatastore.GetKey(dbLocalID)
+
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		var existing models.DBGatewayLocalID
+		err := tx.Get(key, &existing)
+		if err != nil && !errors.Is(err, datastore.ErrNoSuchEntity) {
+			return err
+		}
+
+		// the local id can only move to another gateway when the gateway it
+		// maps to is offboarded
+		if err == nil && existing.GatewayID != dbLocalID.GatewayID {
+			dbGateway := models.DBGateway{
+				ID:              existing.GatewayID,
+				ContractAddress: utils.AddressToString(s.contract),
+			}
+			err := tx.Get(daclouddatastore.GetKey(&dbGateway), &dbGateway)
+			if err == nil {
+				return models.ErrLocalIDInUse
+			}
+			if !errors.Is(err, datastore.ErrNoSuchEntity) {
+				return err
+			}
+		}
+
+		_, err = tx.Put(key, dbLocalID)
+		return err
+	})
+	return err
+}
+
+func (s *Store) GetByLocalID(ctx context.Context, localID string) (*types.Gateway, error) {
+	dbLocalID := models.DBGatewayLocalID{LocalID: localID}
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbLocalID), &dbLocalID)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the gateway is gone when it is offboarded after it was onboarded
+	gateway, err := s.Get(ctx, types.IDFromString(dbLocalID.GatewayID))
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	return gateway, err
+}
+
+func (s *Store) StoreMissingLocalID(ctx context.Context, event *types.GatewayEvent) error {
+	dbMissing := models.NewDBGatewayMissingLocalID(event)
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(dbMissing), dbMissing)
+	return err
+}
+
+func (s *Store) MissingLocalIDs(ctx context.Context) ([]*types.GatewayEvent, error) {
+	var dbMissing []*models.DBGatewayMissingLocalID
+	q := datastore.NewQuery((&models.DBGatewayMissingLocalID{}).Entity())
+	if _, err := s.client.GetAll(ctx, q, &dbMissing); err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.GatewayEvent, len(dbMissing))
+	for i, m := range dbMissing {
+		events[i] = m.GatewayEvent()
+	}
+	return events, nil
+}
+
+func (s *Store) DeleteMissingLocalID(ctx context.Context, gatewayID types.ID) error {
+	dbMissing := &models.DBGatewayMissingLocalID{GatewayID: gatewayID.String()}
+	return s.client.Delete(ctx, daclouddatastore.GetKey(dbMissing))
+}
+
 func (s *Store) PurgeExpiredOnboards(ctx context.Context, expiry time.Duration) error {
 	q := datastore.NewQuery((&models.DBGatewayOnboard{}).Entity()).KeysOnly().FilterField("CreatedAt", "<=", time.Now().Add(-1*expiry))
 
@@ -616,7 +1026,7 @@ func (s *Store) PurgeExpiredOnboards(ctx context.Context, expiry time.Duration)
 		return err
 	}
 
-	logrus.WithField("#", len(expiredKeys)).Info("purged expired gateway onboard messages")
+	s.log.WithField("#", len(expiredKeys)).Info("purged expired gateway onboard messages")
 
 	var gatewayOnboards []*models.DBGatewayOnboard
 	q = datastore.NewQuery((&models.DBGatewayOnboard{}).Entity())
@@ -625,11 +1035,26 @@ func (s *Store) PurgeExpiredOnboards(ctx context.Context, expiry time.Duration)
 		return err
 	}
 
+	// the onboard message of a gateway that is missing its local id is kept
+	// until it expires, the aggregator takes the local id from it on retry
+	q = datastore.NewQuery((&models.DBGatewayMissingLocalID{}).Entity()).KeysOnly()
+	missingKeys, err := s.client.GetAll(ctx, q, nil)
+	if err != nil {
+		return err
+	}
+	missing := make(map[string]bool, len(missingKeys))
+	for _, key := range missingKeys {
+		missing[key.Name] = true
+	}
+
 	for _, gatewayOnboard := range gatewayOnboards {
+		if missing[gatewayOnboard.GatewayID] {
+			continue
+		}
 		gw, _ := s.Get(ctx, types.IDFromString(gatewayOnboard.GatewayID))
 		if gw != nil {
 			s.client.Delete(ctx, daclouddatastore.GetKey(gatewayOnboard))
-			logrus.WithField("gateway-id", gw.ID).Info("purged gateway onboard that was already onboarded")
+			s.log.WithField("gateway-id", gw.ID).Info("purged gateway onboard that was already onboarded")
 		}
 	}
 
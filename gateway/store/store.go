@@ -44,6 +44,12 @@ type Store interface {
 	FirstEvent(ctx context.Context) (*types.GatewayEvent, error)
 	GetEvents(ctx context.Context, gatewayID types.ID, limit int, cursor string) ([]*types.GatewayEvent, string, error)
 	GetEventsBetween(ctx context.Context, start, end time.Time) ([]*types.GatewayEvent, error)
+	// GetLatestEvents returns up to limit events from newest to oldest,
+	// starting at cursor, together with the cursor after each event.
+	GetLatestEvents(ctx context.Context, limit int, cursor string) ([]*types.GatewayEvent, []string, error)
+	// GetEventsByOwner is GetLatestEvents for the events with owner as new
+	// owner, or as old owner when oldOwner is set.
+	GetEventsByOwner(ctx context.Context, owner common.Address, oldOwner bool, limit int, cursor string) ([]*types.GatewayEvent, []string, error)
 
 	StoreHistory(ctx context.Context, history *types.GatewayHistory) error
 	GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.GatewayHistory, error)
@@ -51,8 +57,15 @@ type Store interface {
 	Store(ctx context.Context, gateway *types.Gateway) error
 	Delete(ctx context.Context, id types.ID) error
 	Get(ctx context.Context, id types.ID) (*types.Gateway, error)
+	// GetMulti returns the gateways with the ids in the same order, gateways
+	// that don't exist are nil.
+	GetMulti(ctx context.Context, ids []types.ID) ([]*types.Gateway, error)
 	GetByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.Gateway, string, error)
 	GetAll(ctx context.Context) ([]*types.Gateway, error)
+	// Search returns up to limit gateways matching the search and the cursor
+	// of the next page, which is empty after the last page. A page can be
+	// short when the search reads many gateways that don't match.
+	Search(ctx context.Context, search *models.GatewaySearch, limit int, cursor string) ([]*types.Gateway, string, error)
 
 	GetRes3CountPerRes0(ctx context.Context) (map[h3light.Cell]map[h3light.Cell]uint64, error)
 	GetCountInCellAtRes(ctx context.Context, cell h3light.Cell, res int) (map[h3light.Cell]uint64, error)
@@ -61,6 +74,25 @@ type Store interface {
 	StoreGatewayOnboard(ctx context.Context, onboarder common.Address, gatewayID types.ID, owner common.Address, signature string, version uint8, localId string) error
 	GetGatewayOnboardsByOwner(ctx context.Context, onboarder common.Address, owner common.Address, limit int, cursor string) ([]*models.GatewayOnboard, string, error)
 	GetGatewayOnboardByGatewayID(ctx context.Context, gatewayID string) (*models.GatewayOnboard, error)
+	// GetGatewayOnboardsForGateway returns the onboard messages of the gateway
+	// of all onboarders.
+	GetGatewayOnboardsForGateway(ctx context.Context, gatewayID types.ID) ([]*models.GatewayOnboard, error)
+
+	// StoreLocalID maps the local id of a gateway, its EUI, to the gateway.
+	// It returns models.ErrLocalIDInUse when the local id maps to another
+	// gateway that is still onboarded.
+	StoreLocalID(ctx context.Context, localID string, gatewayID types.ID, onboarder common.Address, at time.Time) error
+	// GetByLocalID returns the gateway with the local id, or nil when there is
+	// no onboarded gateway with the local id.
+	GetByLocalID(ctx context.Context, localID string) (*types.Gateway, error)
+	// StoreMissingLocalID records the onboard event of a gateway of which the
+	// local id couldn't be stored yet, the onboard message of the gateway is
+	// kept until it expires.
+	StoreMissingLocalID(ctx context.Context, event *types.GatewayEvent) error
+	// MissingLocalIDs returns the recorded onboard events of the gateways
+	// that are missing their local id.
+	MissingLocalIDs(ctx context.Context) ([]*types.GatewayEvent, error)
+	DeleteMissingLocalID(ctx context.Context, gatewayID types.ID) error
 
 	PurgeExpiredOnboards(ctx context.Context, expiry time.Duration) error
 }
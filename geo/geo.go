@@ -0,0 +1,74 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package geo computes great-circle distances and expands H3 cells into the
+// rings of cells around them.
+package geo
+
+import "math"
+
+// EarthRadius is the mean radius of the earth in meters.
+const EarthRadius = 6371008.8
+
+// Distance returns the great-circle distance in meters between two points
+// given in degrees.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	var (
+		phi1   = radians(lat1)
+		phi2   = radians(lat2)
+		dPhi   = radians(lat2 - lat1)
+		dLamda = radians(lon2 - lon1)
+		a      = math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLamda/2)*math.Sin(dLamda/2)
+	)
+	// haversine formula
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Destination returns the point that is distance meters away from the given
+// point in the direction of bearing, in degrees clockwise from north.
+func Destination(lat, lon, bearing, distance float64) (float64, float64) {
+	var (
+		phi1   = radians(lat)
+		lamda1 = radians(lon)
+		theta  = radians(bearing)
+		delta  = distance / EarthRadius
+		phi2   = math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+		lamda2 = lamda1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1), math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+	)
+	// normalize the longitude to [-180, 180)
+	return degrees(phi2), math.Mod(degrees(lamda2)+540, 360) - 180
+}
+
+// bearing returns the initial bearing in degrees clockwise from north of the
+// great circle from the first to the second point.
+func bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	var (
+		phi1   = radians(lat1)
+		phi2   = radians(lat2)
+		dLamda = radians(lon2 - lon1)
+		y      = math.Sin(dLamda) * math.Cos(phi2)
+		x      = math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLamda)
+	)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package geo
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/sirupsen/logrus"
+)
+
+// GeoJSONContentType is the media type of GeoJSON documents.
+const GeoJSONContentType = "application/geo+json"
+
+// FeatureCollection is a GeoJSON feature collection as defined by RFC 7946.
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature, the properties describe the geometry.
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON point or polygon, positions are longitude, latitude
+// pairs.
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// NewFeatureCollection returns an empty feature collection.
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{Type: "FeatureCollection", Features: []*Feature{}}
+}
+
+// AddCell adds the polygon of the cell with the given properties, the cell
+// index is added as the cell property.
+func (fc *FeatureCollection) AddCell(cell h3light.Cell, properties map[string]interface{}) {
+	boundary := CellBoundary(cell)
+	ring := make([][2]float64, 0, len(boundary)+1)
+	for _, vertex := range boundary {
+		ring = append(ring, [2]float64{vertex[1], vertex[0]})
+	}
+	ring = append(ring, ring[0])
+
+	fc.add(cell.String(), &Geometry{Type: "Polygon", Coordinates: [][][2]float64{ring}}, cell, properties)
+}
+
+// AddPoint adds the point with the given properties, the cell index of the
+// point is added as the cell property.
+func (fc *FeatureCollection) AddPoint(id string, lat, lon float64, cell h3light.Cell, properties map[string]interface{}) {
+	fc.add(id, &Geometry{Type: "Point", Coordinates: [2]float64{lon, lat}}, cell, properties)
+}
+
+func (fc *FeatureCollection) add(id string, geometry *Geometry, cell h3light.Cell, properties map[string]interface{}) {
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+	properties["cell"] = cell.String()
+
+	fc.Features = append(fc.Features, &Feature{
+		Type:       "Feature",
+		ID:         id,
+		Geometry:   geometry,
+		Properties: properties,
+	})
+}
+
+// Features is a reply that can also be represented as GeoJSON features.
+type Features interface {
+	GeoJSON() *FeatureCollection
+}
+
+// Reply replies v as GeoJSON when the client asked for it and as JSON
+// otherwise.
+func Reply(w http.ResponseWriter, r *http.Request, statusCode int, v Features) {
+	if utils.FormatFromRequest(r) == utils.FormatGeoJSON {
+		ReplyGeoJSON(w, r, statusCode, v.GeoJSON())
+		return
+	}
+	encoding.ReplyJSON(w, r, statusCode, v)
+}
+
+// ReplyGeoJSON replies the feature collection as GeoJSON document.
+func ReplyGeoJSON(w http.ResponseWriter, r *http.Request, statusCode int, fc *FeatureCollection) {
+	w.Header().Set("Content-Type", GeoJSONContentType)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(fc); err != nil {
+		logrus.WithError(err).Error("could not send http GeoJSON reply")
+	}
+}
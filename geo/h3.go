@@ -0,0 +1,191 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package geo
+
+import (
+	"math"
+	"sort"
+
+	h3light "github.com/ThingsIXFoundation/h3-light"
+)
+
+// averageEdgeLength is the average edge length in meters of the hexagons per
+// H3 resolution.
+var averageEdgeLength = [...]float64{
+	1281256.011, 483056.8391, 182512.9565, 68979.22179, 26071.75968, 9854.090990,
+	3724.532667, 1406.475763, 531.414010, 200.786148, 75.863783, 28.663897,
+	10.830188, 4.092010, 1.546100, 0.584169,
+}
+
+// neighbourBearings are the directions in which the neighbours of a cell are
+// probed, every neighbour spans at least 60 degrees seen from the centre.
+var neighbourBearings = [...]float64{0, 30, 60, 90, 120, 150, 180, 210, 240, 270, 300, 330}
+
+// AverageEdgeLength returns the average edge length in meters of the hexagons
+// at the resolution.
+func AverageEdgeLength(res int) float64 {
+	return averageEdgeLength[res]
+}
+
+// CellDistance returns the great-circle distance in meters between the
+// centres of two cells.
+func CellDistance(a, b h3light.Cell) float64 {
+	lat1, lon1 := a.LatLon()
+	lat2, lon2 := b.LatLon()
+	return Distance(lat1, lon1, lat2, lon2)
+}
+
+// Rings returns the cells around the cell up to k cells away, grouped by
+// their grid distance. Rings[0] only contains the cell itself.
+//
+// The neighbours of a cell are found by probing the points 1.5 edge lengths
+// away from its centre. That is inside the neighbouring hexagons in every
+// direction, the edge length is measured around the cell because H3 cells
+// aren't equally sized.
+func Rings(cell h3light.Cell, k int) [][]h3light.Cell {
+	var (
+		res     = cell.Resolution()
+		step    = 1.5 * edgeLength(cell)
+		rings   = [][]h3light.Cell{{cell}}
+		visited = map[h3light.Cell]bool{cell: true}
+	)
+
+	for i := 1; i <= k; i++ {
+		var ring []h3light.Cell
+		for _, c := range rings[i-1] {
+			lat, lon := c.LatLon()
+			for _, bearing := range neighbourBearings {
+				n := cellAt(lat, lon, bearing, step, res)
+				if !visited[n] {
+					visited[n] = true
+					ring = append(ring, n)
+				}
+			}
+		}
+		rings = append(rings, ring)
+	}
+
+	return rings
+}
+
+// Disk returns the cells up to k cells away from the cell.
+func Disk(cell h3light.Cell, k int) []h3light.Cell {
+	var disk []h3light.Cell
+	for _, ring := range Rings(cell, k) {
+		disk = append(disk, ring...)
+	}
+	return disk
+}
+
+// ResolutionForRadius returns the finest resolution at which a disk of at
+// most k rings covers the circle with the given radius in meters.
+func ResolutionForRadius(radius float64, k int) int {
+	for res := len(averageEdgeLength) - 1; res > 0; res-- {
+		if RingsForRadius(radius, res) <= k {
+			return res
+		}
+	}
+	return 0
+}
+
+// RingsForRadius returns the number of rings around a cell at the resolution
+// that covers the circle with the given radius in meters around any point in
+// the cell.
+func RingsForRadius(radius float64, res int) int {
+	// the centres of neighbouring hexagons are sqrt(3) edge lengths apart,
+	// one extra ring covers the point not being in the centre of its cell
+	return int(math.Ceil(radius/(math.Sqrt(3)*averageEdgeLength[res]))) + 1
+}
+
+// edgeLength measures the distance from the centre of the cell to its
+// boundary going north. That is between the apothem and the edge length of
+// the hexagon, the average is returned.
+func edgeLength(cell h3light.Cell) float64 {
+	var (
+		res      = cell.Resolution()
+		lat, lon = cell.LatLon()
+		lo, hi   = 0.0, 2 * averageEdgeLength[res]
+	)
+
+	for i := 0; i < 4 && cellAt(lat, lon, 0, hi, res) == cell; i++ {
+		hi *= 2
+	}
+
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if cellAt(lat, lon, 0, mid, res) == cell {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi / ((1 + math.Sqrt(3)/2) / 2)
+}
+
+// cellAt returns the cell at the resolution that contains the point distance
+// meters away in the direction of bearing.
+func cellAt(lat, lon, bearing, distance float64, res int) h3light.Cell {
+	lat, lon = Destination(lat, lon, bearing, distance)
+	return h3light.LatLonToCell(lat, lon, res)
+}
+
+// CellBoundary returns the vertices of the cell as lat, lon pairs in
+// counterclockwise order. A vertex is where the cell meets two neighbours, it
+// is placed at the spherical centroid of the centres of these three cells.
+func CellBoundary(cell h3light.Cell) [][2]float64 {
+	var (
+		lat, lon   = cell.LatLon()
+		neighbours = Rings(cell, 1)[1]
+		bearings   = make(map[h3light.Cell]float64, len(neighbours))
+	)
+
+	for _, n := range neighbours {
+		nLat, nLon := n.LatLon()
+		bearings[n] = bearing(lat, lon, nLat, nLon)
+	}
+	// counterclockwise is in decreasing bearing
+	sort.Slice(neighbours, func(i, j int) bool {
+		return bearings[neighbours[i]] > bearings[neighbours[j]]
+	})
+
+	boundary := make([][2]float64, len(neighbours))
+	for i, n := range neighbours {
+		vLat, vLon := centroid(cell, n, neighbours[(i+1)%len(neighbours)])
+		// keep the boundary continuous around the antimeridian
+		if vLon-lon > 180 {
+			vLon -= 360
+		} else if lon-vLon > 180 {
+			vLon += 360
+		}
+		boundary[i] = [2]float64{vLat, vLon}
+	}
+
+	return boundary
+}
+
+// centroid returns the spherical centroid of the centres of the cells.
+func centroid(cells ...h3light.Cell) (float64, float64) {
+	var x, y, z float64
+	for _, c := range cells {
+		lat, lon := c.LatLon()
+		x += math.Cos(radians(lat)) * math.Cos(radians(lon))
+		y += math.Cos(radians(lat)) * math.Sin(radians(lon))
+		z += math.Sin(radians(lat))
+	}
+	return degrees(math.Atan2(z, math.Hypot(x, y))), degrees(math.Atan2(y, x))
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var errLeaseLost = errors.New("lease lost")
+
+// generateIdentity returns a lease holder name made of the hostname and a
+// random suffix, so replicas on the same host differ.
+func generateIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(suffix))
+}
+
+// Options configure an Elector.
+type Options struct {
+	Store store.Store
+	// Holder identifies this process as lease holder, defaults to the
+	// hostname with a random suffix.
+	Holder string
+	// LeaseDuration is the time a lease is valid without being renewed.
+	LeaseDuration time.Duration
+	// RetryInterval is the interval to retry acquiring a lease held by
+	// another holder.
+	RetryInterval time.Duration
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Elector runs functions only while holding their lease.
+type Elector struct {
+	store         store.Store
+	holder        string
+	leaseDuration time.Duration
+	retryInterval time.Duration
+	log           logrus.FieldLogger
+}
+
+// New creates an Elector with the given options.
+func New(opts Options) *Elector {
+	if opts.Holder == "" {
+		opts.Holder = generateIdentity()
+	}
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Elector{
+		store:         opts.Store,
+		holder:        opts.Holder,
+		leaseDuration: opts.LeaseDuration,
+		retryInterval: opts.RetryInterval,
+		log:           opts.Logger,
+	}
+}
+
+// NewElector creates an Elector from the config. It returns nil if leader
+// election is disabled, a nil Elector runs functions without a lease. A
+// process creates a single Elector that all its components share.
+func NewElector() (*Elector, error) {
+	if !viper.GetBool(config.CONFIG_LEADER_ELECTION_ENABLED) {
+		return nil, nil
+	}
+
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Store:         store,
+		Holder:        viper.GetString(config.CONFIG_LEADER_ELECTION_IDENTITY),
+		LeaseDuration: viper.GetDuration(config.CONFIG_LEADER_ELECTION_LEASE_DURATION),
+		RetryInterval: viper.GetDuration(config.CONFIG_LEADER_ELECTION_RETRY_INTERVAL),
+	}), nil
+}
+
+// Run calls fn only while this process holds the lease for process on
+// contract. If the lease is lost the context passed to fn is cancelled and Run
+// waits until it can acquire the lease again, after which fn is called again.
+// fn is therefore expected to build all its state from the store when called.
+// A nil Elector calls fn directly.
+func (e *Elector) Run(ctx context.Context, process string, contract common.Address, fn func(ctx context.Context) error) error {
+	if e == nil {
+		return fn(ctx)
+	}
+
+	le := &elector{
+		Elector:  e,
+		process:  process,
+		contract: contract,
+	}
+
+	return le.run(ctx, fn)
+}
+
+// elector competes for a single lease.
+type elector struct {
+	*Elector
+	process  string
+	contract common.Address
+}
+
+func (le *elector) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	le.log.Infof("waiting for lease %s as %s", le.process, le.holder)
+	for {
+		acquired, err := le.store.AcquireLease(ctx, le.process, le.contract, le.holder, le.leaseDuration)
+		if err != nil {
+			le.log.WithError(err).Warnf("unable to acquire lease %s", le.process)
+		}
+
+		if acquired {
+			err := le.lead(ctx, fn)
+			if !errors.Is(err, errLeaseLost) {
+				return err
+			}
+			le.log.Warnf("lost lease %s, stopped %s", le.process, le.process)
+		}
+
+		select {
+		case <-time.After(le.retryInterval):
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// lead runs fn and renews the lease until fn returns or the lease is lost.
+func (le *elector) lead(ctx context.Context, fn func(ctx context.Context) error) error {
+	le.log.Infof("acquired lease %s, starting %s", le.process, le.process)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(leaderCtx)
+	}()
+
+	defer le.release()
+
+	renewed := time.Now()
+	renew := time.NewTicker(le.leaseDuration / 3)
+	defer renew.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-renew.C:
+			acquired, err := le.store.AcquireLease(ctx, le.process, le.contract, le.holder, le.leaseDuration)
+			if err == nil && acquired {
+				renewed = time.Now()
+				continue
+			}
+
+			// Temporary store errors are tolerated as long as the lease is
+			// guaranteed to be ours, stop before another replica can take over.
+			if err != nil && time.Since(renewed) < le.leaseDuration*2/3 {
+				le.log.WithError(err).Warnf("unable to renew lease %s, retrying", le.process)
+				continue
+			}
+
+			cancel()
+			<-done
+			return errLeaseLost
+		}
+	}
+}
+
+func (le *elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := le.store.ReleaseLease(ctx, le.process, le.contract, le.holder); err != nil {
+		le.log.WithError(err).Warnf("unable to release lease %s", le.process)
+	}
+}
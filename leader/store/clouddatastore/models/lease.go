@@ -14,16 +14,25 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package api
+package models
 
 import (
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
+	"fmt"
+	"time"
 )
 
-type RewardCheque struct {
-	Beneficiary common.Address `json:"beneficiary" gorm:"primaryKey;type:bytea"`
-	Processor   common.Address `json:"processor" gorm:"type:bytea"`
-	TotalAmount hexutil.Bytes  `json:"totalAmount" gorm:"type:bytea;not null"`
-	Signature   hexutil.Bytes  `json:"signature" gorm:"type:bytea"`
+type DBLease struct {
+	Process         string
+	ContractAddress string
+	Holder          string
+	Expiry          time.Time
+	RenewedAt       time.Time `datastore:",noindex"`
+}
+
+func (e *DBLease) Entity() string {
+	return "Lease"
+}
+
+func (e *DBLease) Key() string {
+	return fmt.Sprintf("%s.%s", e.Process, e.ContractAddress)
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	daclouddatastore "github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/leader/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Store struct {
+	client *datastore.Client
+	log    logrus.FieldLogger
+}
+
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Client: client,
+	}), nil
+}
+
+// AcquireLease implements store.Store
+func (s *Store) AcquireLease(ctx context.Context, process string, contract common.Address, holder string, duration time.Duration) (bool, error) {
+	lease := models.DBLease{
+		Process:         process,
+		ContractAddress: utils.AddressToString(contract),
+	}
+	key := daclouddatastore.GetKey(&lease)
+
+	acquired := false
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		acquired = false
+
+		err := tx.Get(key, &lease)
+		if err != nil && !errors.Is(err, datastore.ErrNoSuchEntity) {
+			return err
+		}
+
+		now := time.Now()
+		// Someone else holds a lease that didn't expire yet
+		if err == nil && lease.Holder != holder && now.Before(lease.Expiry) {
+			return nil
+		}
+
+		lease.Holder = holder
+		lease.Expiry = now.Add(duration)
+		lease.RenewedAt = now
+
+		if _, err := tx.Put(key, &lease); err != nil {
+			return err
+		}
+
+		acquired = true
+		return nil
+	})
+	if err != nil {
+		s.log.WithError(err).Errorf("error while acquiring lease %s for contract %s in Cloud DataStore", process, contract)
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+// ReleaseLease implements store.Store
+func (s *Store) ReleaseLease(ctx context.Context, process string, contract common.Address, holder string) error {
+	lease := models.DBLease{
+		Process:         process,
+		ContractAddress: utils.AddressToString(contract),
+	}
+	key := daclouddatastore.GetKey(&lease)
+
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		err := tx.Get(key, &lease)
+		if errors.Is(err, datastore.ErrNoSuchEntity) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Only the holder is allowed to release the lease
+		if lease.Holder != holder {
+			return nil
+		}
+
+		return tx.Delete(key)
+	})
+	if err != nil {
+		s.log.WithError(err).Errorf("error while releasing lease %s for contract %s in Cloud DataStore", process, contract)
+		return err
+	}
+
+	return nil
+}
@@ -23,32 +23,82 @@ import (
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Options configure a MapperAggregator.
+type Options struct {
+	// Contract is the address of the mapper registry.
+	Contract common.Address
+	Store    store.Store
+	// PollInterval is the interval to poll the store for new events.
+	PollInterval time.Duration
+	// MaxBlockScanRange is the number of blocks to aggregate at most at once.
+	MaxBlockScanRange uint64
+	// Polls records the successful polls, optional.
+	Polls *status.Polls
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type MapperAggregator struct {
-	store           store.Store
-	contractAddress common.Address
+	store             store.Store
+	contractAddress   common.Address
+	pollInterval      time.Duration
+	maxBlockScanRange uint64
+	polls             *status.Polls
+	log               logrus.FieldLogger
+}
+
+// New creates a MapperAggregator with the given options.
+func New(opts Options) *MapperAggregator {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &MapperAggregator{
+		contractAddress:   opts.Contract,
+		store:             opts.Store,
+		pollInterval:      opts.PollInterval,
+		maxBlockScanRange: opts.MaxBlockScanRange,
+		polls:             opts.Polls,
+		log:               opts.Logger,
+	}
 }
 
+// NewMapperAggregator creates a MapperAggregator from the config.
 func NewMapperAggregator() (*MapperAggregator, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	return &MapperAggregator{
-		contractAddress: common.HexToAddress(viper.GetString(config.CONFIG_MAPPER_CONTRACT)),
-		store:           store,
-	}, nil
+	contract := config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT)
+
+	polls, err := status.NewPolls("MapperAggregator", contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Contract:          contract,
+		Store:             store,
+		PollInterval:      viper.GetDuration(config.CONFIG_MAPPER_AGGREGATOR_POLL_INTERVAL),
+		MaxBlockScanRange: viper.GetUint64(config.CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE),
+		Polls:             polls,
+	}), nil
 }
 
 func (ma *MapperAggregator) Run(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	ma.log.WithFields(logrus.Fields{
 		"mapper-registry": ma.contractAddress,
 	}).Info("aggregating mapper events")
 
@@ -59,13 +109,16 @@ func (ma *MapperAggregator) Run(ctx context.Context) error {
 		select {
 		case <-time.After(pollInterval):
 			for {
+				start := time.Now()
 				synced, err := ma.aggregate(ctx)
+				metrics.ObserveAggregation(ctx, start, err)
 				if err != nil {
-					logrus.WithError(err).Warn("unable to integrate mapper events")
+					ma.log.WithError(err).Warn("unable to integrate mapper events")
 					break
 				}
 				if synced {
-					pollInterval = viper.GetDuration(config.CONFIG_MAPPER_AGGREGATOR_POLL_INTERVAL)
+					ma.polls.Succeeded(ctx)
+					pollInterval = ma.pollInterval
 					break
 				}
 			}
@@ -75,7 +128,11 @@ func (ma *MapperAggregator) Run(ctx context.Context) error {
 	}
 }
 
-func (ma *MapperAggregator) aggregate(ctx context.Context) (bool, error) {
+func (ma *MapperAggregator) aggregate(ctx context.Context) (synced bool, err error) {
+	ctx, span := tracing.Start(ctx, "aggregator.Aggregate",
+		attribute.String("registry.contract", ma.contractAddress.Hex()))
+	defer func() { tracing.End(span, err) }()
+
 	from, err := ma.aggregateFrom(ctx)
 	if err != nil {
 		return false, err
@@ -90,7 +147,7 @@ func (ma *MapperAggregator) aggregate(ctx context.Context) (bool, error) {
 		return synced, nil
 	}
 
-	logrus.WithFields(logrus.Fields{
+	ma.log.WithFields(logrus.Fields{
 		"from":     from,
 		"to":       to,
 		"contract": ma.contractAddress,
@@ -100,6 +157,10 @@ func (ma *MapperAggregator) aggregate(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	span.SetAttributes(
+		attribute.Int64("block.from", int64(from)),
+		attribute.Int64("block.to", int64(to)),
+		attribute.Int("events", len(events)))
 
 	for _, event := range events {
 		err := ma.processEvent(ctx, event)
@@ -109,6 +170,7 @@ func (ma *MapperAggregator) aggregate(ctx context.Context) (bool, error) {
 	}
 
 	ma.store.StoreCurrentBlock(ctx, "MapperAggregator", to)
+	metrics.SyncedBlock(ctx, to)
 
 	return synced, nil
 
@@ -156,7 +218,7 @@ func (ma *MapperAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 	}
 
 	if iblock == 0 && gblock == 0 || from == 0 {
-		logrus.Infof("no mapper-events found, waiting for first events")
+		ma.log.Infof("no mapper-events found, waiting for first events")
 		return 0, true, nil
 	}
 
@@ -164,8 +226,8 @@ func (ma *MapperAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 		return 0, false, fmt.Errorf("MapperIntegrator (%d) is behind on MapperAggregator (%d), this should not happen", iblock, gblock)
 	} else if iblock == gblock {
 		return gblock, true, nil
-	} else if iblock-from > viper.GetUint64(config.CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE) {
-		return from + viper.GetUint64(config.CONFIG_MAPPER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE), false, nil
+	} else if iblock-from > ma.maxBlockScanRange {
+		return from + ma.maxBlockScanRange, false, nil
 	} else {
 		return iblock, true, nil
 	}
@@ -173,7 +235,7 @@ func (ma *MapperAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 }
 
 func (ma *MapperAggregator) processEvent(ctx context.Context, event *types.MapperEvent) error {
-	logrus.WithFields(logrus.Fields{
+	ma.log.WithFields(logrus.Fields{
 		"contract": event.ContractAddress,
 		"mapper":   event.ID,
 		"type":     event.Type,
@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_MAPPER_AGGREGATOR_ENABLED) {
-		ga, err := NewMapperAggregator()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating mapper aggregator")
-			return err
-		}
+		// Only the replica holding the lease runs the mapper aggregator, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "MapperAggregator", config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT), func(ctx context.Context) error {
+			ma, err := NewMapperAggregator()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating mapper aggregator")
+				return err
+			}
 
-		err = ga.Run(ctx)
-		if err != nil {
-			return err
-		}
+			return ma.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
@@ -17,29 +17,74 @@
 package api
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/mapper/cacher"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
 )
 
+// Options configure a MapperAPI.
+type Options struct {
+	Store store.Store
+	// Confirmations is the number of blocks after which a pending event is
+	// confirmed.
+	Confirmations uint64
+	// Cache serves batch lookups when set.
+	Cache *cacher.MapperCacheClient
+}
+
 type MapperAPI struct {
-	store store.Store
+	store         store.Store
+	confirmations uint64
+	cache         *cacher.MapperCacheClient
 }
 
+// New creates a MapperAPI with the given options.
+func New(opts Options) *MapperAPI {
+	return &MapperAPI{
+		store:         opts.Store,
+		confirmations: opts.Confirmations,
+		cache:         opts.Cache,
+	}
+}
+
+// NewMapperAPI creates a MapperAPI from the config.
 func NewMapperAPI() (*MapperAPI, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
-	return &MapperAPI{
-		store: store,
-	}, nil
+
+	opts := Options{
+		Store:         store,
+		Confirmations: viper.GetUint64(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS),
+	}
+
+	if viper.GetBool(config.CONFIG_MAPPER_CACHER_ENABLED) {
+		redis := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{viper.GetString(config.CONFIG_MAPPER_CACHER_REDIS_HOST)}})
+		cache, err := cacher.NewMapperCacheClient(redis)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cache = cache
+	}
+
+	return New(opts), nil
 }
 
 func (mapi *MapperAPI) Bind(root *chi.Mux) error {
 	root.Route("/mappers", func(r chi.Router) {
 		r.Route("/v1", func(r chi.Router) {
 			r.Get("/owned/{owner:(?i)(0x)?[0-9a-f]{40}}", mapi.OwnedMappers)
+			r.Post("/batch", mapi.MappersBatch)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}", mapi.MapperDetailsByID)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}/list", mapi.MapperListByID)
 			r.Get("/{id:(?i)(0x)?[0-9a-f]{64}}/events", mapi.MapperEventsByID)
@@ -52,6 +97,21 @@ func (mapi *MapperAPI) Bind(root *chi.Mux) error {
 	return nil
 }
 
+// notModified derives the ETag for the request from the block the given
+// process synced to. It returns true when the handler is done because the
+// client copy is still current or the block could not be determined.
+func (mapi *MapperAPI) notModified(ctx context.Context, w http.ResponseWriter, r *http.Request, process string, cacheControl string) (utils.ETag, bool) {
+	currentBlock, err := mapi.store.CurrentBlock(ctx, process)
+	if err != nil {
+		logging.WithContext(r.Context()).WithError(err).Error("error while getting sync state")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return "", true
+	}
+
+	etag := utils.NewETag(r, currentBlock)
+	return etag, etag.NotModified(w, r, cacheControl)
+}
+
 var (
 	emptyMapperSlice      = make([]*types.Mapper, 0)
 	emptyMapperEventSlice = make([]*types.MapperEvent, 0)
@@ -0,0 +1,48 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// MappersBatch replies the mappers with the ids in the request, the ids of
+// mappers that don't exist are listed as missing. At most utils.MaxBatchSize
+// ids are looked up at once.
+//
+// The mappers are looked up in the cache when it's enabled, mappers that
+// aren't cached yet are looked up in the store.
+func (mapi *MapperAPI) MappersBatch(w http.ResponseWriter, r *http.Request) {
+	utils.ReplyBatch(w, r, "mappers", mapi.getMulti, func(found []*types.Mapper, missing []types.ID) interface{} {
+		return &apitypes.MappersBatchResponse{Mappers: found, Missing: missing}
+	})
+}
+
+// getMulti returns the mappers with the ids in the same order, mappers that
+// don't exist are nil.
+func (mapi *MapperAPI) getMulti(ctx context.Context, ids []types.ID) ([]*types.Mapper, error) {
+	var cache utils.GetMultiFunc[types.Mapper]
+	if mapi.cache != nil {
+		cache = mapi.cache.GetMulti
+	}
+	return utils.GetMultiCached(ctx, ids, cache, mapi.store.GetMulti)
+}
@@ -19,12 +19,12 @@ package api
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
@@ -37,16 +37,10 @@ func replyEventsCursor(events []*types.MapperEvent, cursor string, pageSize int,
 		events = events[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor": cursor,
-			"events": mapperEventsOrEmptySlice(events),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"events": mapperEventsOrEmptySlice(events),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.MapperEventsResponse{
+		Cursor: cursor,
+		Events: mapperEventsOrEmptySlice(events),
+	})
 }
 
 func replyMappersCursor(mappers []*types.Mapper, cursor string, pageSize int, w http.ResponseWriter, r *http.Request) {
@@ -56,16 +50,10 @@ func replyMappersCursor(mappers []*types.Mapper, cursor string, pageSize int, w
 		mappers = mappers[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor":  cursor,
-			"mappers": mappersOrEmptySlice(mappers),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"mappers": mappersOrEmptySlice(mappers),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.MappersResponse{
+		Cursor:  cursor,
+		Mappers: mappersOrEmptySlice(mappers),
+	})
 }
 
 func (gapi *MapperAPI) OwnedMappers(w http.ResponseWriter, r *http.Request) {
@@ -73,13 +61,14 @@ func (gapi *MapperAPI) OwnedMappers(w http.ResponseWriter, r *http.Request) {
 		log         = logging.WithContext(r.Context())
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
+	etag, done := gapi.notModified(ctx, w, r, "MapperAggregator", utils.Revalidate)
+	if done {
+		return
 	}
 
 	mappers, cursor, err := gapi.store.GetByOwner(ctx, owner, pageSize, cursor)
@@ -89,6 +78,7 @@ func (gapi *MapperAPI) OwnedMappers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	replyMappersCursor(mappers, cursor, pageSize, w, r)
 }
 
@@ -100,6 +90,11 @@ func (gapi *MapperAPI) MapperDetailsByID(w http.ResponseWriter, r *http.Request)
 	)
 	defer cancel()
 
+	etag, done := gapi.notModified(ctx, w, r, "MapperAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
 	mapper, err := gapi.store.Get(ctx, mapperID)
 	if err != nil {
 		log.WithError(err).Error("error while getting mapper details")
@@ -112,6 +107,7 @@ func (gapi *MapperAPI) MapperDetailsByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	encoding.ReplyJSON(w, r, http.StatusOK, mapper)
 }
 
@@ -123,6 +119,11 @@ func (gapi *MapperAPI) MapperListByID(w http.ResponseWriter, r *http.Request) {
 	)
 	defer cancel()
 
+	etag, done := gapi.notModified(ctx, w, r, "MapperAggregator", utils.Revalidate)
+	if done {
+		return
+	}
+
 	mapper, err := gapi.store.Get(ctx, mapperID)
 	if err != nil {
 		log.WithError(err).Error("error while getting mapper details")
@@ -130,6 +131,7 @@ func (gapi *MapperAPI) MapperListByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	if mapper == nil {
 		replyMappersCursor([]*types.Mapper{}, "", 1, w, r)
 		return
@@ -143,13 +145,14 @@ func (gapi *MapperAPI) MapperEventsByID(w http.ResponseWriter, r *http.Request)
 		log         = logging.WithContext(r.Context())
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		mapperID    = utils.IDFromRequest(r, "id")
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
+	etag, done := gapi.notModified(ctx, w, r, "MapperIngestor", utils.Revalidate)
+	if done {
+		return
 	}
 
 	events, cursor, err := gapi.store.GetEvents(ctx, mapperID, pageSize, cursor)
@@ -159,5 +162,6 @@ func (gapi *MapperAPI) MapperEventsByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	replyEventsCursor(events, cursor, pageSize, w, r)
 }
@@ -21,13 +21,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
-	"github.com/spf13/viper"
 
-	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
 )
@@ -71,8 +70,8 @@ func (mapi *MapperAPI) PendingMapperEvents(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	encoding.ReplyJSON(w, r, http.StatusOK, &PendingMapperEventsResponse{
-		Confirmations: viper.GetUint64(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS),
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.PendingMapperEventsResponse{
+		Confirmations: mapi.confirmations,
 		SyncedTo:      syncedTo,
 		Events:        mapperEventsOrEmptySlice(events),
 	})
@@ -25,16 +25,49 @@ import (
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// Options configure a MapperCacher.
+type Options struct {
+	Store store.Store
+	Redis redis.UniversalClient
+	// UpdateInterval is the interval to refresh the cache in.
+	UpdateInterval time.Duration
+	// Polls records the successful refreshes, optional.
+	Polls *status.Polls
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type MapperCacher struct {
-	redis redis.UniversalClient
-	store store.Store
+	redis          redis.UniversalClient
+	store          store.Store
+	updateInterval time.Duration
+	polls          *status.Polls
+	log            logrus.FieldLogger
+}
+
+// New creates a MapperCacher with the given options.
+func New(opts Options) *MapperCacher {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &MapperCacher{
+		store:          opts.Store,
+		redis:          opts.Redis,
+		updateInterval: opts.UpdateInterval,
+		polls:          opts.Polls,
+		log:            opts.Logger,
+	}
 }
 
+// NewMapperCacher creates a MapperCacher from the config.
 func NewMapperCacher() (*MapperCacher, error) {
 	store, err := store.NewStore()
 	if err != nil {
@@ -43,44 +76,56 @@ func NewMapperCacher() (*MapperCacher, error) {
 
 	redis := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{viper.GetString(config.CONFIG_MAPPER_CACHER_REDIS_HOST)}})
 
-	gc := &MapperCacher{
-		store: store,
-		redis: redis,
+	polls, err := status.NewPolls("MapperCacher", config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT))
+	if err != nil {
+		return nil, err
 	}
 
-	return gc, nil
+	return New(Options{
+		Store:          store,
+		Redis:          redis,
+		UpdateInterval: viper.GetDuration(config.CONFIG_MAPPER_CACHER_UPDATE_INTERVAL),
+		Polls:          polls,
+	}), nil
 }
 
 func (gc *MapperCacher) Run(ctx context.Context) error {
-	pollInterval := viper.GetDuration(config.CONFIG_MAPPER_CACHER_UPDATE_INTERVAL)
+	pollInterval := gc.updateInterval
 
-	logrus.Info("caching mapper state")
+	gc.log.Info("caching mapper state")
 
-	err := gc.cache(ctx)
-	if err != nil {
-		logrus.WithError(err).Warn("unable to cache mapper state")
-	}
+	gc.refresh(ctx)
 
 	// periodically update the mapper cache
 	for {
 		select {
 		case <-time.After(pollInterval):
-			err := gc.cache(ctx)
-			if err != nil {
-				logrus.WithError(err).Warn("unable to cache mapper state")
-			}
+			gc.refresh(ctx)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
-func (gc *MapperCacher) cache(ctx context.Context) error {
+// refresh caches the mapper state and records the outcome.
+func (gc *MapperCacher) refresh(ctx context.Context) {
+	start := time.Now()
+	entries, err := gc.cache(ctx)
+	metrics.ObserveCacheRefresh(ctx, start, entries, err)
+	if err != nil {
+		gc.log.WithError(err).Warn("unable to cache mapper state")
+		return
+	}
+
+	gc.polls.Succeeded(ctx)
+}
+
+func (gc *MapperCacher) cache(ctx context.Context) (int, error) {
 
-	logrus.Info("caching mapper state")
+	gc.log.Info("caching mapper state")
 	mappers, err := gc.store.GetAll(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	ids := make(map[string]bool)
@@ -89,7 +134,7 @@ func (gc *MapperCacher) cache(ctx context.Context) error {
 	for _, mapper := range mappers {
 		b, err := json.Marshal(&mapper)
 		if err != nil {
-			return nil
+			return 0, nil
 		}
 		pipe.Set(ctx, fmt.Sprintf("Mapper.%s", mapper.ID.String()), string(b), 0)
 		ids[mapper.ID.String()] = true
@@ -97,7 +142,7 @@ func (gc *MapperCacher) cache(ctx context.Context) error {
 
 	_, err = pipe.Exec(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	it := gc.redis.Scan(ctx, 0, "Mapper.*", 0).Iterator()
@@ -105,16 +150,16 @@ func (gc *MapperCacher) cache(ctx context.Context) error {
 		key := it.Val()
 		parts := strings.Split(key, ".")
 		if len(parts) < 2 {
-			logrus.Warnf("got invalid key while deleting mappers from cache: %s", key)
+			gc.log.Warnf("got invalid key while deleting mappers from cache: %s", key)
 			continue
 		}
 
 		id := parts[1]
 		if _, ok := ids[id]; !ok {
-			logrus.Infof("deleting mapper from cache as it's not in the store anymore: %s", id)
+			gc.log.Infof("deleting mapper from cache as it's not in the store anymore: %s", id)
 			gc.redis.Del(ctx, key)
 		}
 	}
 
-	return nil
+	return len(mappers), nil
 }
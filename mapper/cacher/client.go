@@ -53,3 +53,36 @@ func (gcc *MapperCacheClient) Get(ctx context.Context, mapperID types.ID) (*type
 
 	return &mapper, nil
 }
+
+// GetMulti returns the cached mappers with the ids in the same order, mappers that
+// aren't cached are nil. The lookups are pipelined so they take a single round
+// trip.
+func (gcc *MapperCacheClient) GetMulti(ctx context.Context, ids []types.ID) ([]*types.Mapper, error) {
+	pipe := gcc.redis.Pipeline()
+	cmds := make([]*redis.StringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.Get(ctx, fmt.Sprintf("Mapper.%s", id.String()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	mappers := make([]*types.Mapper, len(ids))
+	for i, cmd := range cmds {
+		gjson, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var mapper types.Mapper
+		if err := json.Unmarshal([]byte(gjson), &mapper); err != nil {
+			return nil, err
+		}
+		mappers[i] = &mapper
+	}
+
+	return mappers, nil
+}
@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_MAPPER_CACHER_ENABLED) {
-		gc, err := NewMapperCacher()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating mapper cacher")
-			return err
-		}
+		// Only the replica holding the lease runs the mapper cacher, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "MapperCacher", config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT), func(ctx context.Context) error {
+			mc, err := NewMapperCacher()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating mapper cacher")
+				return err
+			}
 
-		err = gc.Run(ctx)
-		if err != nil {
-			return nil
-		}
+			return mc.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
@@ -19,61 +19,122 @@ package ingestor
 import (
 	"context"
 
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/source/chainsync"
 	source_interface "github.com/ThingsIXFoundation/data-aggregator/mapper/source/interfac"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Options configure a MapperIngestor.
+type Options struct {
+	// Source provides the mapper events, the ingestor registers its
+	// funcs on it.
+	Source source_interface.Source
+	Store  store.Store
+	// Publisher is optional, when set stored pending and confirmed events
+	// are published on it.
+	Publisher eventbus.Publisher
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type MapperIngestor struct {
-	source source_interface.Source
-	store  store.Store
+	source    source_interface.Source
+	store     store.Store
+	publisher eventbus.Publisher
+	log       logrus.FieldLogger
 
 	lastPendingEventCleanHeight uint64
 }
 
+// New creates a MapperIngestor with the given options.
+func New(opts Options) *MapperIngestor {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	gi := &MapperIngestor{
+		source:    opts.Source,
+		store:     opts.Store,
+		publisher: opts.Publisher,
+		log:       opts.Logger,
+	}
+	gi.source.SetFuncs(gi.PendingEventFunc, gi.EventsFunc, gi.SetCurrentBlockFunc, gi.CurrentBlockFunc)
+
+	return gi
+}
+
+// NewMapperIngestor creates a MapperIngestor from the config.
 func NewMapperIngestor() (*MapperIngestor, error) {
-	gi := &MapperIngestor{}
 	source, err := chainsync.NewChainSync()
 	if err != nil {
 		return nil, err
 	}
-	source.SetFuncs(gi.PendingEventFunc, gi.EventsFunc, gi.SetCurrentBlockFunc, gi.CurrentBlockFunc)
-	gi.source = source
 
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	gi.store = store
-	return gi, nil
+	publisher, err := eventbus.NewPublisher()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := webhook.NewEventPublisher()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Source:    source,
+		Store:     store,
+		Publisher: eventbus.Multi(publisher, webhooks),
+	}), nil
 }
 
 func (gi *MapperIngestor) Run(ctx context.Context) error {
 	return gi.source.Run(ctx)
 }
 
-func (gi *MapperIngestor) PendingEventFunc(ctx context.Context, pendingEvent *types.MapperEvent) error {
-	logrus.WithFields(logrus.Fields{
+func (gi *MapperIngestor) PendingEventFunc(ctx context.Context, pendingEvent *types.MapperEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "ingestor.PendingEvent",
+		attribute.String("mapper", pendingEvent.ID.String()),
+		attribute.String("event.type", string(pendingEvent.Type)))
+	defer func() { tracing.End(span, err) }()
+
+	gi.log.WithFields(logrus.Fields{
 		"contract": pendingEvent.ContractAddress,
 		"mapper":   pendingEvent.ID,
 		"type":     pendingEvent.Type,
 		"block":    pendingEvent.BlockNumber,
 	}).Info("ingesting pending mapper event")
-	return gi.store.StorePendingEvent(ctx, pendingEvent)
+	if err = gi.store.StorePendingEvent(ctx, pendingEvent); err != nil {
+		return err
+	}
+
+	gi.publish(ctx, eventbus.FromMapperEvent(pendingEvent, true))
+	return nil
 }
 
-func (gi *MapperIngestor) EventsFunc(ctx context.Context, events []*types.MapperEvent) error {
+func (gi *MapperIngestor) EventsFunc(ctx context.Context, events []*types.MapperEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "ingestor.Events", attribute.Int("events", len(events)))
+	defer func() { tracing.End(span, err) }()
+
 	for _, event := range events {
-		logrus.WithFields(logrus.Fields{
+		gi.log.WithFields(logrus.Fields{
 			"contract": event.ContractAddress,
 			"mapper":   event.ID,
 			"type":     event.Type,
 			"block":    event.BlockNumber,
 		}).Info("ingesting mapper event")
-		err := gi.store.StoreEvent(ctx, event)
+		err = gi.store.StoreEvent(ctx, event)
 		if err != nil {
 			return err
 		}
@@ -84,16 +145,31 @@ func (gi *MapperIngestor) EventsFunc(ctx context.Context, events []*types.Mapper
 			return err
 		}
 
+		gi.publish(ctx, eventbus.FromMapperEvent(event, false))
+		metrics.EventIngested(ctx, string(event.Type))
 	}
 
 	return nil
 }
 
+// publish publishes a stored event. Failures are only logged so a failing
+// subscriber never stalls the ingestion, subscribers that can't miss events,
+// like the webhook dispatcher, read the confirmed events from the store.
+func (gi *MapperIngestor) publish(ctx context.Context, event *eventbus.Event) {
+	if gi.publisher == nil {
+		return
+	}
+
+	if err := gi.publisher.Publish(ctx, event); err != nil {
+		gi.log.WithError(err).WithField("cursor", event.Cursor).Warn("unable to publish mapper event")
+	}
+}
+
 func (gi *MapperIngestor) SetCurrentBlockFunc(ctx context.Context, height uint64) error {
 	if height-gi.lastPendingEventCleanHeight > 500 {
 		err := gi.store.CleanOldPendingEvents(ctx, height)
 		if err != nil {
-			logrus.WithError(err).Warn("error while cleaning old pending events, continuing as these will be cleaned up anyway")
+			gi.log.WithError(err).Warn("error while cleaning old pending events, continuing as these will be cleaned up anyway")
 		}
 		gi.lastPendingEventCleanHeight = height
 	}
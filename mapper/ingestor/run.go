@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_MAPPER_INGESTOR_ENABLED) {
-		gi, err := NewMapperIngestor()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating mapper ingestor")
-			return err
-		}
+		// Only the replica holding the lease runs the mapper ingestor, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "MapperIngestor", config.AddressFromConfig(config.CONFIG_MAPPER_CONTRACT), func(ctx context.Context) error {
+			mi, err := NewMapperIngestor()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating mapper ingestor")
+				return err
+			}
 
-		err = gi.Run(ctx)
-		if err != nil {
-			return nil
-		}
+			return mi.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
@@ -18,49 +18,47 @@ package mapper
 
 import (
 	"context"
-	"errors"
 
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/aggregator"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/cacher"
 	"github.com/ThingsIXFoundation/data-aggregator/mapper/ingestor"
-	"github.com/sirupsen/logrus"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+// Components returns the enabled mapper components.
+func Components(elector *leader.Elector) []supervisor.Component {
+	var components []supervisor.Component
 
-	ingestorErr := make(chan error)
-	go func() {
-		defer close(ingestorErr)
-		if err := ingestor.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("mapper ingestor failed")
-			ingestorErr <- err
-		}
-	}()
-
-	aggregatorErr := make(chan error)
-	go func() {
-		defer close(aggregatorErr)
-		if err := aggregator.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("mapper aggregator failed")
-			aggregatorErr <- err
-		}
-	}()
-
-	cacherErr := make(chan error)
-	go func() {
-		defer close(cacherErr)
-		if err := cacher.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("mapper cacher failed")
-			cacherErr <- err
-		}
-	}()
-
-	select {
-	case err := <-ingestorErr:
-		return err
-	case err := <-aggregatorErr:
-		return err
-	case err := <-cacherErr:
-		return err
+	if viper.GetBool(config.CONFIG_MAPPER_INGESTOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "mapper-ingestor",
+			Registry: "mapper",
+			Role:     supervisor.RoleIngestor,
+			Run:      func(ctx context.Context) error { return ingestor.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
 	}
+	if viper.GetBool(config.CONFIG_MAPPER_AGGREGATOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "mapper-aggregator",
+			Registry: "mapper",
+			Role:     supervisor.RoleAggregator,
+			Run:      func(ctx context.Context) error { return aggregator.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+	if viper.GetBool(config.CONFIG_MAPPER_CACHER_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "mapper-cacher",
+			Registry: "mapper",
+			Role:     supervisor.RoleCacher,
+			Run:      func(ctx context.Context) error { return cacher.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+
+	return components
 }
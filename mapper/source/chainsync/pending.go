@@ -21,24 +21,23 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
-	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	mapper_registry "github.com/ThingsIXFoundation/mapper-registry-go"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	etypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func (cs *ChainSync) runPending(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	cs.log.WithFields(logrus.Fields{
 		"registry":      cs.contractAddress,
-		"confirmations": viper.GetUint(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS),
+		"confirmations": cs.confirmations,
 	}).Info("syncing pending mapper events from smart contract")
 
-	if viper.GetUint(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS) == 0 {
-		logrus.Info("confirmations 0, don't integrate pending events")
+	if cs.confirmations == 0 {
+		cs.log.Info("confirmations 0, don't integrate pending events")
 		<-ctx.Done() // wait until the shutdown signal is given
 		return nil
 	}
@@ -54,7 +53,7 @@ func (cs *ChainSync) runPending(ctx context.Context) error {
 		case <-time.After(retry):
 			lastTime = time.Now()
 			if err := cs.handlePending(ctx); err != nil {
-				logrus.WithError(err).Warn("integrate pending mapper events stopped")
+				cs.log.WithError(err).Warn("integrate pending mapper events stopped")
 			}
 			if lastTime.Before(time.Now().Add(-time.Minute)) {
 				retry = time.Millisecond
@@ -72,7 +71,7 @@ func (cs *ChainSync) runPending(ctx context.Context) error {
 
 func (cs *ChainSync) handlePending(ctx context.Context) error {
 	// dial RPC node
-	client, err := chainsync.DialRpc(ctx)
+	client, err := cs.dial(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to dial RPC node: %w", err)
 	}
@@ -105,7 +104,7 @@ func (cs *ChainSync) handlePending(ctx context.Context) error {
 
 	mapperRegistry, err := mapper_registry.NewMapperRegistryCaller(cs.contractAddress, client)
 	if err != nil {
-		logrus.WithError(err).Error("error while creating mapper-registry caller")
+		cs.log.WithError(err).Error("error while creating mapper-registry caller")
 		return err
 	}
 
@@ -125,16 +124,22 @@ func (cs *ChainSync) handlePending(ctx context.Context) error {
 				return fmt.Errorf("unable to retrieve pending mapper logs")
 			}
 
-			event, err := decodeLogToMapperEvent(ctx, &l, client, mapperRegistry, cs.contractAddress)
+			eventCtx, span := tracing.Start(ctx, "chainsync.PendingEvent",
+				attribute.String("tx", l.TxHash.Hex()),
+				attribute.Int64("block", int64(l.BlockNumber)))
+			event, err := cs.decodeLogToMapperEvent(eventCtx, &l, client, mapperRegistry, cs.contractAddress)
 			if err != nil {
-				logrus.WithError(err).Error("error while processing pending mapper events")
+				cs.log.WithError(err).Error("error while processing pending mapper events")
+				tracing.End(span, err)
 				return err
 			}
 			if event == nil {
+				tracing.End(span, nil)
 				continue
 			}
 
-			cs.pendingEventFunc(ctx, event)
+			cs.pendingEventFunc(eventCtx, event)
+			tracing.End(span, nil)
 		}
 	}
 }
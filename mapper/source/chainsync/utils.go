@@ -44,7 +44,7 @@ var (
 	MapperTransferredEvent = common.BytesToHash(crypto.Keccak256([]byte("MapperTransferred(bytes32,address,address)")))
 )
 
-func decodeLogToMapperEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, mapperRegistry *mapper_registry.MapperRegistryCaller, contractAddress common.Address) (*types.MapperEvent, error) {
+func (cs *ChainSync) decodeLogToMapperEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, mapperRegistry *mapper_registry.MapperRegistryCaller, contractAddress common.Address) (*types.MapperEvent, error) {
 	event := &types.MapperEvent{
 		Block:            log.BlockHash,
 		BlockNumber:      log.BlockNumber,
@@ -103,7 +103,7 @@ func decodeLogToMapperEvent(ctx context.Context, log *etypes.Log, client *ethcli
 		event.OldOwner = oldMapper.Owner
 		event.NewOwner = newMapper.Owner
 	default:
-		logrus.WithFields(logrus.Fields{
+		cs.log.WithFields(logrus.Fields{
 			"block":    log.BlockHash,
 			"tx":       log.TxHash,
 			"txindex":  log.TxIndex,
@@ -114,7 +114,7 @@ func decodeLogToMapperEvent(ctx context.Context, log *etypes.Log, client *ethcli
 	}
 	eventTime, err := chainsync.BlockTime(ctx, client, event.BlockNumber)
 	if err != nil {
-		logrus.WithError(err).Error("error while getting time of block")
+		cs.log.WithError(err).Error("error while getting time of block")
 		return nil, err
 	}
 	event.Time = eventTime
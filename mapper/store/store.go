@@ -41,6 +41,13 @@ type Store interface {
 	EventsFromTo(ctx context.Context, from, to uint64) ([]*types.MapperEvent, error)
 	FirstEvent(ctx context.Context) (*types.MapperEvent, error)
 	GetEvents(ctx context.Context, mapperID types.ID, limit int, cursor string) ([]*types.MapperEvent, string, error)
+	GetEventsBetween(ctx context.Context, start, end time.Time) ([]*types.MapperEvent, error)
+	// GetLatestEvents returns up to limit events from newest to oldest,
+	// starting at cursor, together with the cursor after each event.
+	GetLatestEvents(ctx context.Context, limit int, cursor string) ([]*types.MapperEvent, []string, error)
+	// GetEventsByOwner is GetLatestEvents for the events with owner as new
+	// owner, or as old owner when oldOwner is set.
+	GetEventsByOwner(ctx context.Context, owner common.Address, oldOwner bool, limit int, cursor string) ([]*types.MapperEvent, []string, error)
 
 	StoreHistory(ctx context.Context, history *types.MapperHistory) error
 	GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.MapperHistory, error)
@@ -48,6 +55,9 @@ type Store interface {
 	Store(ctx context.Context, mapper *types.Mapper) error
 	Delete(ctx context.Context, id types.ID) error
 	Get(ctx context.Context, id types.ID) (*types.Mapper, error)
+	// GetMulti returns the mappers with the ids in the same order, mappers
+	// that don't exist are nil.
+	GetMulti(ctx context.Context, ids []types.ID) ([]*types.Mapper, error)
 	GetByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.Mapper, string, error)
 	GetAll(ctx context.Context) ([]*types.Mapper, error)
 }
@@ -24,12 +24,29 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// Options configure a MappingAPI.
+type Options struct {
+	Store       store.Store
+	RewardStore rewardStore.Store
+	MapperStore mapperStore.Store
+}
+
 type MappingAPI struct {
 	store       store.Store
 	rewardStore rewardStore.Store
 	mapperStore mapperStore.Store
 }
 
+// New creates a MappingAPI with the given options.
+func New(opts Options) *MappingAPI {
+	return &MappingAPI{
+		store:       opts.Store,
+		rewardStore: opts.RewardStore,
+		mapperStore: opts.MapperStore,
+	}
+}
+
+// NewMappingAPI creates a MappingAPI from the config.
 func NewMappingAPI() (*MappingAPI, error) {
 	store, err := store.NewStore()
 	if err != nil {
@@ -46,11 +63,11 @@ func NewMappingAPI() (*MappingAPI, error) {
 		return nil, err
 	}
 
-	return &MappingAPI{
-		store:       store,
-		rewardStore: rewardStore,
-		mapperStore: mapperStore,
-	}, nil
+	return New(Options{
+		Store:       store,
+		RewardStore: rewardStore,
+		MapperStore: mapperStore,
+	}), nil
 }
 
 func (mapi *MappingAPI) Bind(root *chi.Mux) error {
@@ -0,0 +1,94 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+)
+
+// assumedCoverageFeatures is an AssumedCoverageHexContainer that can be
+// replied as GeoJSON.
+type assumedCoverageFeatures apitypes.AssumedCoverageHexContainer
+
+// coverageFeatures is a CoverageHexContainer that can be replied as GeoJSON.
+type coverageFeatures apitypes.CoverageHexContainer
+
+// unverifiedCoverageFeatures is an UnverifiedCoverageHexContainer that can be
+// replied as GeoJSON.
+type unverifiedCoverageFeatures apitypes.UnverifiedCoverageHexContainer
+
+// GeoJSON returns the covered cells as polygons.
+func (c *assumedCoverageFeatures) GeoJSON() *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	for _, cell := range c.Hexes {
+		fc.AddCell(cell, nil)
+	}
+	return fc
+}
+
+// GeoJSON returns the covered cells as polygons with the mapping that proved
+// the coverage.
+func (c *coverageFeatures) GeoJSON() *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	for _, ch := range c.Hexes {
+		fc.AddCell(ch.Location, map[string]interface{}{
+			"date":            ch.Date.Format(time.DateOnly),
+			"gatewayId":       ch.GatewayID,
+			"gatewayLocation": ch.GatewayLocation,
+			"frequencyPlan":   ch.FrequencyPlan,
+			"mapperId":        ch.MapperID,
+			"mappingId":       ch.MappingID,
+			"mappingTime":     ch.MappingTime,
+			"rssi":            ch.RSSI,
+		})
+	}
+	return fc
+}
+
+// GeoJSON returns the unverified mappings as points at the reported mapper
+// location with the reception by the best gateway.
+func (c *unverifiedCoverageFeatures) GeoJSON() *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	for _, m := range c.Hexes {
+		properties := map[string]interface{}{
+			"mapperId":        m.MapperID,
+			"mapperAccuracy":  m.MapperAccuracy,
+			"mapperHeight":    m.MapperHeight,
+			"frequency":       m.Frequency,
+			"spreadingFactor": m.SpreadingFactor,
+			"bandwidth":       m.Bandwidth,
+			"receivedTime":    m.ReceivedTime,
+		}
+		if m.BestGatewayID != nil {
+			properties["bestGatewayId"] = *m.BestGatewayID
+		}
+		if m.BestGatewayLocation != nil {
+			properties["bestGatewayLocation"] = *m.BestGatewayLocation
+		}
+		if m.BestGatewayRssi != nil {
+			properties["bestGatewayRssi"] = *m.BestGatewayRssi
+		}
+		if m.BestGatewaySnr != nil {
+			properties["bestGatewaySnr"] = *m.BestGatewaySnr
+		}
+		fc.AddPoint(m.ID.String(), m.MapperLat, m.MapperLon, m.MapperLocation, properties)
+	}
+	return fc
+}
@@ -25,11 +25,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/mapping/store/clouddatastore/models"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
-	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -37,31 +37,6 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-type ChallengeRequest struct {
-	Owner common.Address `json:"owner"`
-}
-
-type ChallengeResponse struct {
-	Owner     common.Address `json:"owner"`
-	Challenge string         `json:"challenge"`
-}
-
-type SignatureRequest struct {
-	Owner     common.Address `json:"owner"`
-	Challenge string         `json:"challenge"`
-	Signature string         `json:"signature"`
-}
-
-type SignatureResponse struct {
-	Owner common.Address `json:"owner"`
-	Code  string         `json:"code"`
-}
-
-type CodeCheckRequest struct {
-	MapperID types.ID `json:"mapperId"`
-	Code     string   `json:"code"`
-}
-
 func generateCode() (string, error) {
 	length := 8
 	charset := "abcdefghijkmnpqrstuvwxyz23456789" // Excludes confusing characters
@@ -87,7 +62,7 @@ func (mapi *MappingAPI) CreateChallenge(w http.ResponseWriter, r *http.Request)
 	defer cancel()
 	defer r.Body.Close()
 
-	challengeRequest := ChallengeRequest{}
+	challengeRequest := apitypes.ChallengeRequest{}
 
 	err := json.NewDecoder(r.Body).Decode(&challengeRequest)
 	if err != nil {
@@ -118,7 +93,7 @@ func (mapi *MappingAPI) CreateChallenge(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := ChallengeResponse{
+	resp := apitypes.ChallengeResponse{
 		Owner:     challengeRequest.Owner,
 		Challenge: hex.Dump(challenge),
 	}
@@ -134,7 +109,7 @@ func (mapi *MappingAPI) SubmitSignature(w http.ResponseWriter, r *http.Request)
 	defer cancel()
 	defer r.Body.Close()
 
-	signatureRequest := SignatureRequest{}
+	signatureRequest := apitypes.SignatureRequest{}
 
 	err := json.NewDecoder(r.Body).Decode(&signatureRequest)
 	if err != nil {
@@ -209,7 +184,7 @@ func (mapi *MappingAPI) SubmitSignature(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := SignatureResponse{
+	resp := apitypes.SignatureResponse{
 		Owner: signatureRequest.Owner,
 		Code:  code,
 	}
@@ -249,7 +224,7 @@ func (mapi *MappingAPI) CheckCode(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 	defer r.Body.Close()
 
-	codeCheckRequest := CodeCheckRequest{}
+	codeCheckRequest := apitypes.CodeCheckRequest{}
 
 	err := json.NewDecoder(r.Body).Decode(&codeCheckRequest)
 	if err != nil {
@@ -21,8 +21,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/go-chi/chi/v5"
 )
@@ -34,6 +37,18 @@ func (mapi *MappingAPI) MinMaxCoverageDates(w http.ResponseWriter, r *http.Reque
 	)
 	defer cancel()
 
+	latestRewardsDate, err := mapi.rewardStore.GetLatestRewardsDateCached(ctx)
+	if err != nil {
+		log.WithError(err).Error("cannot get latest reward date")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, utils.Revalidate) {
+		return
+	}
+
 	min, max, err := mapi.rewardStore.GetMinMaxRewardsDates(ctx)
 	if err != nil {
 		log.WithError(err).Error("error while getting min max coverage date")
@@ -41,11 +56,12 @@ func (mapi *MappingAPI) MinMaxCoverageDates(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	ret := &MinMaxCoverageDates{
+	ret := &apitypes.MinMaxCoverageDates{
 		Min: min.Format(time.DateOnly),
 		Max: max.Format(time.DateOnly),
 	}
 
+	etag.Set(w, utils.Revalidate)
 	encoding.ReplyJSON(w, r, http.StatusOK, ret)
 }
 
@@ -80,6 +96,12 @@ func (mapi *MappingAPI) CoverageForGatewayAt(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// coverage at a date only changes when the rewards are (re)calculated
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, coverageCacheControl) {
+		return
+	}
+
 	chs, err := mapi.store.GetCoverageForGatewayAt(ctx, gatewayID, at)
 	if err != nil {
 		log.WithError(err).Error("error while getting gateway coverage")
@@ -87,12 +109,12 @@ func (mapi *MappingAPI) CoverageForGatewayAt(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	chc := &CoverageHexContainer{
+	chc := &apitypes.CoverageHexContainer{
 		Hexes: chs,
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	encoding.ReplyJSON(w, r, http.StatusOK, chc)
+	etag.Set(w, coverageCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*coverageFeatures)(chc))
 }
 
 func (mapi *MappingAPI) AssumedCoverageForGatewayAt(w http.ResponseWriter, r *http.Request) {
@@ -126,6 +148,12 @@ func (mapi *MappingAPI) AssumedCoverageForGatewayAt(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// coverage at a date only changes when the rewards are (re)calculated
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, coverageCacheControl) {
+		return
+	}
+
 	coverageLocations, err := mapi.store.GetAssumedCoverageLocationsForGateway(ctx, gatewayID, at)
 	if err != nil {
 		log.WithError(err).Error("error while getting gateway coverage")
@@ -133,8 +161,8 @@ func (mapi *MappingAPI) AssumedCoverageForGatewayAt(w http.ResponseWriter, r *ht
 		return
 	}
 
-	ret := &AssumedCoverageHexContainer{Hexes: coverageLocations}
+	ret := &apitypes.AssumedCoverageHexContainer{Hexes: coverageLocations}
 
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	encoding.ReplyJSON(w, r, http.StatusOK, ret)
+	etag.Set(w, coverageCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*assumedCoverageFeatures)(ret))
 }
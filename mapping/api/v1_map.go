@@ -21,9 +21,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	h3light "github.com/ThingsIXFoundation/h3-light"
-	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -31,6 +33,8 @@ const MAP_ASSUMED_COVERAGE_MAX_RES = 8
 const MAP_COVERAGE_MIN_RES = 6
 const MAP_COVERAGE_MAX_RES = 8
 
+const coverageCacheControl = "public, max-age=86400"
+
 func (mapi *MappingAPI) AssumedCoverageMapRes0(w http.ResponseWriter, r *http.Request) {
 	var (
 		log         = logging.WithContext(r.Context())
@@ -59,6 +63,12 @@ func (mapi *MappingAPI) AssumedCoverageMapRes0(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// coverage at a date only changes when the rewards are (re)calculated
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, coverageCacheControl) {
+		return
+	}
+
 	coverageLocations, err := mapi.store.GetAllAssumedCoverageLocationsAtWithRes(ctx, at, 6)
 	if err != nil {
 		log.WithError(err).Error("error while getting coverage locations")
@@ -66,9 +76,9 @@ func (mapi *MappingAPI) AssumedCoverageMapRes0(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	ret := &AssumedCoverageHexContainer{Hexes: coverageLocations}
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	encoding.ReplyJSON(w, r, http.StatusOK, ret)
+	ret := &apitypes.AssumedCoverageHexContainer{Hexes: coverageLocations}
+	etag.Set(w, coverageCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*assumedCoverageFeatures)(ret))
 }
 
 func (mapi *MappingAPI) AssumedCoverageMap(w http.ResponseWriter, r *http.Request) {
@@ -115,6 +125,12 @@ func (mapi *MappingAPI) AssumedCoverageMap(w http.ResponseWriter, r *http.Reques
 	}
 
 	//var coverageLocations []h3.Cell
+	// coverage at a date only changes when the rewards are (re)calculated
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, coverageCacheControl) {
+		return
+	}
+
 	coverageLocations, err := mapi.store.GetAssumedCoverageLocationsInRegionAtWithRes(ctx, hexCell, at, 8)
 	if err != nil {
 		log.WithError(err).Error("error while getting coverage locations")
@@ -122,9 +138,9 @@ func (mapi *MappingAPI) AssumedCoverageMap(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	ret := &AssumedCoverageHexContainer{Hexes: coverageLocations}
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	encoding.ReplyJSON(w, r, http.StatusOK, ret)
+	ret := &apitypes.AssumedCoverageHexContainer{Hexes: coverageLocations}
+	etag.Set(w, coverageCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*assumedCoverageFeatures)(ret))
 
 }
 
@@ -171,6 +187,12 @@ func (mapi *MappingAPI) CoverageMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// coverage at a date only changes when the rewards are (re)calculated
+	etag := utils.NewETag(r, latestRewardsDate)
+	if etag.NotModified(w, r, coverageCacheControl) {
+		return
+	}
+
 	chs, err := mapi.store.GetCoverageInRegionAt(ctx, hexCell, at)
 	if err != nil {
 		log.WithError(err).Error("error while getting coverage locations")
@@ -178,10 +200,10 @@ func (mapi *MappingAPI) CoverageMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chc := &CoverageHexContainer{
+	chc := &apitypes.CoverageHexContainer{
 		Hexes: chs,
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	encoding.ReplyJSON(w, r, http.StatusOK, chc)
+	etag.Set(w, coverageCacheControl)
+	geo.Reply(w, r, http.StatusOK, (*coverageFeatures)(chc))
 }
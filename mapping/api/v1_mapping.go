@@ -19,12 +19,12 @@ package api
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -36,16 +36,10 @@ func replyMappingsCursor(mappings []*types.MappingRecord, cursor string, pageSiz
 		mappings = mappings[:pageSize]
 	}
 
-	if cursor != "" {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"cursor":   cursor,
-			"mappings": mappingsOrEmptySlice(mappings),
-		})
-	} else {
-		encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-			"mappings": mappingsOrEmptySlice(mappings),
-		})
-	}
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.MappingsResponse{
+		Cursor:   cursor,
+		Mappings: mappingsOrEmptySlice(mappings),
+	})
 }
 
 func (mapi *MappingAPI) GetMappingById(w http.ResponseWriter, r *http.Request) {
@@ -72,16 +66,12 @@ func (mapi *MappingAPI) GetRecentMappingsForMapper(w http.ResponseWriter, r *htt
 		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
 		cursor      = r.URL.Query().Get("cursor")
 		code        = r.URL.Query().Get("code")
-		pageSize, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+		pageSize    = utils.PageSizeFromRequest(r)
 		mapperID    = utils.IDFromRequest(r, "id")
 		since       = 24 * time.Hour
 	)
 	defer cancel()
 
-	if pageSize == 0 {
-		pageSize = 15
-	}
-
 	showLive := false
 
 	if len(code) > 0 {
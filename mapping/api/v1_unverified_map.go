@@ -21,9 +21,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/geo"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	h3light "github.com/ThingsIXFoundation/h3-light"
-	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -41,9 +42,10 @@ func (mapi *MappingAPI) AssumedUnverifiedCoverageMapRes0(w http.ResponseWriter,
 		return
 	}
 
-	ret := &AssumedCoverageHexContainer{Hexes: coverageLocations}
+	ret := &apitypes.AssumedCoverageHexContainer{Hexes: coverageLocations}
 	w.Header().Set("Cache-Control", "public, max-age=10800")
-	encoding.ReplyJSON(w, r, http.StatusOK, ret)
+	w.Header().Add("Vary", "Accept")
+	geo.Reply(w, r, http.StatusOK, (*assumedCoverageFeatures)(ret))
 }
 
 func (mapi *MappingAPI) AssumedUnverifiedCoverageMap(w http.ResponseWriter, r *http.Request) {
@@ -76,9 +78,10 @@ func (mapi *MappingAPI) AssumedUnverifiedCoverageMap(w http.ResponseWriter, r *h
 		return
 	}
 
-	ret := &AssumedCoverageHexContainer{Hexes: coverageLocations}
+	ret := &apitypes.AssumedCoverageHexContainer{Hexes: coverageLocations}
 	w.Header().Set("Cache-Control", "public, max-age=10800")
-	encoding.ReplyJSON(w, r, http.StatusOK, ret)
+	w.Header().Add("Vary", "Accept")
+	geo.Reply(w, r, http.StatusOK, (*assumedCoverageFeatures)(ret))
 
 }
 
@@ -111,10 +114,11 @@ func (mapi *MappingAPI) UnverifiedCoverageMap(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	chc := &UnverifiedCoverageHexContainer{
+	chc := &apitypes.UnverifiedCoverageHexContainer{
 		Hexes: chs,
 	}
 
 	w.Header().Set("Cache-Control", "public, max-age=10800")
-	encoding.ReplyJSON(w, r, http.StatusOK, chc)
+	w.Header().Add("Vary", "Accept")
+	geo.Reply(w, r, http.StatusOK, (*unverifiedCoverageFeatures)(chc))
 }
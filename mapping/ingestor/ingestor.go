@@ -22,40 +22,78 @@ import (
 	source_interface "github.com/ThingsIXFoundation/data-aggregator/mapping/source/interfac"
 	"github.com/ThingsIXFoundation/data-aggregator/mapping/source/pubsub"
 	"github.com/ThingsIXFoundation/data-aggregator/mapping/store"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Options configure a MappingIngestor.
+type Options struct {
+	// Source provides the mapping records, the ingestor registers its
+	// func on it.
+	Source source_interface.Source
+	Store  store.Store
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type MappingIngestor struct {
 	source source_interface.Source
 	store  store.Store
+	log    logrus.FieldLogger
 }
 
+// New creates a MappingIngestor with the given options.
+func New(opts Options) *MappingIngestor {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	gi := &MappingIngestor{
+		source: opts.Source,
+		store:  opts.Store,
+		log:    opts.Logger,
+	}
+	gi.source.SetFuncs(gi.MappingFunc)
+
+	return gi
+}
+
+// NewMappingIngestor creates a MappingIngestor from the config.
 func NewMappingIngestor() (*MappingIngestor, error) {
-	gi := &MappingIngestor{}
 	source, err := pubsub.NewPubSub(context.Background())
 	if err != nil {
 		return nil, err
 	}
-	source.SetFuncs(gi.MappingFunc)
-	gi.source = source
 
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	gi.store = store
-	return gi, nil
+	return New(Options{
+		Source: source,
+		Store:  store,
+	}), nil
 }
 
 func (gi *MappingIngestor) Run(ctx context.Context) error {
 	return gi.source.Run(ctx)
 }
 
-func (gi *MappingIngestor) MappingFunc(ctx context.Context, mappingRecord *types.MappingRecord) error {
-	logrus.WithFields(logrus.Fields{
+func (gi *MappingIngestor) MappingFunc(ctx context.Context, mappingRecord *types.MappingRecord) (err error) {
+	ctx, span := tracing.Start(ctx, "ingestor.Mapping", attribute.String("mapping", mappingRecord.ID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	gi.log.WithFields(logrus.Fields{
 		"mapping_id": mappingRecord.ID,
 	}).Info("received mapping record")
-	return gi.store.StoreMapping(ctx, mappingRecord)
+	if err = gi.store.StoreMapping(ctx, mappingRecord); err != nil {
+		return err
+	}
+
+	metrics.EventIngested(ctx, "mapping")
+	return nil
 }
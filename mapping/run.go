@@ -17,26 +17,25 @@
 package mapping
 
 import (
-	"context"
-	"errors"
-
+	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/mapping/ingestor"
-	"github.com/sirupsen/logrus"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
-
-	ingestorErr := make(chan error)
-	go func() {
-		defer close(ingestorErr)
-		if err := ingestor.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("verified mapping ingestor failed")
-			ingestorErr <- err
-		}
-	}()
+// Components returns the enabled mapping components.
+func Components() []supervisor.Component {
+	var components []supervisor.Component
 
-	select {
-	case err := <-ingestorErr:
-		return err
+	if viper.GetBool(config.CONFIG_MAPPING_INGESTOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "mapping-ingestor",
+			Registry: "mapping",
+			Role:     supervisor.RoleIngestor,
+			Run:      ingestor.Run,
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
 	}
+
+	return components
 }
@@ -28,27 +28,55 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Options configure a PubSub source.
+type Options struct {
+	Client *pubsub.Client
+	// Subscription is the name of the subscription verified mappings are
+	// received on.
+	Subscription string
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type PubSub struct {
-	pubSub      *pubsub.Client
-	mappingFunc interfac.MappingFunc
+	pubSub       *pubsub.Client
+	subscription string
+	mappingFunc  interfac.MappingFunc
+	log          logrus.FieldLogger
 }
 
 var _ interfac.Source = (*PubSub)(nil)
 
+// New creates a PubSub source with the given options.
+func New(opts Options) *PubSub {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &PubSub{
+		pubSub:       opts.Client,
+		subscription: opts.Subscription,
+		log:          opts.Logger,
+	}
+}
+
+// NewPubSub creates a PubSub source from the config.
 func NewPubSub(ctx context.Context) (*PubSub, error) {
 	pubSub, err := pubsub.NewClient(ctx, viper.GetString(config.CONFIG_PUBSUB_PROJECT))
 	if err != nil {
 		return nil, err
 	}
-	return &PubSub{
-		pubSub: pubSub,
-	}, nil
+
+	return New(Options{
+		Client:       pubSub,
+		Subscription: "verified-mapping-datastore",
+	}), nil
 }
 
 func (ps *PubSub) Run(ctx context.Context) error {
-	err := ps.pubSub.Subscription("verified-mapping-datastore").Receive(ctx, ps.receiveMessage)
+	err := ps.pubSub.Subscription(ps.subscription).Receive(ctx, ps.receiveMessage)
 	if err != nil {
-		logrus.WithError(err).Error("error while receiving verified mappings")
+		ps.log.WithError(err).Error("error while receiving verified mappings")
 		return err
 	}
 
@@ -59,13 +87,13 @@ func (ps *PubSub) receiveMessage(ctx context.Context, m *pubsub.Message) {
 	var mappingRecord types.MappingRecord
 	err := json.Unmarshal(m.Data, &mappingRecord)
 	if err != nil {
-		logrus.WithError(err).Error("error while decoding mapping record")
+		ps.log.WithError(err).Error("error while decoding mapping record")
 		m.Nack()
 	}
 
 	err = ps.mappingFunc(ctx, &mappingRecord)
 	if err != nil {
-		logrus.WithError(err).Error("error while handling mapping record")
+		ps.log.WithError(err).Error("error while handling mapping record")
 		m.Nack()
 	}
 
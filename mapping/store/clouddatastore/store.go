@@ -18,36 +18,54 @@ package clouddatastore
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"cloud.google.com/go/datastore"
 	"github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
-	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/mapping/store/clouddatastore/models"
 	h3light "github.com/ThingsIXFoundation/h3-light"
 	"github.com/ThingsIXFoundation/types"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"google.golang.org/api/iterator"
 )
 
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type Store struct {
 	client *datastore.Client
+	log    logrus.FieldLogger
 }
 
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
 func NewStore(ctx context.Context) (*Store, error) {
-	client, err := datastore.NewClient(ctx, viper.GetString(config.CONFIG_STORE_CLOUDDATASTORE_PROJECT))
+	client, err := clouddatastore.NewClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	s := &Store{
-		client: client,
-	}
-
-	return s, nil
+	return New(Options{
+		Client: client,
+	}), nil
 }
 
 //var _ store.Store = &Store{}
@@ -231,7 +249,7 @@ func (s *Store) StoreMapping(ctx context.Context, mappingRecord *types.MappingRe
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbMappingRecord), &dbMappingRecord)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
 		return err
 	}
 
@@ -251,7 +269,7 @@ func (s *Store) StoreMapping(ctx context.Context, mappingRecord *types.MappingRe
 
 	_, err = s.client.PutMulti(ctx, dbDiscoveryRecordKeys, dbDiscoveryRecords)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
 		return err
 	}
 
@@ -271,7 +289,7 @@ func (s *Store) StoreMapping(ctx context.Context, mappingRecord *types.MappingRe
 
 	_, err = s.client.PutMulti(ctx, dbDownlinkRecordKeys, dbDownlinkRecords)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing mapping record in gcloud datastore")
 		return err
 	}
 
@@ -284,6 +302,9 @@ func (s *Store) GetMapping(ctx context.Context, id types.ID) (*types.MappingReco
 	}
 
 	err := s.client.Get(ctx, clouddatastore.GetKey(&dbMappingRecord), &dbMappingRecord)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -396,12 +417,12 @@ func (s *Store) GetRecentMappingsInRegion(ctx context.Context, region h3light.Ce
 		mappingRecords[i] = dbRecord.MappingRecord()
 		mappingRecords[i].DiscoveryReceiptRecords, err = s.getDiscoveryRecordsForMapping(ctx, dbRecord.MappingRecord().ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting discovery records")
+			s.log.WithError(err).Error("error while getting discovery records")
 			return nil, err
 		}
 		mappingRecords[i].DownlinkReceiptRecords, err = s.getDownlinkRecordsForMapping(ctx, dbRecord.MappingRecord().ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting downlink records")
+			s.log.WithError(err).Error("error while getting downlink records")
 			return nil, err
 		}
 	}
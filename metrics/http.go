@@ -0,0 +1,47 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Middleware records the latency and status of HTTP requests per route
+// pattern. It labels the request context with the api component so the store
+// and RPC calls made while serving the request are labelled with the route.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start = time.Now()
+			ctx   = WithLabels(r.Context(), "", "api")
+			ww    = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		// the route pattern is complete once the request is routed
+		httpDuration.WithLabelValues(values(ctx, r.Method, strconv.Itoa(status))...).Observe(time.Since(start).Seconds())
+	})
+}
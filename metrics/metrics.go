@@ -0,0 +1,233 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics collects the Prometheus metrics of the data aggregator.
+// All metrics carry the registry, component and route labels. The registry
+// and component are taken from the context the metric is recorded with, the
+// supervisor labels the context of every component it runs. The route is the
+// pattern of the HTTP route that is being served and empty otherwise.
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "data_aggregator"
+
+	LabelRegistry  = "registry"
+	LabelComponent = "component"
+	LabelRoute     = "route"
+)
+
+var (
+	labelNames = []string{LabelRegistry, LabelComponent, LabelRoute}
+
+	eventsIngested = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_ingested_total",
+		Help:      "Number of confirmed events ingested, partitioned by event type",
+	}, append(labelNames, "type"))
+
+	syncedBlock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "synced_block",
+		Help:      "Block the component synced to",
+	}, labelNames)
+
+	aggregationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "aggregation_duration_seconds",
+		Help:      "How long it took to aggregate a range of events into state",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, append(labelNames, "result"))
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "How long RPC node calls took, partitioned by JSON-RPC method",
+		Buckets:   prometheus.DefBuckets,
+	}, append(labelNames, "method"))
+
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Number of failed RPC node calls, partitioned by JSON-RPC method",
+	}, append(labelNames, "method"))
+
+	storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_duration_seconds",
+		Help:      "How long store operations took, partitioned by entity and operation",
+		Buckets:   prometheus.DefBuckets,
+	}, append(labelNames, "entity", "operation"))
+
+	storeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_errors_total",
+		Help:      "Number of failed store operations, partitioned by entity and operation",
+	}, append(labelNames, "entity", "operation"))
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "How long it took to serve HTTP requests, partitioned by method and status code",
+		Buckets:   prometheus.DefBuckets,
+	}, append(labelNames, "method", "code"))
+
+	cacheRefreshDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cache_refresh_duration_seconds",
+		Help:      "How long it took to refresh the cache",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, append(labelNames, "result"))
+
+	cacheEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_entries",
+		Help:      "Number of entries written by the last successful cache refresh",
+	}, labelNames)
+
+	cacheLastRefresh = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_last_refresh_timestamp_seconds",
+		Help:      "Unix time of the last successful cache refresh",
+	}, labelNames)
+)
+
+func init() {
+	prometheus.MustRegister(
+		eventsIngested,
+		syncedBlock,
+		aggregationDuration,
+		rpcDuration,
+		rpcErrors,
+		storeDuration,
+		storeErrors,
+		httpDuration,
+		cacheRefreshDuration,
+		cacheEntries,
+		cacheLastRefresh,
+	)
+}
+
+type labelsKey struct{}
+
+type labels struct {
+	registry  string
+	component string
+}
+
+// WithLabels returns a copy of ctx that labels the metrics recorded with it
+// with the given registry and component.
+func WithLabels(ctx context.Context, registry, component string) context.Context {
+	return context.WithValue(ctx, labelsKey{}, labels{registry: registry, component: component})
+}
+
+// values returns the registry, component and route label values for ctx
+// followed by the given extra values.
+func values(ctx context.Context, extra ...string) []string {
+	l, _ := ctx.Value(labelsKey{}).(labels)
+
+	var route string
+	if rctx := chi.RouteContext(ctx); rctx != nil {
+		route = rctx.RoutePattern()
+	}
+	if l.registry == "" && l.component == "api" {
+		l.registry = registryFromRoute(route)
+	}
+
+	return append([]string{l.registry, l.component, route}, extra...)
+}
+
+// registryFromRoute returns the registry that is served on the route, routes
+// that don't serve a registry are labelled as api.
+func registryFromRoute(route string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(route, "/"), "/")
+	switch segment {
+	case "gateways":
+		return "gateway"
+	case "routers":
+		return "router"
+	case "mappers":
+		return "mapper"
+	case "owners":
+		return "owner"
+	case "mapping", "rewards", "stats", "activity":
+		return segment
+	default:
+		return "api"
+	}
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// EventIngested counts a confirmed event of the given type.
+func EventIngested(ctx context.Context, eventType string) {
+	eventsIngested.WithLabelValues(values(ctx, eventType)...).Inc()
+}
+
+// SyncedBlock records the block the component synced to.
+func SyncedBlock(ctx context.Context, block uint64) {
+	syncedBlock.WithLabelValues(values(ctx)...).Set(float64(block))
+}
+
+// ObserveAggregation records how long an aggregation that started at start
+// took and whether it failed.
+func ObserveAggregation(ctx context.Context, start time.Time, err error) {
+	aggregationDuration.WithLabelValues(values(ctx, result(err))...).Observe(time.Since(start).Seconds())
+}
+
+// ObserveRPC records how long an RPC node call that started at start took and
+// whether it failed.
+func ObserveRPC(ctx context.Context, method string, start time.Time, failed bool) {
+	labels := values(ctx, method)
+	rpcDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	if failed {
+		rpcErrors.WithLabelValues(labels...).Inc()
+	}
+}
+
+// ObserveStore records how long a store operation on the entity that started
+// at start took and whether it failed.
+func ObserveStore(ctx context.Context, entity, operation string, start time.Time, err error) {
+	labels := values(ctx, entity, operation)
+	storeDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	if err != nil {
+		storeErrors.WithLabelValues(labels...).Inc()
+	}
+}
+
+// ObserveCacheRefresh records how long a cache refresh that started at start
+// took, and on success the number of cached entries and the refresh time.
+func ObserveCacheRefresh(ctx context.Context, start time.Time, entries int, err error) {
+	cacheRefreshDuration.WithLabelValues(values(ctx, result(err))...).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return
+	}
+	cacheEntries.WithLabelValues(values(ctx)...).Set(float64(entries))
+	cacheLastRefresh.WithLabelValues(values(ctx)...).SetToCurrentTime()
+}
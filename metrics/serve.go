@@ -0,0 +1,65 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Run serves the metrics on the metrics listen address in the config until
+// ctx expires.
+func Run(ctx context.Context) error {
+	return Serve(ctx, viper.GetString(config.CONFIG_METRICS_LISTEN_ADDRESS))
+}
+
+// Serve serves the metrics on /metrics at the listen address until ctx
+// expires.
+func Serve(ctx context.Context, listenAddress string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := http.Server{
+		Handler:     mux,
+		Addr:        listenAddress,
+		ReadTimeout: 15 * time.Second,
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		logrus.WithField("addr", listenAddress).Info("start metrics service")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			stopped <- err
+		}
+	}()
+
+	select {
+	case err := <-stopped:
+		return err
+	case <-ctx.Done():
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	mappingStore "github.com/ThingsIXFoundation/data-aggregator/mapping/store"
+	rewardStore "github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/spf13/viper"
+)
+
+// Options configure an OwnerAPI.
+type Options struct {
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	MappingStore mappingStore.Store
+	RewardStore  rewardStore.Store
+	// GatewayConfirmations and MapperConfirmations are the number of
+	// confirmations pending events need.
+	GatewayConfirmations uint64
+	MapperConfirmations  uint64
+	// FetchTimeout is the time each part of a portfolio has to be fetched
+	// in, parts that take longer are left out.
+	FetchTimeout time.Duration
+}
+
+type OwnerAPI struct {
+	gatewayStore         gatewayStore.Store
+	mapperStore          mapperStore.Store
+	mappingStore         mappingStore.Store
+	rewardStore          rewardStore.Store
+	gatewayConfirmations uint64
+	mapperConfirmations  uint64
+	fetchTimeout         time.Duration
+}
+
+// New creates an OwnerAPI with the given options.
+func New(opts Options) *OwnerAPI {
+	return &OwnerAPI{
+		gatewayStore:         opts.GatewayStore,
+		mapperStore:          opts.MapperStore,
+		mappingStore:         opts.MappingStore,
+		rewardStore:          opts.RewardStore,
+		gatewayConfirmations: opts.GatewayConfirmations,
+		mapperConfirmations:  opts.MapperConfirmations,
+		fetchTimeout:         opts.FetchTimeout,
+	}
+}
+
+// NewOwnerAPI creates an OwnerAPI from the config.
+func NewOwnerAPI() (*OwnerAPI, error) {
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mappingStore, err := mappingStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	rewardStore, err := rewardStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		GatewayStore:         gatewayStore,
+		MapperStore:          mapperStore,
+		MappingStore:         mappingStore,
+		RewardStore:          rewardStore,
+		GatewayConfirmations: viper.GetUint64(config.CONFIG_GATEWAY_CHAINSYNC_CONFORMATIONS),
+		MapperConfirmations:  viper.GetUint64(config.CONFIG_MAPPER_CHAINSYNC_CONFORMATIONS),
+		FetchTimeout:         viper.GetDuration(config.CONFIG_OWNER_API_FETCH_TIMEOUT),
+	}), nil
+}
+
+func (oapi *OwnerAPI) Bind(root *chi.Mux) error {
+	root.Route("/owners", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Get("/{owner:(?i)(0x)?[0-9a-f]{40}}", oapi.Portfolio)
+		})
+	})
+
+	return nil
+}
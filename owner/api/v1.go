@@ -0,0 +1,307 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// pageSize is the number of gateways or mappers that are requested from
+	// the store at once.
+	pageSize = 100
+	// maxConcurrentFetches limits the number of per gateway fetches that run
+	// at the same time.
+	maxConcurrentFetches = 10
+)
+
+func (oapi *OwnerAPI) Portfolio(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
+		log         = logging.WithContext(r.Context()).WithFields(logrus.Fields{
+			"owner": owner,
+		})
+		now  = time.Now()
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sem  = make(chan struct{}, maxConcurrentFetches)
+		resp = &apitypes.OwnerResponse{
+			Owner:    owner,
+			Gateways: []*apitypes.OwnerGateway{},
+			Mappers:  []*types.Mapper{},
+		}
+	)
+	defer cancel()
+
+	// fetch runs fn in the background with its own timeout and adds it to
+	// wg, if fn fails the part is logged and reported as unavailable
+	fetch := func(wg *sync.WaitGroup, part string, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				unavailable(log, &mu, resp, part, ctx.Err())
+				return
+			}
+
+			fctx, fcancel := context.WithTimeout(ctx, oapi.fetchTimeout)
+			defer fcancel()
+
+			if err := fn(fctx); err != nil {
+				unavailable(log, &mu, resp, part, err)
+			}
+		}()
+	}
+
+	fetch(&wg, "gateways", func(fctx context.Context) error {
+		gateways, err := allPages(fctx, pageSize, func(ctx context.Context, cursor string) ([]*types.Gateway, string, error) {
+			return oapi.gatewayStore.GetByOwner(ctx, owner, pageSize, cursor)
+		})
+		if err != nil {
+			return err
+		}
+
+		ogs := make([]*apitypes.OwnerGateway, len(gateways))
+		for i, gw := range gateways {
+			ogs[i] = &apitypes.OwnerGateway{Gateway: gw}
+		}
+		mu.Lock()
+		resp.Gateways = ogs
+		mu.Unlock()
+
+		// the coverage of all gateways is taken at the same date
+		at, atErr := oapi.rewardStore.GetLatestRewardsDateCached(fctx)
+		if atErr != nil {
+			unavailable(log, &mu, resp, "coverage", atErr)
+		}
+
+		// the rewards and coverage are fetched a page of gateways at a time,
+		// this bounds the work in flight by the page size instead of the
+		// number of gateways of the owner
+		for start := 0; start < len(ogs) && ctx.Err() == nil; start += pageSize {
+			end := start + pageSize
+			if end > len(ogs) {
+				end = len(ogs)
+			}
+
+			var pwg sync.WaitGroup
+			for _, og := range ogs[start:end] {
+				og := og
+				fetch(&pwg, "gatewayRewards", func(ctx context.Context) error {
+					rewards, err := oapi.rewardStore.GetGatewayRewardsAt(ctx, og.Gateway.ID, now)
+					if err != nil {
+						return err
+					}
+					mu.Lock()
+					og.LatestRewards = rewards
+					mu.Unlock()
+					return nil
+				})
+				if atErr != nil {
+					continue
+				}
+				fetch(&pwg, "coverage", func(ctx context.Context) error {
+					coverage, err := oapi.mappingStore.GetCoverageForGatewayAt(ctx, og.Gateway.ID, at)
+					if err != nil {
+						return err
+					}
+					mu.Lock()
+					og.CoverageSize = len(coverage)
+					mu.Unlock()
+					return nil
+				})
+			}
+			pwg.Wait()
+		}
+
+		// the details of the remaining gateways weren't fetched
+		if err := ctx.Err(); err != nil {
+			unavailable(log, &mu, resp, "gatewayRewards", err)
+			unavailable(log, &mu, resp, "coverage", err)
+		}
+
+		return nil
+	})
+
+	fetch(&wg, "mappers", func(ctx context.Context) error {
+		mappers, err := allPages(ctx, pageSize, func(ctx context.Context, cursor string) ([]*types.Mapper, string, error) {
+			return oapi.mapperStore.GetByOwner(ctx, owner, pageSize, cursor)
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resp.Mappers = mappers
+		mu.Unlock()
+		return nil
+	})
+
+	fetch(&wg, "pendingGatewayEvents", func(ctx context.Context) error {
+		events, err := oapi.gatewayStore.PendingEventsForOwner(ctx, owner)
+		if err != nil {
+			return err
+		}
+		syncedTo, err := oapi.gatewayStore.CurrentBlock(ctx, "GatewayIngestor")
+		if err != nil {
+			return err
+		}
+		if events == nil {
+			events = []*types.GatewayEvent{}
+		}
+		mu.Lock()
+		resp.PendingGatewayEvents = &apitypes.PendingGatewayEventsResponse{
+			Confirmations: oapi.gatewayConfirmations,
+			SyncedTo:      syncedTo,
+			Events:        events,
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	fetch(&wg, "pendingMapperEvents", func(ctx context.Context) error {
+		events, err := oapi.mapperStore.PendingEventsForOwner(ctx, owner)
+		if err != nil {
+			return err
+		}
+		syncedTo, err := oapi.mapperStore.CurrentBlock(ctx, "MapperIngestor")
+		if err != nil {
+			return err
+		}
+		if events == nil {
+			events = []*types.MapperEvent{}
+		}
+		mu.Lock()
+		resp.PendingMapperEvents = &apitypes.PendingMapperEventsResponse{
+			Confirmations: oapi.mapperConfirmations,
+			SyncedTo:      syncedTo,
+			Events:        events,
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	fetch(&wg, "latestRewards", func(ctx context.Context) error {
+		rewards, err := oapi.rewardStore.GetAccountRewardsAt(ctx, owner, now)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resp.LatestRewards = rewards
+		mu.Unlock()
+		return nil
+	})
+
+	fetch(&wg, "cheque", func(ctx context.Context) error {
+		arh, err := oapi.rewardStore.GetLatestSignedAccountReward(ctx, owner)
+		if err != nil || arh == nil {
+			return err
+		}
+		mu.Lock()
+		resp.Cheque = &apitypes.RewardCheque{
+			Beneficiary: arh.Account,
+			Processor:   arh.Processor,
+			TotalAmount: arh.TotalRewards.Bytes(),
+			Signature:   arh.Signature,
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	sort.Strings(resp.Unavailable)
+	resp.Totals = totals(resp)
+
+	encoding.ReplyJSON(w, r, http.StatusOK, resp)
+}
+
+// unavailable logs err and records part as unavailable in resp.
+func unavailable(log *logrus.Entry, mu *sync.Mutex, resp *apitypes.OwnerResponse, part string, err error) {
+	log.WithError(err).WithField("part", part).Warn("unable to fetch part of owner portfolio")
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, p := range resp.Unavailable {
+		if p == part {
+			return
+		}
+	}
+	resp.Unavailable = append(resp.Unavailable, part)
+}
+
+// allPages retrieves all pages of pageSize items from page. Like the store
+// methods it wraps, page returns one item more than pageSize when there is a
+// next page, which starts at that item.
+func allPages[T any](ctx context.Context, pageSize int, page func(ctx context.Context, cursor string) ([]T, string, error)) ([]T, error) {
+	var (
+		all    = []T{}
+		cursor string
+	)
+	for {
+		items, next, err := page(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) <= pageSize || next == "" {
+			return append(all, items...), nil
+		}
+		all = append(all, items[:pageSize]...)
+		cursor = next
+	}
+}
+
+func totals(resp *apitypes.OwnerResponse) apitypes.OwnerTotals {
+	t := apitypes.OwnerTotals{
+		Gateways:       len(resp.Gateways),
+		Mappers:        len(resp.Mappers),
+		GatewayRewards: new(big.Int),
+	}
+	for _, og := range resp.Gateways {
+		t.CoverageSize += og.CoverageSize
+		if og.LatestRewards != nil && og.LatestRewards.Rewards != nil {
+			t.GatewayRewards.Add(t.GatewayRewards, og.LatestRewards.Rewards)
+		}
+	}
+	if resp.PendingGatewayEvents != nil {
+		t.PendingGatewayEvents = len(resp.PendingGatewayEvents.Events)
+	}
+	if resp.PendingMapperEvents != nil {
+		t.PendingMapperEvents = len(resp.PendingMapperEvents.Events)
+	}
+	return t
+}
@@ -17,22 +17,41 @@
 package api
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/go-chi/chi/v5"
 )
 
+// Options configure a RewardsAPI.
+type Options struct {
+	Store store.Store
+}
+
 type RewardsAPI struct {
 	store store.Store
 }
 
+// New creates a RewardsAPI with the given options.
+func New(opts Options) *RewardsAPI {
+	return &RewardsAPI{
+		store: opts.Store,
+	}
+}
+
+// NewRewardsAPI creates a RewardsAPI from the config.
 func NewRewardsAPI() (*RewardsAPI, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
-	return &RewardsAPI{
-		store: store,
-	}, nil
+
+	return New(Options{
+		Store: store,
+	}), nil
 }
 
 func (rapi *RewardsAPI) Bind(root *chi.Mux) error {
@@ -54,3 +73,19 @@ func (rapi *RewardsAPI) Bind(root *chi.Mux) error {
 
 	return nil
 }
+
+// notModified derives the ETag for the request from the latest rewards date
+// and the given versions. It returns true when the handler is done because
+// the client copy is still current or the rewards date could not be
+// determined.
+func (rapi *RewardsAPI) notModified(ctx context.Context, w http.ResponseWriter, r *http.Request, versions ...interface{}) (utils.ETag, bool) {
+	latestRewardsDate, err := rapi.store.GetLatestRewardsDateCached(ctx)
+	if err != nil {
+		logging.WithContext(r.Context()).WithError(err).Error("cannot get latest reward date")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return "", true
+	}
+
+	etag := utils.NewETag(r, append([]interface{}{latestRewardsDate}, versions...)...)
+	return etag, etag.NotModified(w, r, utils.Revalidate)
+}
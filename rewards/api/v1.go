@@ -24,9 +24,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/http-utils/encoding"
-	"github.com/ThingsIXFoundation/http-utils/logging"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
@@ -50,6 +51,12 @@ func (rapi *RewardsAPI) AccountRewardsHistory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// relative start and end dates resolve differently each day
+	etag, done := rapi.notModified(ctx, w, r, start, end)
+	if done {
+		return
+	}
+
 	rewards, err := rapi.store.GetAccountRewardsBetween(ctx, account, start, end)
 	if err != nil {
 		log.WithError(err).Error("error when getting latest account rewards")
@@ -75,8 +82,9 @@ func (rapi *RewardsAPI) AccountRewardsHistory(w http.ResponseWriter, r *http.Req
 		now = now.Add(-24 * time.Hour)
 	}
 
-	encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-		"rewards": filled_rewards,
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.AccountRewardsHistoryResponse{
+		Rewards: filled_rewards,
 	})
 }
 
@@ -98,6 +106,12 @@ func (rapi *RewardsAPI) GatewayRewardsHistory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// relative start and end dates resolve differently each day
+	etag, done := rapi.notModified(ctx, w, r, start, end)
+	if done {
+		return
+	}
+
 	rewards, err := rapi.store.GetGatewayRewardsBetween(ctx, gatewayID, start, end)
 	if err != nil {
 		log.WithError(err).Error("error when getting latest account rewards")
@@ -124,8 +138,9 @@ func (rapi *RewardsAPI) GatewayRewardsHistory(w http.ResponseWriter, r *http.Req
 		now = now.Add(-24 * time.Hour)
 	}
 
-	encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-		"rewards": filled_rewards,
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.GatewayRewardsHistoryResponse{
+		Rewards: filled_rewards,
 	})
 }
 
@@ -147,6 +162,12 @@ func (rapi *RewardsAPI) MapperRewardsHistory(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// relative start and end dates resolve differently each day
+	etag, done := rapi.notModified(ctx, w, r, start, end)
+	if done {
+		return
+	}
+
 	rewards, err := rapi.store.GetMapperRewardsBetween(ctx, mapperID, start, end)
 	if err != nil {
 		log.WithError(err).Error("error when getting latest account rewards")
@@ -174,8 +195,9 @@ func (rapi *RewardsAPI) MapperRewardsHistory(w http.ResponseWriter, r *http.Requ
 		now = now.Add(-24 * time.Hour)
 	}
 
-	encoding.ReplyJSON(w, r, http.StatusOK, map[string]interface{}{
-		"rewards": filled_rewards,
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, &apitypes.MapperRewardsHistoryResponse{
+		Rewards: filled_rewards,
 	})
 }
 
@@ -189,6 +211,11 @@ func (rapi *RewardsAPI) LatestAccountRewards(w http.ResponseWriter, r *http.Requ
 	)
 	defer cancel()
 
+	etag, done := rapi.notModified(ctx, w, r)
+	if done {
+		return
+	}
+
 	arh, err := rapi.store.GetAccountRewardsAt(ctx, account, time.Now())
 	if err != nil {
 		log.WithError(err).Error("error when getting latest signed account rewards")
@@ -201,6 +228,7 @@ func (rapi *RewardsAPI) LatestAccountRewards(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	etag.Set(w, utils.Revalidate)
 	encoding.ReplyJSON(w, r, http.StatusOK, arh)
 }
 
@@ -226,7 +254,7 @@ func (rapi *RewardsAPI) LatestCheque(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rc := &RewardCheque{
+	rc := &apitypes.RewardCheque{
 		Beneficiary: arh.Account,
 		Processor:   arh.Processor,
 		TotalAmount: arh.TotalRewards.Bytes(),
@@ -22,15 +22,18 @@ import (
 
 	"cloud.google.com/go/datastore"
 	"github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
-	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/rewards/store/clouddatastore/models"
 	"github.com/ThingsIXFoundation/data-aggregator/utils"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/spf13/viper"
 	"google.golang.org/api/iterator"
 )
 
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+}
+
 type Store struct {
 	client *datastore.Client
 
@@ -102,13 +105,39 @@ func (s *Store) GetAllAccountRewardsAt(ctx context.Context, at time.Time) ([]*ty
 }
 
 // GetGatewayRewardsAt implements store.Store
-func (*Store) GetGatewayRewardsAt(ctx context.Context, gatewayID types.ID, at time.Time) (*types.GatewayRewardHistory, error) {
-	panic("unimplemented")
+func (s *Store) GetGatewayRewardsAt(ctx context.Context, gatewayID types.ID, at time.Time) (*types.GatewayRewardHistory, error) {
+	q := datastore.NewQuery((&models.DBGatewayRewardHistory{}).Entity()).FilterField("GatewayID", "=", gatewayID.String()).FilterField("Date", "<=", at).Order("-Date")
+
+	ret := models.DBGatewayRewardHistory{}
+
+	it := s.client.Run(ctx, q)
+	_, err := it.Next(&ret)
+	if err != nil {
+		if err == iterator.Done {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ret.GatewayRewardHistory()
 }
 
 // GetMapperRewardsAt implements store.Store
-func (*Store) GetMapperRewardsAt(ctx context.Context, mapperID types.ID, at time.Time) (*types.MapperRewardHistory, error) {
-	panic("unimplemented")
+func (s *Store) GetMapperRewardsAt(ctx context.Context, mapperID types.ID, at time.Time) (*types.MapperRewardHistory, error) {
+	q := datastore.NewQuery((&models.DBMapperRewardHistory{}).Entity()).FilterField("MapperID", "=", mapperID.String()).FilterField("Date", "<=", at).Order("-Date")
+
+	ret := models.DBMapperRewardHistory{}
+
+	it := s.client.Run(ctx, q)
+	_, err := it.Next(&ret)
+	if err != nil {
+		if err == iterator.Done {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ret.MapperRewardHistory()
 }
 
 // StoreAccountRewards implements store.Store
@@ -150,18 +179,23 @@ func (s *Store) StoreMapperRewards(ctx context.Context, mapperRewardHistories []
 	return nil
 }
 
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	return &Store{
+		client: opts.Client,
+	}
+}
+
+// NewStore creates a Store from the config.
 func NewStore(ctx context.Context) (*Store, error) {
-	client, err := datastore.NewClient(ctx, viper.GetString(config.CONFIG_STORE_CLOUDDATASTORE_PROJECT))
+	client, err := clouddatastore.NewClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	s := &Store{
-		client: client,
-	}
-
-	return s, nil
-
+	return New(Options{
+		Client: client,
+	}), nil
 }
 
 func (s *Store) GetAccountRewards(ctx context.Context, account common.Address, limit int, cursor string) ([]*types.AccountRewardHistory, string, error) {
@@ -245,6 +279,51 @@ func (s *Store) GetAccountRewardsBetween(ctx context.Context, account common.Add
 	return rewards, nil
 }
 
+// GetLatestAccountRewards implements store.Store
+func (s *Store) GetLatestAccountRewards(ctx context.Context, account common.Address, limit int, cursor string) ([]*types.AccountRewardHistory, []string, error) {
+	q := datastore.NewQuery((&models.DBAccountRewardHistory{}).Entity()).
+		FilterField("Account", "=", utils.AddressToString(account)).
+		Limit(limit).Order("-Date")
+
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		q = q.Start(cursorObj)
+	}
+
+	var (
+		rewards []*types.AccountRewardHistory
+		cursors []string
+		it      = s.client.Run(ctx, q)
+	)
+
+	var reward models.DBAccountRewardHistory
+	_, err := it.Next(&reward)
+	for err == nil {
+		r, derr := reward.AccountRewardHistory()
+		if derr != nil {
+			return nil, nil, derr
+		}
+		cursorObj, cerr := it.Cursor()
+		if cerr != nil {
+			return nil, nil, cerr
+		}
+
+		rewards = append(rewards, r)
+		cursors = append(cursors, cursorObj.String())
+
+		reward = models.DBAccountRewardHistory{}
+		_, err = it.Next(&reward)
+	}
+	if err != iterator.Done {
+		return nil, nil, err
+	}
+
+	return rewards, cursors, nil
+}
+
 func (s *Store) GetMapperRewards(ctx context.Context, mapperID types.ID, limit int, cursor string) ([]*types.MapperRewardHistory, string, error) {
 	q := datastore.NewQuery((&models.DBMapperRewardHistory{}).Entity()).
 		FilterField("MapperID", "=", mapperID.String()).
@@ -467,3 +546,45 @@ func (s *Store) GetMinMaxRewardsDates(ctx context.Context) (time.Time, time.Time
 
 	return min, max, nil
 }
+
+// GetLatestRewardHistories implements store.Store
+func (s *Store) GetLatestRewardHistories(ctx context.Context, limit int, cursor string) ([]*types.RewardHistory, []string, error) {
+	q := datastore.NewQuery((&models.DBRewardHistory{}).Entity()).Limit(limit).Order("-Date")
+
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		q = q.Start(cursorObj)
+	}
+
+	var (
+		histories []*types.RewardHistory
+		cursors   []string
+		it        = s.client.Run(ctx, q)
+	)
+
+	var dbRewardHistory models.DBRewardHistory
+	_, err := it.Next(&dbRewardHistory)
+	for err == nil {
+		rh, derr := dbRewardHistory.RewardHistory()
+		if derr != nil {
+			return nil, nil, derr
+		}
+		cursorObj, cerr := it.Cursor()
+		if cerr != nil {
+			return nil, nil, cerr
+		}
+
+		histories = append(histories, rh)
+		cursors = append(cursors, cursorObj.String())
+
+		_, err = it.Next(&dbRewardHistory)
+	}
+	if err != iterator.Done {
+		return nil, nil, err
+	}
+
+	return histories, cursors, nil
+}
@@ -42,6 +42,10 @@ type Store interface {
 
 	GetAccountRewards(ctx context.Context, account common.Address, limit int, cursor string) ([]*types.AccountRewardHistory, string, error)
 	GetAccountRewardsBetween(ctx context.Context, account common.Address, start, end time.Time) ([]*types.AccountRewardHistory, error)
+	// GetLatestAccountRewards returns up to limit rewards of the account from
+	// newest to oldest, starting at cursor, together with the cursor after
+	// each reward.
+	GetLatestAccountRewards(ctx context.Context, account common.Address, limit int, cursor string) ([]*types.AccountRewardHistory, []string, error)
 	GetMapperRewards(ctx context.Context, mapperID types.ID, limit int, cursor string) ([]*types.MapperRewardHistory, string, error)
 	GetMapperRewardsBetween(ctx context.Context, mapperID types.ID, start, end time.Time) ([]*types.MapperRewardHistory, error)
 	GetGatewayRewards(ctx context.Context, gatewayID types.ID, limit int, cursor string) ([]*types.GatewayRewardHistory, string, error)
@@ -50,6 +54,9 @@ type Store interface {
 	GetLatestRewardsDate(ctx context.Context) (time.Time, error)
 	GetLatestRewardsDateCached(ctx context.Context) (time.Time, error)
 	GetMinMaxRewardsDates(ctx context.Context) (time.Time, time.Time, error)
+	// GetLatestRewardHistories returns up to limit reward histories from newest
+	// to oldest, starting at cursor, together with the cursor after each.
+	GetLatestRewardHistories(ctx context.Context, limit int, cursor string) ([]*types.RewardHistory, []string, error)
 	StoreRewardHistory(ctx context.Context, rewardHistory *types.RewardHistory) error
 }
 
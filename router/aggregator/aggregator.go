@@ -22,33 +22,83 @@ import (
 	"time"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
 	"github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
 	"github.com/ThingsIXFoundation/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Options configure a RouterAggregator.
+type Options struct {
+	// Contract is the address of the router registry.
+	Contract common.Address
+	Store    store.Store
+	// PollInterval is the interval to poll the store for new events.
+	PollInterval time.Duration
+	// MaxBlockScanRange is the number of blocks to aggregate at most at once.
+	MaxBlockScanRange uint64
+	// Polls records the successful polls, optional.
+	Polls *status.Polls
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type RouterAggregator struct {
-	store           store.Store
-	contractAddress common.Address
+	store             store.Store
+	contractAddress   common.Address
+	pollInterval      time.Duration
+	maxBlockScanRange uint64
+	polls             *status.Polls
+	log               logrus.FieldLogger
+}
+
+// New creates a RouterAggregator with the given options.
+func New(opts Options) *RouterAggregator {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &RouterAggregator{
+		contractAddress:   opts.Contract,
+		store:             opts.Store,
+		pollInterval:      opts.PollInterval,
+		maxBlockScanRange: opts.MaxBlockScanRange,
+		polls:             opts.Polls,
+		log:               opts.Logger,
+	}
 }
 
+// NewRouterAggregator creates a RouterAggregator from the config.
 func NewRouterAggregator() (*RouterAggregator, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
 
-	return &RouterAggregator{
-		contractAddress: common.HexToAddress(viper.GetString(config.CONFIG_ROUTER_CONTRACT)),
-		store:           store,
-	}, nil
+	contract := config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)
+
+	polls, err := status.NewPolls("RouterAggregator", contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Contract:          contract,
+		Store:             store,
+		PollInterval:      viper.GetDuration(config.CONFIG_ROUTER_AGGREGATOR_POLL_INTERVAL),
+		MaxBlockScanRange: viper.GetUint64(config.CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE),
+		Polls:             polls,
+	}), nil
 }
 
 func (ga *RouterAggregator) Run(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"router-registry": ga.contractAddress,
 	}).Info("aggregating router events")
 
@@ -59,13 +109,16 @@ func (ga *RouterAggregator) Run(ctx context.Context) error {
 		select {
 		case <-time.After(pollInterval):
 			for {
+				start := time.Now()
 				synced, err := ga.aggregate(ctx)
+				metrics.ObserveAggregation(ctx, start, err)
 				if err != nil {
-					logrus.WithError(err).Warn("unable to integrate router events")
+					ga.log.WithError(err).Warn("unable to integrate router events")
 					break
 				}
 				if synced {
-					pollInterval = viper.GetDuration(config.CONFIG_ROUTER_AGGREGATOR_POLL_INTERVAL)
+					ga.polls.Succeeded(ctx)
+					pollInterval = ga.pollInterval
 					break
 				}
 			}
@@ -75,7 +128,11 @@ func (ga *RouterAggregator) Run(ctx context.Context) error {
 	}
 }
 
-func (ga *RouterAggregator) aggregate(ctx context.Context) (bool, error) {
+func (ga *RouterAggregator) aggregate(ctx context.Context) (synced bool, err error) {
+	ctx, span := tracing.Start(ctx, "aggregator.Aggregate",
+		attribute.String("registry.contract", ga.contractAddress.Hex()))
+	defer func() { tracing.End(span, err) }()
+
 	from, err := ga.aggregateFrom(ctx)
 	if err != nil {
 		return false, err
@@ -90,7 +147,7 @@ func (ga *RouterAggregator) aggregate(ctx context.Context) (bool, error) {
 		return synced, nil
 	}
 
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"from":     from,
 		"to":       to,
 		"contract": ga.contractAddress,
@@ -100,6 +157,10 @@ func (ga *RouterAggregator) aggregate(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	span.SetAttributes(
+		attribute.Int64("block.from", int64(from)),
+		attribute.Int64("block.to", int64(to)),
+		attribute.Int("events", len(events)))
 
 	for _, event := range events {
 		err := ga.processEvent(ctx, event)
@@ -109,6 +170,7 @@ func (ga *RouterAggregator) aggregate(ctx context.Context) (bool, error) {
 	}
 
 	ga.store.StoreCurrentBlock(ctx, "RouterAggregator", to)
+	metrics.SyncedBlock(ctx, to)
 
 	return synced, nil
 
@@ -155,7 +217,7 @@ func (ga *RouterAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 	}
 
 	if iblock == 0 && gblock == 0 || from == 0 {
-		logrus.Infof("no router-events found, waiting for first events")
+		ga.log.Infof("no router-events found, waiting for first events")
 		return 0, true, nil
 	}
 
@@ -163,8 +225,8 @@ func (ga *RouterAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 		return 0, false, fmt.Errorf("RouterIntegrator (%d) is behind on RouterAggregator (%d), this should not happen", iblock, gblock)
 	} else if iblock == gblock {
 		return gblock, true, nil
-	} else if iblock-from > viper.GetUint64(config.CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE) {
-		return from + viper.GetUint64(config.CONFIG_ROUTER_AGGREGATOR_MAX_BLOCK_SCAN_RANGE), false, nil
+	} else if iblock-from > ga.maxBlockScanRange {
+		return from + ga.maxBlockScanRange, false, nil
 	} else {
 		return iblock, true, nil
 	}
@@ -172,7 +234,7 @@ func (ga *RouterAggregator) aggregateTo(ctx context.Context, from uint64) (uint6
 }
 
 func (ga *RouterAggregator) processEvent(ctx context.Context, event *types.RouterEvent) error {
-	logrus.WithFields(logrus.Fields{
+	ga.log.WithFields(logrus.Fields{
 		"contract": event.ContractAddress,
 		"router":   event.ID,
 		"type":     event.Type,
@@ -17,28 +17,50 @@
 package api
 
 import (
+	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/router/store"
 	"github.com/go-chi/chi/v5"
+	"github.com/spf13/viper"
 )
 
+// Options configure a RouterAPI.
+type Options struct {
+	Store store.Store
+	// ChainID is the id of the chain the router registry is deployed on.
+	ChainID uint64
+}
+
 type RouterAPI struct {
-	store store.Store
+	store   store.Store
+	chainID uint64
+}
+
+// New creates a RouterAPI with the given options.
+func New(opts Options) *RouterAPI {
+	return &RouterAPI{
+		store:   opts.Store,
+		chainID: opts.ChainID,
+	}
 }
 
+// NewRouterAPI creates a RouterAPI from the config.
 func NewRouterAPI() (*RouterAPI, error) {
 	store, err := store.NewStore()
 	if err != nil {
 		return nil, err
 	}
-	return &RouterAPI{
-		store: store,
-	}, nil
+
+	return New(Options{
+		Store:   store,
+		ChainID: viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID),
+	}), nil
 }
 
 func (rapi *RouterAPI) Bind(root *chi.Mux) error {
 	root.Route("/routers", func(r chi.Router) {
 		r.Route("/v1", func(r chi.Router) {
 			r.Get("/snapshot", rapi.Snapshot)
+			r.Post("/batch", rapi.RoutersBatch)
 		})
 	})
 
@@ -0,0 +1,34 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// RoutersBatch replies the routers with the ids in the request, the ids of
+// routers that don't exist are listed as missing. At most utils.MaxBatchSize
+// ids are looked up at once.
+func (rapi *RouterAPI) RoutersBatch(w http.ResponseWriter, r *http.Request) {
+	utils.ReplyBatch(w, r, "routers", rapi.store.GetMulti, func(found []*types.Router, missing []types.ID) interface{} {
+		return &apitypes.RoutersBatchResponse{Routers: found, Missing: missing}
+	})
+}
@@ -22,11 +22,13 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/ThingsIXFoundation/data-aggregator/config"
-	"github.com/ThingsIXFoundation/http-utils/logging"
-	"github.com/spf13/viper"
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
 )
 
+const snapshotCacheControl = "public, max-age=900"
+
 // Snapshot returns the registed routers from cache.
 func (rapi *RouterAPI) Snapshot(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -35,25 +37,31 @@ func (rapi *RouterAPI) Snapshot(w http.ResponseWriter, r *http.Request) {
 	)
 	defer cancel()
 
-	routers, err := rapi.store.GetAll(ctx)
+	currentBlock, err := rapi.store.CurrentBlock(ctx, "RouterAggregator")
 	if err != nil {
-		log.WithError(err).Error("error while getting routers")
+		log.WithError(err).Error("error while sync state")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	currentBlock, err := rapi.store.CurrentBlock(ctx, "RouterAggregator")
+	// the routers only change when the aggregator processes new blocks
+	etag := utils.NewETag(r, rapi.chainID, currentBlock)
+	if etag.NotModified(w, r, snapshotCacheControl) {
+		return
+	}
+
+	routers, err := rapi.store.GetAll(ctx)
 	if err != nil {
-		log.WithError(err).Error("error while sync state")
+		log.WithError(err).Error("error while getting routers")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
 	// got router info, cache it for fast returning
-	reply, err := json.Marshal(map[string]interface{}{
-		"blockNumber": currentBlock,
-		"chainId":     viper.GetUint64(config.CONFIG_CHAINSYNC_CHAINID),
-		"routers":     routers,
+	reply, err := json.Marshal(&apitypes.RouterSnapshotResponse{
+		BlockNumber: currentBlock,
+		ChainID:     rapi.chainID,
+		Routers:     routers,
 	})
 	if err != nil {
 		log.WithError(err).Error("error while getting routers")
@@ -62,7 +70,7 @@ func (rapi *RouterAPI) Snapshot(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.Header().Set("Cache-Control", "public, max-age=900")
+	etag.Set(w, snapshotCacheControl)
 	w.WriteHeader(http.StatusOK)
 	w.Write(reply)
 }
@@ -20,22 +20,24 @@ import (
 	"context"
 
 	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, elector *leader.Elector) error {
 	if viper.GetBool(config.CONFIG_ROUTER_INGESTOR_ENABLED) {
-		ri, err := NewRouterIngestor()
-		if err != nil {
-			logrus.WithError(err).Error("error while creating router ingestor")
-			return err
-		}
+		// Only the replica holding the lease runs the router ingestor, it is created
+		// again for every term so it picks up the state left by the previous holder.
+		return elector.Run(ctx, "RouterIngestor", config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT), func(ctx context.Context) error {
+			ri, err := NewRouterIngestor()
+			if err != nil {
+				logrus.WithError(err).Error("error while creating router ingestor")
+				return err
+			}
 
-		err = ri.Run(ctx)
-		if err != nil {
-			return err
-		}
+			return ri.Run(ctx)
+		})
 	}
 
 	<-ctx.Done()
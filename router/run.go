@@ -18,37 +18,37 @@ package router
 
 import (
 	"context"
-	"errors"
 
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
 	"github.com/ThingsIXFoundation/data-aggregator/router/aggregator"
 	"github.com/ThingsIXFoundation/data-aggregator/router/ingestor"
-	"github.com/sirupsen/logrus"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/spf13/viper"
 )
 
-func Run(ctx context.Context) error {
+// Components returns the enabled router components.
+func Components(elector *leader.Elector) []supervisor.Component {
+	var components []supervisor.Component
 
-	ingestorErr := make(chan error)
-	go func() {
-		defer close(ingestorErr)
-		if err := ingestor.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("router ingestor failed")
-			ingestorErr <- err
-		}
-	}()
-
-	aggregatorErr := make(chan error)
-	go func() {
-		defer close(aggregatorErr)
-		if err := aggregator.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			logrus.WithError(err).Error("router aggregator failed")
-			aggregatorErr <- err
-		}
-	}()
-
-	select {
-	case err := <-ingestorErr:
-		return err
-	case err := <-aggregatorErr:
-		return err
+	if viper.GetBool(config.CONFIG_ROUTER_INGESTOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "router-ingestor",
+			Registry: "router",
+			Role:     supervisor.RoleIngestor,
+			Run:      func(ctx context.Context) error { return ingestor.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
 	}
+	if viper.GetBool(config.CONFIG_ROUTER_AGGREGATOR_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "router-aggregator",
+			Registry: "router",
+			Role:     supervisor.RoleAggregator,
+			Run:      func(ctx context.Context) error { return aggregator.Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+
+	return components
 }
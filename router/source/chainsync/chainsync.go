@@ -18,30 +18,87 @@ package chainsync
 
 import (
 	"context"
+	"time"
 
 	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
 	"github.com/ThingsIXFoundation/data-aggregator/config"
 	"github.com/ThingsIXFoundation/data-aggregator/router/source/interfac"
+	"github.com/ThingsIXFoundation/data-aggregator/status"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// Options configure a ChainSync source.
+type Options struct {
+	// Contract is the address of the router registry.
+	Contract common.Address
+	// Dial connects to the RPC node to sync from.
+	Dial chainsync.Dialer
+	// Confirmations is the number of blocks after which an event is
+	// confirmed, pending events aren't synced when 0.
+	Confirmations uint64
+	// MaxBlockScanRange is the number of blocks to scan at most at once.
+	MaxBlockScanRange uint64
+	// PollInterval is the interval to poll the RPC node for new events.
+	PollInterval time.Duration
+	// Polls records the successful polls of the confirmed sync, optional.
+	Polls *status.Polls
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
 type ChainSync struct {
 	pendingEventFunc    interfac.PendingEventFunc
 	eventsFunc          interfac.EventsFunc
 	setCurrentBlockFunc chainsync.SetCurrentBlockFunc
 	currentBlockFunc    chainsync.CurrentBlockFunc
 
-	contractAddress common.Address
+	contractAddress   common.Address
+	dial              chainsync.Dialer
+	confirmations     uint64
+	maxBlockScanRange uint64
+	pollInterval      time.Duration
+	polls             *status.Polls
+	log               logrus.FieldLogger
 }
 
 var _ interfac.Source = (*ChainSync)(nil)
 
-func NewChainSync() (*ChainSync, error) {
+// New creates a ChainSync source with the given options.
+func New(opts Options) *ChainSync {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
 	return &ChainSync{
-		contractAddress: common.HexToAddress(viper.GetString(config.CONFIG_ROUTER_CONTRACT)),
-	}, nil
+		contractAddress:   opts.Contract,
+		dial:              opts.Dial,
+		confirmations:     opts.Confirmations,
+		maxBlockScanRange: opts.MaxBlockScanRange,
+		pollInterval:      opts.PollInterval,
+		polls:             opts.Polls,
+		log:               opts.Logger,
+	}
+}
+
+// NewChainSync creates a ChainSync source from the config.
+func NewChainSync() (*ChainSync, error) {
+	contract := config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)
+
+	polls, err := status.NewPolls("RouterIngestor", contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Contract:          contract,
+		Dial:              chainsync.DialerFromConfig(),
+		Confirmations:     viper.GetUint64(config.CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS),
+		MaxBlockScanRange: viper.GetUint64(config.CONFIG_ROUTER_CHAINSYNC_MAX_BLOCK_SCAN_RANGE),
+		PollInterval:      viper.GetDuration(config.CONFIG_ROUTER_CHAINSYNC_POLL_INTERVAL),
+		Polls:             polls,
+	}), nil
 }
 
 // Run implements source.Source
@@ -54,13 +111,13 @@ func (cs *ChainSync) Run(ctx context.Context) error {
 	go func() {
 		defer close(finishedConfirmed)
 		if err := cs.runConfirmedSync(ctx); err != nil {
-			logrus.WithError(err).Error("error while syncing confirmed router events")
+			cs.log.WithError(err).Error("error while syncing confirmed router events")
 		}
 	}()
 	go func() {
 		defer close(finishedPending)
 		if err := cs.runPending(ctx); err != nil {
-			logrus.WithError(err).Error("error while syncing pending router events")
+			cs.log.WithError(err).Error("error while syncing pending router events")
 		}
 	}()
 
@@ -21,15 +21,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
-	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
 	router_registry "github.com/ThingsIXFoundation/router-registry-go"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	etypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
@@ -39,13 +38,13 @@ var (
 )
 
 func (cs *ChainSync) runPending(ctx context.Context) error {
-	logrus.WithFields(logrus.Fields{
+	cs.log.WithFields(logrus.Fields{
 		"registry":      cs.contractAddress,
-		"confirmations": viper.GetUint(config.CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS),
+		"confirmations": cs.confirmations,
 	}).Info("syncing pending router events from smart contract")
 
-	if viper.GetUint(config.CONFIG_ROUTER_CHAINSYNC_CONFORMATIONS) == 0 {
-		logrus.Info("confirmations 0, don't integrate pending events")
+	if cs.confirmations == 0 {
+		cs.log.Info("confirmations 0, don't integrate pending events")
 		<-ctx.Done() // wait until the shutdown signal is given
 		return nil
 	}
@@ -61,7 +60,7 @@ func (cs *ChainSync) runPending(ctx context.Context) error {
 		case <-time.After(retry):
 			lastTime = time.Now()
 			if err := cs.handlePending(ctx); err != nil {
-				logrus.WithError(err).Warn("integrate pending router events stopped")
+				cs.log.WithError(err).Warn("integrate pending router events stopped")
 			}
 			if lastTime.Before(time.Now().Add(-time.Minute)) {
 				retry = time.Millisecond
@@ -79,7 +78,7 @@ func (cs *ChainSync) runPending(ctx context.Context) error {
 
 func (cs *ChainSync) handlePending(ctx context.Context) error {
 	// dial RPC node
-	client, err := chainsync.DialRpc(ctx)
+	client, err := cs.dial(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to dial RPC node: %w", err)
 	}
@@ -108,7 +107,7 @@ func (cs *ChainSync) handlePending(ctx context.Context) error {
 
 	routerRegistry, err := router_registry.NewRouterRegistryCaller(cs.contractAddress, client)
 	if err != nil {
-		logrus.WithError(err).Error("error while creating router-registry caller")
+		cs.log.WithError(err).Error("error while creating router-registry caller")
 		return err
 	}
 
@@ -128,16 +127,22 @@ func (cs *ChainSync) handlePending(ctx context.Context) error {
 				return fmt.Errorf("unable to retrieve pending router logs")
 			}
 
-			event, err := decodeLogToRouterEvent(ctx, &l, client, routerRegistry, cs.contractAddress)
+			eventCtx, span := tracing.Start(ctx, "chainsync.PendingEvent",
+				attribute.String("tx", l.TxHash.Hex()),
+				attribute.Int64("block", int64(l.BlockNumber)))
+			event, err := cs.decodeLogToRouterEvent(eventCtx, &l, client, routerRegistry, cs.contractAddress)
 			if err != nil {
-				logrus.WithError(err).Error("error while processing pending router events")
+				cs.log.WithError(err).Error("error while processing pending router events")
+				tracing.End(span, err)
 				return err
 			}
 			if event == nil {
+				tracing.End(span, nil)
 				continue
 			}
 
-			cs.pendingEventFunc(ctx, event)
+			cs.pendingEventFunc(eventCtx, event)
+			tracing.End(span, nil)
 		}
 	}
 }
@@ -33,7 +33,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, routerRegistry *router_registry.RouterRegistryCaller, contractAddress common.Address) (*types.RouterEvent, error) {
+func (cs *ChainSync) decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethclient.Client, routerRegistry *router_registry.RouterRegistryCaller, contractAddress common.Address) (*types.RouterEvent, error) {
 	event := &types.RouterEvent{
 		Block:            log.BlockHash,
 		BlockNumber:      log.BlockNumber,
@@ -49,7 +49,7 @@ func decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethcli
 
 		router, err := routerDetails(routerRegistry, contractAddress, log.BlockNumber, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting added router details")
+			cs.log.WithError(err).Error("error while getting added router details")
 			return nil, err
 		}
 
@@ -65,12 +65,12 @@ func decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethcli
 		event.ID = types.ID(log.Topics[1])
 		routerBefore, err := routerDetails(routerRegistry, contractAddress, log.BlockNumber-1, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting before-update router details")
+			cs.log.WithError(err).Error("error while getting before-update router details")
 			return nil, err
 		}
 		routerAfter, err := routerDetails(routerRegistry, contractAddress, log.BlockNumber, event.ID)
 		if err != nil {
-			logrus.WithError(err).Error("error while getting updated router details")
+			cs.log.WithError(err).Error("error while getting updated router details")
 			return nil, err
 		}
 
@@ -93,7 +93,7 @@ func decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethcli
 		event.ID = types.ID(log.Topics[1])
 
 	default:
-		logrus.WithFields(logrus.Fields{
+		cs.log.WithFields(logrus.Fields{
 			"block":    log.BlockHash,
 			"tx":       log.TxHash,
 			"txindex":  log.TxIndex,
@@ -105,7 +105,7 @@ func decodeLogToRouterEvent(ctx context.Context, log *etypes.Log, client *ethcli
 
 	eventTime, err := chainsync.BlockTime(ctx, client, event.BlockNumber)
 	if err != nil {
-		logrus.WithError(err).Error("error while getting time of block")
+		cs.log.WithError(err).Error("error while getting time of block")
 		return nil, err
 	}
 	event.Time = eventTime
@@ -19,6 +19,8 @@ package clouddatastore
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"cloud.google.com/go/datastore"
@@ -38,46 +40,90 @@ type currentBlockCacheItem struct {
 	StoredHeight  uint64
 	CurrentHeight uint64
 	StoredTime    time.Time
+	// StoredWrites is the number of state writes when the block was stored.
+	StoredWrites uint64
 }
 
-type Store struct {
-	client *datastore.Client
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Contract is the address of the router registry the data belongs to.
+	Contract common.Address
+	// BlockCacheDuration is the time to keep the current block in cache
+	// before it's written to the store, it's written right away when the
+	// state changed.
+	BlockCacheDuration time.Duration
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
 
-	currentblockCache map[string]*currentBlockCacheItem
+type Store struct {
+	client             *datastore.Client
+	contract           common.Address
+	blockCacheDuration time.Duration
+	log                logrus.FieldLogger
+
+	currentblockCacheMu sync.Mutex
+	currentblockCache   map[string]*currentBlockCacheItem
+	// writes counts the writes that change the state, the APIs derive their
+	// ETags from the current block so it's stored right away after a change.
+	writes atomic.Uint64
 }
 
-func NewStore(ctx context.Context) (*Store, error) {
-	client, err := datastore.NewClient(ctx, viper.GetString(config.CONFIG_STORE_CLOUDDATASTORE_PROJECT))
-	if err != nil {
-		return nil, err
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
 	}
 
-	s := &Store{
-		client: client,
+	return &Store{
+		client:             opts.Client,
+		contract:           opts.Contract,
+		blockCacheDuration: opts.BlockCacheDuration,
+		log:                opts.Logger,
 
 		currentblockCache: make(map[string]*currentBlockCacheItem),
 	}
+}
 
-	return s, nil
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	return New(Options{
+		Client:             client,
+		Contract:           config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT),
+		BlockCacheDuration: viper.GetDuration(config.CONFIG_BLOCK_CACHE_DURATION),
+	}), nil
 }
 
 func (s *Store) currentBlockCacheLookup(pksk string) *currentBlockCacheItem {
+	s.currentblockCacheMu.Lock()
+	defer s.currentblockCacheMu.Unlock()
+
 	bc, ok := s.currentblockCache[pksk]
 	if !ok {
 		return nil
-	} else {
-		return bc
 	}
+
+	// Return a copy so callers can't modify the cached item without holding the lock
+	ci := *bc
+	return &ci
 }
 
 func (s *Store) currentBlockCacheStore(pksk string, ci *currentBlockCacheItem) {
+	s.currentblockCacheMu.Lock()
+	defer s.currentblockCacheMu.Unlock()
+
 	s.currentblockCache[pksk] = ci
 }
 
 // CurrentBlock implements store.Store
 func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error) {
-	contract := config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)
+	contract := s.contract
 	cb := daclouddatastore.DBCurrentBlock{
 		Process:         process,
 		ContractAddress: utils.AddressToString(contract),
@@ -92,7 +138,7 @@ func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error
 		return 0, nil
 	}
 	if err != nil {
-		logrus.WithError(err).Errorf("error while getting current block for contract %s from Cloud DataStore", contract)
+		s.log.WithError(err).Errorf("error while getting current block for contract %s from Cloud DataStore", contract)
 		return 0, err
 	}
 
@@ -102,7 +148,7 @@ func (s *Store) CurrentBlock(ctx context.Context, process string) (uint64, error
 
 // StoreCurrentBlock implements store.Store
 func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height uint64) error {
-	contract := config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)
+	contract := s.contract
 	cb := daclouddatastore.DBCurrentBlock{
 		Process:         process,
 		ContractAddress: utils.AddressToString(contract),
@@ -112,8 +158,10 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 	// Try to lookup the block cache
 	bci := s.currentBlockCacheLookup(cb.Key())
 
-	// If an item is available and it isn't too old or too far away cache it and dont' hit the database
-	if bci != nil && time.Since(bci.StoredTime) < viper.GetDuration(config.CONFIG_BLOCK_CACHE_DURATION) && height-bci.StoredHeight < 10000 {
+	writes := s.writes.Load()
+
+	// If an item is available, the state didn't change and it isn't too old or too far away cache it and dont' hit the database
+	if bci != nil && bci.StoredWrites == writes && time.Since(bci.StoredTime) < s.blockCacheDuration && height-bci.StoredHeight < 10000 {
 		bci.CurrentHeight = height
 		s.currentBlockCacheStore(cb.Key(), bci)
 		return nil
@@ -121,7 +169,7 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&cb), &cb)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing current block for contract %s in CloudDataStore", contract)
+		s.log.WithError(err).Errorf("error while storing current block for contract %s in CloudDataStore", contract)
 		return err
 	}
 
@@ -134,6 +182,7 @@ func (s *Store) StoreCurrentBlock(ctx context.Context, process string, height ui
 	bci.CurrentHeight = height
 	bci.StoredHeight = height
 	bci.StoredTime = time.Now()
+	bci.StoredWrites = writes
 	s.currentBlockCacheStore(cb.Key(), bci)
 
 	return nil
@@ -177,6 +226,24 @@ func (s *Store) EventsFromTo(ctx context.Context, from, to uint64) ([]*types.Rou
 	return events, nil
 }
 
+func (s *Store) GetEventsBetween(ctx context.Context, start, end time.Time) ([]*types.RouterEvent, error) {
+	var dbEvents []*models.DBRouterEvent
+
+	q := datastore.NewQuery((&models.DBRouterEvent{}).Entity()).FilterField("Time", ">=", start).FilterField("Time", "< ", end).Order("Time")
+
+	_, err := s.client.GetAll(ctx, q, &dbEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.RouterEvent, len(dbEvents))
+	for i, dbevent := range dbEvents {
+		events[i] = dbevent.RouterEvent()
+	}
+
+	return events, nil
+}
+
 func (s *Store) GetEvents(ctx context.Context, routerID types.ID, limit int, cursor string) ([]*types.RouterEvent, string, error) {
 	q := datastore.NewQuery((&models.DBRouterEvent{}).Entity()).FilterField("ID", "=", routerID.String()).Limit(limit + 1).Order("__key__")
 
@@ -219,13 +286,67 @@ func (s *Store) GetEvents(ctx context.Context, routerID types.ID, limit int, cur
 
 }
 
+// GetLatestEvents implements store.Store
+func (s *Store) GetLatestEvents(ctx context.Context, limit int, cursor string) ([]*types.RouterEvent, []string, error) {
+	q := datastore.NewQuery((&models.DBRouterEvent{}).Entity()).Limit(limit).Order("-Time")
+
+	return s.eventsWithCursors(ctx, q, cursor)
+}
+
+// GetEventsByOwner implements store.Store
+func (s *Store) GetEventsByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.RouterEvent, []string, error) {
+	q := datastore.NewQuery((&models.DBRouterEvent{}).Entity()).FilterField("Owner", "=", utils.AddressToString(owner)).Limit(limit).Order("-Time")
+
+	return s.eventsWithCursors(ctx, q, cursor)
+}
+
+// eventsWithCursors runs q from cursor and returns the events together with
+// the cursor after each event.
+func (s *Store) eventsWithCursors(ctx context.Context, q *datastore.Query, cursor string) ([]*types.RouterEvent, []string, error) {
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		q = q.Start(cursorObj)
+	}
+
+	var (
+		events  []*types.RouterEvent
+		cursors []string
+		it      = s.client.Run(ctx, q)
+	)
+
+	var dbEvent models.DBRouterEvent
+	_, err := it.Next(&dbEvent)
+	for err == nil {
+		cursorObj, cerr := it.Cursor()
+		if cerr != nil {
+			return nil, nil, cerr
+		}
+
+		events = append(events, dbEvent.RouterEvent())
+		cursors = append(cursors, cursorObj.String())
+
+		dbEvent = models.DBRouterEvent{}
+		_, err = it.Next(&dbEvent)
+	}
+	if err != iterator.Done {
+		return nil, nil, err
+	}
+
+	return events, cursors, nil
+}
+
 func (s *Store) StoreEvent(ctx context.Context, event *types.RouterEvent) error {
+	s.writes.Add(1)
 	dbevent := *models.NewDBRouterEvent(event)
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbevent), &dbevent)
 
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing router event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing router event in gcloud datastore")
 		return err
 	}
 
@@ -239,7 +360,7 @@ func (s *Store) StorePendingEvent(ctx context.Context, pendingEvent *types.Route
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbevent), &dbevent)
 
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing pending router event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing pending router event in gcloud datastore")
 		return err
 	}
 
@@ -251,7 +372,7 @@ func (s *Store) DeletePendingEvent(ctx context.Context, pendingEvent *types.Rout
 
 	err := s.client.Delete(ctx, clouddatastore.GetKey(dbevent))
 	if err != nil {
-		logrus.WithError(err).Errorf("error while deleting pending router event in gcloud datastore")
+		s.log.WithError(err).Errorf("error while deleting pending router event in gcloud datastore")
 		return err
 	}
 
@@ -303,11 +424,12 @@ func (s *Store) PendingEventsForOwner(ctx context.Context, owner common.Address)
 }
 
 func (s *Store) StoreHistory(ctx context.Context, history *types.RouterHistory) error {
+	s.writes.Add(1)
 	dbhistory := *models.NewDBRouterHistory(history)
 
 	_, err := s.client.Put(ctx, clouddatastore.GetKey(&dbhistory), &dbhistory)
 	if err != nil {
-		logrus.WithError(err).Errorf("error while storing router history in gcloud datastore")
+		s.log.WithError(err).Errorf("error while storing router history in gcloud datastore")
 		return err
 	}
 
@@ -316,7 +438,7 @@ func (s *Store) StoreHistory(ctx context.Context, history *types.RouterHistory)
 func (s *Store) GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.RouterHistory, error) {
 	dbhistory := &models.DBRouterHistory{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 		Time:            at,
 	}
 
@@ -342,7 +464,7 @@ func (s *Store) GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*t
 func (s *Store) Get(ctx context.Context, id types.ID) (*types.Router, error) {
 	dbrouter := models.DBRouter{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 	}
 
 	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbrouter), &dbrouter)
@@ -353,6 +475,40 @@ func (s *Store) Get(ctx context.Context, id types.ID) (*types.Router, error) {
 	return dbrouter.Router(), nil
 }
 
+// GetMulti implements store.Store
+func (s *Store) GetMulti(ctx context.Context, ids []types.ID) ([]*types.Router, error) {
+	var (
+		routers   = make([]*types.Router, len(ids))
+		keys      = make([]*datastore.Key, len(ids))
+		dbrouters = make([]models.DBRouter, len(ids))
+	)
+	for i, id := range ids {
+		dbrouters[i] = models.DBRouter{
+			ID:              id.String(),
+			ContractAddress: utils.AddressToString(s.contract),
+		}
+		keys[i] = clouddatastore.GetKey(&dbrouters[i])
+	}
+
+	// missing entities are reported per key in a MultiError
+	err := s.client.GetMulti(ctx, keys, dbrouters)
+	var errs datastore.MultiError
+	if err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+	for i := range dbrouters {
+		if errs != nil && errs[i] != nil {
+			if errors.Is(errs[i], datastore.ErrNoSuchEntity) {
+				continue
+			}
+			return nil, errs[i]
+		}
+		routers[i] = dbrouters[i].Router()
+	}
+
+	return routers, nil
+}
+
 func (s *Store) GetByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.Router, string, error) {
 	q := datastore.NewQuery((&models.DBRouter{}).Entity()).FilterField("Owner", "=", utils.AddressToString(owner)).Limit(limit + 1).Order("__key__")
 
@@ -395,6 +551,7 @@ func (s *Store) GetByOwner(ctx context.Context, owner common.Address, limit int,
 }
 
 func (s *Store) Store(ctx context.Context, router *types.Router) error {
+	s.writes.Add(1)
 	dbrouter := *models.NewDBRouter(router)
 
 	_, err := s.client.Put(ctx, daclouddatastore.GetKey(&dbrouter), &dbrouter)
@@ -405,9 +562,10 @@ func (s *Store) Store(ctx context.Context, router *types.Router) error {
 	return nil
 }
 func (s *Store) Delete(ctx context.Context, id types.ID) error {
+	s.writes.Add(1)
 	dbrouter := &models.DBRouter{
 		ID:              id.String(),
-		ContractAddress: utils.AddressToString(config.AddressFromConfig(config.CONFIG_ROUTER_CONTRACT)),
+		ContractAddress: utils.AddressToString(s.contract),
 	}
 
 	err := s.client.Delete(ctx, daclouddatastore.GetKey(dbrouter))
@@ -41,6 +41,12 @@ type Store interface {
 	EventsFromTo(ctx context.Context, from, to uint64) ([]*types.RouterEvent, error)
 	FirstEvent(ctx context.Context) (*types.RouterEvent, error)
 	GetEvents(ctx context.Context, routerID types.ID, limit int, cursor string) ([]*types.RouterEvent, string, error)
+	GetEventsBetween(ctx context.Context, start, end time.Time) ([]*types.RouterEvent, error)
+	// GetLatestEvents returns up to limit events from newest to oldest,
+	// starting at cursor, together with the cursor after each event.
+	GetLatestEvents(ctx context.Context, limit int, cursor string) ([]*types.RouterEvent, []string, error)
+	// GetEventsByOwner is GetLatestEvents for the events of owner.
+	GetEventsByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.RouterEvent, []string, error)
 
 	StoreHistory(ctx context.Context, history *types.RouterHistory) error
 	GetHistoryAt(ctx context.Context, id types.ID, at time.Time) (*types.RouterHistory, error)
@@ -48,15 +54,18 @@ type Store interface {
 	Store(ctx context.Context, router *types.Router) error
 	Delete(ctx context.Context, id types.ID) error
 	Get(ctx context.Context, id types.ID) (*types.Router, error)
+	// GetMulti returns the routers with the ids in the same order, routers
+	// that don't exist are nil.
+	GetMulti(ctx context.Context, ids []types.ID) ([]*types.Router, error)
 	GetByOwner(ctx context.Context, owner common.Address, limit int, cursor string) ([]*types.Router, string, error)
 	GetAll(ctx context.Context) ([]*types.Router, error)
 }
 
 func NewStore() (Store, error) {
-	store := viper.GetString(config.CONFIG_GATEWAY_STORE)
+	store := viper.GetString(config.CONFIG_ROUTER_STORE)
 	if store == "clouddatastore" {
 		return clouddatastore.NewStore(context.Background())
 	} else {
-		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_GATEWAY_STORE))
+		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_ROUTER_STORE))
 	}
 }
@@ -0,0 +1,334 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/chainsync"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/tracing"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const day = 24 * time.Hour
+
+// maxDaysPerChunk is the number of days whose events are loaded at once.
+const maxDaysPerChunk = 31
+
+// Options configure an Aggregator.
+type Options struct {
+	Store        store.Store
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	// Dialer connects to the RPC node to get the time of the blocks the
+	// ingestors synced to.
+	Dialer chainsync.Dialer
+	// PollInterval is the interval to check for completed days in.
+	PollInterval time.Duration
+	// Elector runs the aggregator under a lease, when nil the aggregator
+	// always runs.
+	Elector *leader.Elector
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Aggregator computes the statistics of every completed day that has no
+// statistics yet, once the ingestors stored all events of the day.
+type Aggregator struct {
+	store        store.Store
+	gatewayStore gatewayStore.Store
+	mapperStore  mapperStore.Store
+	routerStore  routerStore.Store
+	dialer       chainsync.Dialer
+	pollInterval time.Duration
+	elector      *leader.Elector
+	log          logrus.FieldLogger
+}
+
+// New creates an Aggregator with the given options.
+func New(opts Options) *Aggregator {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Aggregator{
+		store:        opts.Store,
+		gatewayStore: opts.GatewayStore,
+		mapperStore:  opts.MapperStore,
+		routerStore:  opts.RouterStore,
+		dialer:       opts.Dialer,
+		pollInterval: opts.PollInterval,
+		elector:      opts.Elector,
+		log:          opts.Logger,
+	}
+}
+
+// NewAggregator creates an Aggregator from the config that runs under the
+// lease of the elector.
+func NewAggregator(elector *leader.Elector) (*Aggregator, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	return New(Options{
+		Store:        store,
+		GatewayStore: gatewayStore,
+		MapperStore:  mapperStore,
+		RouterStore:  routerStore,
+		Dialer:       chainsync.DialerFromConfig(),
+		PollInterval: viper.GetDuration(config.CONFIG_STATS_AGGREGATOR_POLL_INTERVAL),
+		Elector:      elector,
+	}), nil
+}
+
+// Run computes the statistics of completed days until the context expires.
+// Only the replica holding the lease computes statistics.
+func (a *Aggregator) Run(ctx context.Context) error {
+	return a.elector.Run(ctx, "StatsAggregator", common.Address{}, a.run)
+}
+
+func (a *Aggregator) run(ctx context.Context) error {
+	a.log.Info("aggregating daily network statistics")
+
+	pollInterval := time.Duration(time.Second) // first run almost instant
+
+	for {
+		select {
+		case <-time.After(pollInterval):
+			if err := a.aggregate(ctx, time.Now()); err != nil {
+				a.log.WithError(err).Warn("unable to aggregate network statistics")
+			}
+			pollInterval = a.pollInterval
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// aggregate stores the statistics of the days after the last day with
+// statistics up to and including the last day that completed before now and
+// that every ingestor synced past, events of later days might not be stored
+// yet. The registries are replayed from the stored state at the end of the
+// last day with statistics, or from their first event when there is none.
+func (a *Aggregator) aggregate(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "stats.Aggregate")
+	defer func() { tracing.End(span, err) }()
+
+	state, err := a.store.LatestState(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		network *network
+		from    time.Time
+	)
+	if state != nil {
+		network, from = networkFromState(state), state.Date.Add(day)
+	} else {
+		first, err := a.firstEventDate(ctx)
+		if err != nil {
+			return err
+		}
+		if first.IsZero() {
+			a.log.Info("no registry events found, waiting for first events")
+			return nil
+		}
+		network, from = newNetwork(), first
+	}
+
+	until := utils.DateOnly(now)
+	if !from.Before(until) {
+		return nil
+	}
+
+	synced, err := a.syncedUntil(ctx)
+	if err != nil {
+		return err
+	}
+	if synced := utils.DateOnly(synced.UTC()); synced.Before(until) {
+		until = synced
+	}
+	span.SetAttributes(attribute.String("until", until.Format(time.DateOnly)))
+
+	// the days are closed in chunks to bound the number of events in memory
+	for from.Before(until) {
+		to := from.Add(maxDaysPerChunk * day)
+		if until.Before(to) {
+			to = until
+		}
+		if err := a.closeDays(ctx, network, from, to); err != nil {
+			return err
+		}
+		from = to
+	}
+
+	return nil
+}
+
+// closeDays applies the events of the days from up to to on the network and
+// stores the statistics of these days and the state of the network at the
+// end of the last one.
+func (a *Aggregator) closeDays(ctx context.Context, network *network, from, to time.Time) error {
+	gatewayEvents, err := a.gatewayStore.GetEventsBetween(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	mapperEvents, err := a.mapperStore.GetEventsBetween(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	routerEvents, err := a.routerStore.GetEventsBetween(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	sortGatewayEvents(gatewayEvents)
+	sortMapperEvents(mapperEvents)
+	sortRouterEvents(routerEvents)
+
+	var (
+		stats   []*models.NetworkStats
+		g, m, r int
+	)
+	for date := from; date.Before(to); date = date.Add(day) {
+		var (
+			end     = date.Add(day)
+			dayStat = &models.NetworkStats{Date: date}
+		)
+		for ; g < len(gatewayEvents) && gatewayEvents[g].Time.Before(end); g++ {
+			network.applyGatewayEvent(gatewayEvents[g], dayStat)
+		}
+		for ; m < len(mapperEvents) && mapperEvents[m].Time.Before(end); m++ {
+			network.applyMapperEvent(mapperEvents[m], dayStat)
+		}
+		for ; r < len(routerEvents) && routerEvents[r].Time.Before(end); r++ {
+			network.applyRouterEvent(routerEvents[r], dayStat)
+		}
+		network.count(dayStat)
+		stats = append(stats, dayStat)
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"from":   from.Format(time.DateOnly),
+		"to":     stats[len(stats)-1].Date.Format(time.DateOnly),
+		"events": len(gatewayEvents) + len(mapperEvents) + len(routerEvents),
+	}).Info("storing daily network statistics")
+
+	// the state is stored last, a failure before it recomputes the days
+	if err := a.store.StoreDailyStats(ctx, stats); err != nil {
+		return err
+	}
+	return a.store.StoreState(ctx, network.state(to.Add(-day)))
+}
+
+// syncedUntil returns the time of the oldest block the ingestors synced to,
+// the events before it are stored. It returns the zero time when an ingestor
+// hasn't synced yet.
+func (a *Aggregator) syncedUntil(ctx context.Context) (time.Time, error) {
+	var block uint64
+	for _, current := range []func() (uint64, error){
+		func() (uint64, error) { return a.gatewayStore.CurrentBlock(ctx, "GatewayIngestor") },
+		func() (uint64, error) { return a.mapperStore.CurrentBlock(ctx, "MapperIngestor") },
+		func() (uint64, error) { return a.routerStore.CurrentBlock(ctx, "RouterIngestor") },
+	} {
+		height, err := current()
+		if err != nil {
+			return time.Time{}, err
+		}
+		if height == 0 {
+			return time.Time{}, nil
+		}
+		if block == 0 || height < block {
+			block = height
+		}
+	}
+
+	client, err := a.dialer(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer client.Close()
+
+	return chainsync.BlockTime(ctx, client, block)
+}
+
+// firstEventDate returns the day of the first event in any of the
+// registries, or the zero time when there are no events yet.
+func (a *Aggregator) firstEventDate(ctx context.Context) (time.Time, error) {
+	var times []time.Time
+
+	gatewayEvent, err := a.gatewayStore.FirstEvent(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if gatewayEvent != nil {
+		times = append(times, gatewayEvent.Time)
+	}
+
+	mapperEvent, err := a.mapperStore.FirstEvent(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if mapperEvent != nil {
+		times = append(times, mapperEvent.Time)
+	}
+
+	routerEvent, err := a.routerStore.FirstEvent(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if routerEvent != nil {
+		times = append(times, routerEvent.Time)
+	}
+
+	var first time.Time
+	for _, t := range times {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+	if first.IsZero() {
+		return first, nil
+	}
+
+	return utils.DateOnly(first.UTC()), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store"
+	"github.com/go-chi/chi/v5"
+)
+
+// Options configure a StatsAPI.
+type Options struct {
+	Store store.Store
+}
+
+type StatsAPI struct {
+	store store.Store
+}
+
+// New creates a StatsAPI with the given options.
+func New(opts Options) *StatsAPI {
+	return &StatsAPI{
+		store: opts.Store,
+	}
+}
+
+// NewStatsAPI creates a StatsAPI from the config.
+func NewStatsAPI() (*StatsAPI, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Store: store,
+	}), nil
+}
+
+func (sapi *StatsAPI) Bind(root *chi.Mux) error {
+	root.Route("/stats", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Get("/network", sapi.NetworkStats)
+			r.Get("/network/latest", sapi.LatestNetworkStats)
+		})
+	})
+
+	return nil
+}
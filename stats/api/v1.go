@@ -0,0 +1,180 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/stats"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+)
+
+// MaxRangeDays is the number of days a time series can span at most.
+const MaxRangeDays = 3 * 366
+
+// defaultPeriods is the number of periods in a time series without a start.
+var defaultPeriods = map[stats.Granularity]int{
+	stats.Day:   30,
+	stats.Week:  26,
+	stats.Month: 12,
+}
+
+// NetworkStats replies the network statistics between the start and end date
+// per day, week or month. The end defaults to the last day with statistics
+// and the start to 30 days, 26 weeks or 12 months before the end. Weeks and
+// months start at the start of the period the start date falls in.
+func (sapi *StatsAPI) NetworkStats(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		query       = r.URL.Query()
+	)
+	defer cancel()
+
+	granularity, err := stats.ParseGranularity(query.Get("granularity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	latest, err := sapi.store.LatestDate(ctx)
+	if err != nil {
+		log.WithError(err).Error("error while getting latest network statistics date")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	start, end, err := parseStartEnd(query.Get("start"), query.Get("end"), latest, granularity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// statistics of a day never change once stored
+	etag := utils.NewETag(r, latest)
+	if etag.NotModified(w, r, utils.Revalidate) {
+		return
+	}
+
+	days, err := sapi.store.GetDailyStatsBetween(ctx, start, end)
+	if err != nil {
+		log.WithError(err).Error("error while getting network statistics")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := &apitypes.NetworkStatsResponse{
+		Granularity: string(granularity),
+		Stats:       stats.RollUp(days, granularity),
+	}
+	if resp.Stats == nil {
+		resp.Stats = []*models.NetworkStats{}
+	}
+
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, resp)
+}
+
+// LatestNetworkStats replies the statistics of the last day statistics are
+// computed for.
+func (sapi *StatsAPI) LatestNetworkStats(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+	)
+	defer cancel()
+
+	latest, err := sapi.store.LatestDate(ctx)
+	if err != nil {
+		log.WithError(err).Error("error while getting latest network statistics date")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if latest.IsZero() {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	etag := utils.NewETag(r, latest)
+	if etag.NotModified(w, r, utils.Revalidate) {
+		return
+	}
+
+	days, err := sapi.store.GetDailyStatsBetween(ctx, latest, latest)
+	if err != nil {
+		log.WithError(err).Error("error while getting network statistics")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if len(days) == 0 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, utils.Revalidate)
+	encoding.ReplyJSON(w, r, http.StatusOK, days[0])
+}
+
+// parseStartEnd parses the start and end dates of a time series, the start
+// is moved back to the start of its period.
+func parseStartEnd(startStr, endStr string, latest time.Time, granularity stats.Granularity) (time.Time, time.Time, error) {
+	end := latest
+	if end.IsZero() {
+		end = utils.DateOnly(time.Now())
+	}
+	if endStr != "" {
+		var err error
+		end, err = time.Parse(time.DateOnly, endStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %s", endStr)
+		}
+	}
+
+	var start time.Time
+	switch periods := defaultPeriods[granularity]; granularity {
+	case stats.Week:
+		start = end.AddDate(0, 0, -7*(periods-1))
+	case stats.Month:
+		start = end.AddDate(0, -(periods - 1), 0)
+	default:
+		start = end.AddDate(0, 0, -(periods - 1))
+	}
+	if startStr != "" {
+		var err error
+		start, err = time.Parse(time.DateOnly, startStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %s", startStr)
+		}
+	}
+	start = granularity.PeriodStart(start)
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start date is after end date")
+	}
+	if end.Sub(start) > MaxRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("range spans more than %d days", MaxRangeDays)
+	}
+
+	return start, end, nil
+}
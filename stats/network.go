@@ -0,0 +1,217 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+)
+
+type gatewayState struct {
+	frequencyPlan string
+	location      *h3light.Cell
+}
+
+// network is the state of the registries as far as the statistics need it,
+// it is built by replaying the events of the registries in order from the
+// stored state of the last day with statistics.
+type network struct {
+	gateways map[types.ID]*gatewayState
+	// mappers holds if the registered mappers are active
+	mappers map[types.ID]bool
+	routers map[types.ID]struct{}
+}
+
+func newNetwork() *network {
+	return &network{
+		gateways: make(map[types.ID]*gatewayState),
+		mappers:  make(map[types.ID]bool),
+		routers:  make(map[types.ID]struct{}),
+	}
+}
+
+// networkFromState returns the network in the stored state.
+func networkFromState(state *models.NetworkState) *network {
+	n := newNetwork()
+	for id, gateway := range state.Gateways {
+		n.gateways[types.IDFromString(id)] = &gatewayState{
+			frequencyPlan: gateway.FrequencyPlan,
+			location:      gateway.Location,
+		}
+	}
+	for id, active := range state.Mappers {
+		n.mappers[types.IDFromString(id)] = active
+	}
+	for _, id := range state.Routers {
+		n.routers[types.IDFromString(id)] = struct{}{}
+	}
+	return n
+}
+
+// state returns the state of the network to store at the end of the day.
+func (n *network) state(date time.Time) *models.NetworkState {
+	state := &models.NetworkState{
+		Date:     date,
+		Gateways: make(map[string]*models.GatewayState, len(n.gateways)),
+		Mappers:  make(map[string]bool, len(n.mappers)),
+		Routers:  make([]string, 0, len(n.routers)),
+	}
+	for id, gateway := range n.gateways {
+		state.Gateways[id.String()] = &models.GatewayState{
+			FrequencyPlan: gateway.frequencyPlan,
+			Location:      gateway.location,
+		}
+	}
+	for id, active := range n.mappers {
+		state.Mappers[id.String()] = active
+	}
+	for id := range n.routers {
+		state.Routers = append(state.Routers, id.String())
+	}
+	return state
+}
+
+// applyGatewayEvent updates the gateway state and counts the event in the
+// statistics of the day it happened on.
+func (n *network) applyGatewayEvent(event *types.GatewayEvent, day *models.NetworkStats) {
+	switch event.Type {
+	case types.GatewayOnboardedEvent:
+		if _, ok := n.gateways[event.ID]; !ok {
+			n.gateways[event.ID] = &gatewayState{}
+			day.GatewaysOnboarded++
+		}
+	case types.GatewayUpdatedEvent:
+		gateway, ok := n.gateways[event.ID]
+		if !ok {
+			return
+		}
+		if gateway.location != nil && event.NewLocation != nil && *gateway.location != *event.NewLocation {
+			day.GatewaysMoved++
+		}
+		gateway.location = event.NewLocation
+		gateway.frequencyPlan = ""
+		if event.NewFrequencyPlan != nil {
+			gateway.frequencyPlan = string(*event.NewFrequencyPlan)
+		}
+	case types.GatewayOffboardedEvent:
+		if _, ok := n.gateways[event.ID]; ok {
+			delete(n.gateways, event.ID)
+			day.GatewaysOffboarded++
+		}
+	}
+}
+
+func (n *network) applyMapperEvent(event *types.MapperEvent, day *models.NetworkStats) {
+	switch event.Type {
+	case types.MapperRegisteredEvent:
+		if _, ok := n.mappers[event.ID]; !ok {
+			n.mappers[event.ID] = false
+			day.MappersRegistered++
+		}
+	case types.MapperOnboardedEvent, types.MapperActivated:
+		if _, ok := n.mappers[event.ID]; ok {
+			n.mappers[event.ID] = true
+		}
+	case types.MapperDeactivated:
+		if _, ok := n.mappers[event.ID]; ok {
+			n.mappers[event.ID] = false
+		}
+	case types.MapperRemovedEvent:
+		if _, ok := n.mappers[event.ID]; ok {
+			delete(n.mappers, event.ID)
+			day.MappersRemoved++
+		}
+	}
+}
+
+func (n *network) applyRouterEvent(event *types.RouterEvent, day *models.NetworkStats) {
+	switch event.Type {
+	case types.RouterRegisteredEvent:
+		if _, ok := n.routers[event.ID]; !ok {
+			n.routers[event.ID] = struct{}{}
+			day.RoutersRegistered++
+		}
+	case types.RouterRemovedEvent:
+		if _, ok := n.routers[event.ID]; ok {
+			delete(n.routers, event.ID)
+			day.RoutersRemoved++
+		}
+	}
+}
+
+// count sets the counts of the registry state at the end of the day.
+func (n *network) count(day *models.NetworkStats) {
+	day.Gateways = len(n.gateways)
+	day.ActiveGateways = 0
+	day.GatewaysByFrequencyPlan = make(map[string]int)
+	day.GatewaysByRegion = make(map[string]int)
+	for _, gateway := range n.gateways {
+		if gateway.location == nil || gateway.frequencyPlan == "" {
+			continue
+		}
+		day.ActiveGateways++
+		day.GatewaysByFrequencyPlan[gateway.frequencyPlan]++
+		day.GatewaysByRegion[gateway.location.Parent(0).String()]++
+	}
+
+	day.Mappers = len(n.mappers)
+	day.ActiveMappers = 0
+	for _, active := range n.mappers {
+		if active {
+			day.ActiveMappers++
+		}
+	}
+
+	day.Routers = len(n.routers)
+}
+
+// sortGatewayEvents sorts the events in the order they were emitted, events
+// of the same block have the same time.
+func sortGatewayEvents(events []*types.GatewayEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventBefore(events[i].BlockNumber, events[i].TransactionIndex, events[i].LogIndex,
+			events[j].BlockNumber, events[j].TransactionIndex, events[j].LogIndex)
+	})
+}
+
+func sortMapperEvents(events []*types.MapperEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventBefore(events[i].BlockNumber, events[i].TransactionIndex, events[i].LogIndex,
+			events[j].BlockNumber, events[j].TransactionIndex, events[j].LogIndex)
+	})
+}
+
+func sortRouterEvents(events []*types.RouterEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventBefore(events[i].BlockNumber, events[i].TransactionIndex, events[i].LogIndex,
+			events[j].BlockNumber, events[j].TransactionIndex, events[j].LogIndex)
+	})
+}
+
+func eventBefore(blockA uint64, txA, logA uint, blockB uint64, txB, logB uint) bool {
+	if blockA != blockB {
+		return blockA < blockB
+	}
+	if txA != txB {
+		return txA < txB
+	}
+	return logA < logB
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/frequency-plan/go/frequency_plan"
+	h3light "github.com/ThingsIXFoundation/h3-light"
+	"github.com/ThingsIXFoundation/types"
+)
+
+func TestApplyEvents(t *testing.T) {
+	var (
+		date      = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+		amsterdam = h3light.LatLonToCell(52.3676, 4.9041, 10)
+		utrecht   = h3light.LatLonToCell(52.0907, 5.1214, 10)
+		region    = amsterdam.Parent(0).String()
+		eu868     = frequency_plan.EU868
+		gw1, gw2  = types.ID{1}, types.ID{2}
+		m1, m2    = types.ID{3}, types.ID{4}
+		r1, r2    = types.ID{5}, types.ID{6}
+	)
+
+	for _, tc := range []struct {
+		name     string
+		gateways []*types.GatewayEvent
+		mappers  []*types.MapperEvent
+		routers  []*types.RouterEvent
+		want     models.NetworkStats
+	}{
+		{
+			name: "no events",
+			want: models.NetworkStats{},
+		},
+		{
+			name: "gateways onboarded once",
+			gateways: []*types.GatewayEvent{
+				{Type: types.GatewayOnboardedEvent, ID: gw1},
+				{Type: types.GatewayOnboardedEvent, ID: gw2},
+				{Type: types.GatewayOnboardedEvent, ID: gw1},
+			},
+			want: models.NetworkStats{GatewaysOnboarded: 2, Gateways: 2},
+		},
+		{
+			name: "active with location and frequency plan",
+			gateways: []*types.GatewayEvent{
+				{Type: types.GatewayOnboardedEvent, ID: gw1},
+				{Type: types.GatewayOnboardedEvent, ID: gw2},
+				{Type: types.GatewayUpdatedEvent, ID: gw1, NewLocation: utils.Ptr(amsterdam), NewFrequencyPlan: &eu868},
+				{Type: types.GatewayUpdatedEvent, ID: gw2, NewLocation: utils.Ptr(utrecht)},
+			},
+			want: models.NetworkStats{
+				GatewaysOnboarded:       2,
+				Gateways:                2,
+				ActiveGateways:          1,
+				GatewaysByFrequencyPlan: map[string]int{"EU868": 1},
+				GatewaysByRegion:        map[string]int{region: 1},
+			},
+		},
+		{
+			name: "moved only from a location",
+			gateways: []*types.GatewayEvent{
+				{Type: types.GatewayOnboardedEvent, ID: gw1},
+				{Type: types.GatewayUpdatedEvent, ID: gw1, NewLocation: utils.Ptr(amsterdam), NewFrequencyPlan: &eu868},
+				{Type: types.GatewayUpdatedEvent, ID: gw1, NewLocation: utils.Ptr(utrecht), NewFrequencyPlan: &eu868},
+				{Type: types.GatewayUpdatedEvent, ID: gw1, NewLocation: utils.Ptr(utrecht), NewFrequencyPlan: &eu868},
+				{Type: types.GatewayUpdatedEvent, ID: gw2, NewLocation: utils.Ptr(amsterdam)},
+			},
+			want: models.NetworkStats{
+				GatewaysOnboarded:       1,
+				GatewaysMoved:           1,
+				Gateways:                1,
+				ActiveGateways:          1,
+				GatewaysByFrequencyPlan: map[string]int{"EU868": 1},
+				GatewaysByRegion:        map[string]int{utrecht.Parent(0).String(): 1},
+			},
+		},
+		{
+			name: "offboarded",
+			gateways: []*types.GatewayEvent{
+				{Type: types.GatewayOnboardedEvent, ID: gw1},
+				{Type: types.GatewayUpdatedEvent, ID: gw1, NewLocation: utils.Ptr(amsterdam), NewFrequencyPlan: &eu868},
+				{Type: types.GatewayOffboardedEvent, ID: gw1},
+				{Type: types.GatewayOffboardedEvent, ID: gw2},
+			},
+			want: models.NetworkStats{GatewaysOnboarded: 1, GatewaysOffboarded: 1},
+		},
+		{
+			name: "mappers",
+			mappers: []*types.MapperEvent{
+				{Type: types.MapperRegisteredEvent, ID: m1},
+				{Type: types.MapperRegisteredEvent, ID: m2},
+				{Type: types.MapperOnboardedEvent, ID: m1},
+				{Type: types.MapperActivated, ID: m2},
+				{Type: types.MapperDeactivated, ID: m2},
+				{Type: types.MapperRemovedEvent, ID: m2},
+				{Type: types.MapperActivated, ID: m2},
+			},
+			want: models.NetworkStats{MappersRegistered: 2, MappersRemoved: 1, Mappers: 1, ActiveMappers: 1},
+		},
+		{
+			name: "routers",
+			routers: []*types.RouterEvent{
+				{Type: types.RouterRegisteredEvent, ID: r1},
+				{Type: types.RouterRegisteredEvent, ID: r2},
+				{Type: types.RouterRegisteredEvent, ID: r1},
+				{Type: types.RouterRemovedEvent, ID: r2},
+			},
+			want: models.NetworkStats{RoutersRegistered: 2, RoutersRemoved: 1, Routers: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				n   = newNetwork()
+				day = &models.NetworkStats{Date: date}
+			)
+			for _, event := range tc.gateways {
+				n.applyGatewayEvent(event, day)
+			}
+			for _, event := range tc.mappers {
+				n.applyMapperEvent(event, day)
+			}
+			for _, event := range tc.routers {
+				n.applyRouterEvent(event, day)
+			}
+			n.count(day)
+
+			want := tc.want
+			want.Date = date
+			if want.GatewaysByFrequencyPlan == nil {
+				want.GatewaysByFrequencyPlan = map[string]int{}
+			}
+			if want.GatewaysByRegion == nil {
+				want.GatewaysByRegion = map[string]int{}
+			}
+			if !reflect.DeepEqual(*day, want) {
+				t.Errorf("stats %+v, want %+v", *day, want)
+			}
+
+			// the stored state replays into the same network
+			if got := networkFromState(n.state(date)); !reflect.DeepEqual(got, n) {
+				t.Errorf("network from state %+v, want %+v", got, n)
+			}
+		})
+	}
+}
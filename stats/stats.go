@@ -0,0 +1,103 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package stats computes daily network statistics by replaying the events of
+// the gateway, mapper and router registries and rolls them up into weekly and
+// monthly time series.
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+)
+
+// Granularity is the length of the periods of a time series.
+type Granularity string
+
+const (
+	Day   Granularity = "day"
+	Week  Granularity = "week"
+	Month Granularity = "month"
+)
+
+// ParseGranularity parses a granularity, it defaults to Day when s is empty.
+func ParseGranularity(s string) (Granularity, error) {
+	switch g := Granularity(s); g {
+	case "":
+		return Day, nil
+	case Day, Week, Month:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid granularity %q, expected day, week or month", s)
+	}
+}
+
+// PeriodStart returns the start of the period that date falls in, weeks start
+// on Monday.
+func (g Granularity) PeriodStart(date time.Time) time.Time {
+	y, m, d := date.Date()
+	switch g {
+	case Week:
+		offset := (int(date.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, time.UTC)
+	case Month:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// RollUp combines the daily statistics, oldest first, into statistics per
+// period. Event counts are summed over the days of a period, the other counts
+// are those of the last day of the period.
+func RollUp(days []*models.NetworkStats, g Granularity) []*models.NetworkStats {
+	if g == Day {
+		return days
+	}
+
+	var periods []*models.NetworkStats
+	for _, day := range days {
+		start := g.PeriodStart(day.Date)
+
+		var period *models.NetworkStats
+		if len(periods) > 0 && periods[len(periods)-1].Date.Equal(start) {
+			period = periods[len(periods)-1]
+		} else {
+			period = &models.NetworkStats{Date: start}
+			periods = append(periods, period)
+		}
+
+		period.GatewaysOnboarded += day.GatewaysOnboarded
+		period.GatewaysOffboarded += day.GatewaysOffboarded
+		period.GatewaysMoved += day.GatewaysMoved
+		period.MappersRegistered += day.MappersRegistered
+		period.MappersRemoved += day.MappersRemoved
+		period.RoutersRegistered += day.RoutersRegistered
+		period.RoutersRemoved += day.RoutersRemoved
+
+		period.Gateways = day.Gateways
+		period.ActiveGateways = day.ActiveGateways
+		period.GatewaysByFrequencyPlan = day.GatewaysByFrequencyPlan
+		period.GatewaysByRegion = day.GatewaysByRegion
+		period.Mappers = day.Mappers
+		period.ActiveMappers = day.ActiveMappers
+		period.Routers = day.Routers
+	}
+
+	return periods
+}
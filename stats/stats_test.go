@@ -0,0 +1,93 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+)
+
+func utcDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriodStart(t *testing.T) {
+	for _, tc := range []struct {
+		g    Granularity
+		date time.Time
+		want time.Time
+	}{
+		{Day, utcDate(2023, 7, 5), utcDate(2023, 7, 5)},
+		{Week, utcDate(2023, 7, 3), utcDate(2023, 7, 3)},
+		{Week, utcDate(2023, 7, 5), utcDate(2023, 7, 3)},
+		{Week, utcDate(2023, 7, 9), utcDate(2023, 7, 3)},
+		{Week, utcDate(2023, 1, 1), utcDate(2022, 12, 26)},
+		{Month, utcDate(2023, 7, 1), utcDate(2023, 7, 1)},
+		{Month, utcDate(2023, 7, 31), utcDate(2023, 7, 1)},
+	} {
+		t.Run(string(tc.g)+" "+tc.date.Format(time.DateOnly), func(t *testing.T) {
+			if got := tc.g.PeriodStart(tc.date); !got.Equal(tc.want) {
+				t.Errorf("period start %s, want %s", got.Format(time.DateOnly), tc.want.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+func TestRollUp(t *testing.T) {
+	// Friday 30 June up to and including Tuesday 4 July
+	days := []*models.NetworkStats{
+		{Date: utcDate(2023, 6, 30), GatewaysOnboarded: 2, Gateways: 10, ActiveGateways: 8, MappersRegistered: 1, Mappers: 3, Routers: 1, GatewaysByFrequencyPlan: map[string]int{"EU868": 8}},
+		{Date: utcDate(2023, 7, 1), GatewaysOnboarded: 1, GatewaysMoved: 1, Gateways: 11, ActiveGateways: 9, Mappers: 3, RoutersRegistered: 1, Routers: 2, GatewaysByFrequencyPlan: map[string]int{"EU868": 9}},
+		{Date: utcDate(2023, 7, 2), GatewaysOffboarded: 1, Gateways: 10, ActiveGateways: 9, Mappers: 3, ActiveMappers: 1, Routers: 2, GatewaysByFrequencyPlan: map[string]int{"EU868": 9}},
+		{Date: utcDate(2023, 7, 3), GatewaysOnboarded: 3, Gateways: 13, ActiveGateways: 10, MappersRemoved: 1, Mappers: 2, Routers: 2, GatewaysByFrequencyPlan: map[string]int{"EU868": 9, "US915": 1}},
+		{Date: utcDate(2023, 7, 4), GatewaysMoved: 2, Gateways: 13, ActiveGateways: 11, Mappers: 2, ActiveMappers: 2, RoutersRemoved: 1, Routers: 1, GatewaysByFrequencyPlan: map[string]int{"EU868": 10, "US915": 1}},
+	}
+
+	for _, tc := range []struct {
+		g    Granularity
+		want []*models.NetworkStats
+	}{
+		{Day, days},
+		{Week, []*models.NetworkStats{
+			{Date: utcDate(2023, 6, 26), GatewaysOnboarded: 3, GatewaysOffboarded: 1, GatewaysMoved: 1, Gateways: 10, ActiveGateways: 9, MappersRegistered: 1, Mappers: 3, ActiveMappers: 1, RoutersRegistered: 1, Routers: 2, GatewaysByFrequencyPlan: map[string]int{"EU868": 9}},
+			{Date: utcDate(2023, 7, 3), GatewaysOnboarded: 3, GatewaysMoved: 2, Gateways: 13, ActiveGateways: 11, MappersRemoved: 1, Mappers: 2, ActiveMappers: 2, RoutersRemoved: 1, Routers: 1, GatewaysByFrequencyPlan: map[string]int{"EU868": 10, "US915": 1}},
+		}},
+		{Month, []*models.NetworkStats{
+			{Date: utcDate(2023, 6, 1), GatewaysOnboarded: 2, Gateways: 10, ActiveGateways: 8, MappersRegistered: 1, Mappers: 3, Routers: 1, GatewaysByFrequencyPlan: map[string]int{"EU868": 8}},
+			{Date: utcDate(2023, 7, 1), GatewaysOnboarded: 4, GatewaysOffboarded: 1, GatewaysMoved: 3, Gateways: 13, ActiveGateways: 11, MappersRemoved: 1, Mappers: 2, ActiveMappers: 2, RoutersRegistered: 1, RoutersRemoved: 1, Routers: 1, GatewaysByFrequencyPlan: map[string]int{"EU868": 10, "US915": 1}},
+		}},
+	} {
+		t.Run(string(tc.g), func(t *testing.T) {
+			got := RollUp(days, tc.g)
+			if len(got) != len(tc.want) {
+				t.Fatalf("periods %d, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], tc.want[i]) {
+					t.Errorf("period %d %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+
+	if got := RollUp(nil, Week); len(got) != 0 {
+		t.Errorf("periods of no days %d, want 0", len(got))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	h3light "github.com/ThingsIXFoundation/h3-light"
+)
+
+// maxStateChunkSize is the number of bytes of the compressed state a chunk
+// holds at most, it keeps a chunk below the 1 MiB entity limit.
+const maxStateChunkSize = 900 << 10
+
+// DBNetworkState is the state of the registries at the end of the last day
+// with statistics. The gzip compressed JSON encoding of the NetworkState holds
+// every registered gateway, mapper and router, it is split over chunks.
+type DBNetworkState struct {
+	Date time.Time `datastore:",noindex"`
+	// Chunks is the number of chunks the state is split over.
+	Chunks int `datastore:",noindex"`
+}
+
+func (e *DBNetworkState) Entity() string {
+	return "NetworkState"
+}
+
+// Key is constant, only the latest state is kept.
+func (e *DBNetworkState) Key() string {
+	return "latest"
+}
+
+// NetworkState decodes the state from its chunks in order.
+func (e *DBNetworkState) NetworkState(chunks []*DBNetworkStateChunk) (*NetworkState, error) {
+	var compressed bytes.Buffer
+	for _, chunk := range chunks {
+		compressed.Write(chunk.Data)
+	}
+
+	r, err := gzip.NewReader(&compressed)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var state NetworkState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	state.Date = e.Date.UTC()
+
+	return &state, nil
+}
+
+// DBNetworkStateChunk is a part of the compressed state of a day.
+type DBNetworkStateChunk struct {
+	Date  time.Time `datastore:",noindex"`
+	Index int       `datastore:",noindex"`
+	Data  []byte    `datastore:",noindex"`
+}
+
+func (e *DBNetworkStateChunk) Entity() string {
+	return "NetworkStateChunk"
+}
+
+// Key is unique per day so the chunks of a new state don't replace the ones
+// of the stored state before it points to them.
+func (e *DBNetworkStateChunk) Key() string {
+	return fmt.Sprintf("%s-%d", e.Date.UTC().Format(time.DateOnly), e.Index)
+}
+
+func NewDBNetworkState(state *NetworkState) (*DBNetworkState, []*DBNetworkStateChunk, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		compressed = buf.Bytes()
+		chunks     []*DBNetworkStateChunk
+	)
+	for start := 0; start < len(compressed); start += maxStateChunkSize {
+		end := start + maxStateChunkSize
+		if end > len(compressed) {
+			end = len(compressed)
+		}
+		chunks = append(chunks, &DBNetworkStateChunk{
+			Date:  state.Date,
+			Index: len(chunks),
+			Data:  compressed[start:end],
+		})
+	}
+
+	return &DBNetworkState{
+		Date:   state.Date,
+		Chunks: len(chunks),
+	}, chunks, nil
+}
+
+// NetworkState is the state of the registries at the end of a day as far as
+// the statistics need it, the registered items are keyed by their id in hex.
+type NetworkState struct {
+	Date     time.Time                `json:"-"`
+	Gateways map[string]*GatewayState `json:"gateways"`
+	// Mappers holds if the registered mappers are active.
+	Mappers map[string]bool `json:"mappers"`
+	Routers []string        `json:"routers"`
+}
+
+type GatewayState struct {
+	FrequencyPlan string        `json:"frequencyPlan,omitempty"`
+	Location      *h3light.Cell `json:"location,omitempty"`
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNetworkStateChunks(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		gateways int
+		chunks   int
+	}{
+		{"empty", 0, 1},
+		{"single chunk", 1000, 1},
+		// the hashes hardly compress, 40000 gateways take more than a chunk
+		{"multiple chunks", 40000, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &NetworkState{
+				Date:     time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				Gateways: make(map[string]*GatewayState, tc.gateways),
+				Mappers:  map[string]bool{"01": true},
+				Routers:  []string{"02"},
+			}
+			for i := 0; i < tc.gateways; i++ {
+				id := sha256.Sum256([]byte(fmt.Sprint(i)))
+				state.Gateways[hex.EncodeToString(id[:])] = &GatewayState{FrequencyPlan: "EU868"}
+			}
+
+			dbState, chunks, err := NewDBNetworkState(state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dbState.Chunks != len(chunks) || len(chunks) < tc.chunks {
+				t.Fatalf("chunks %d (%d stored), want at least %d", len(chunks), dbState.Chunks, tc.chunks)
+			}
+			for i, chunk := range chunks {
+				if chunk.Index != i || len(chunk.Data) > maxStateChunkSize {
+					t.Errorf("chunk %d has index %d and %d bytes, want at most %d", i, chunk.Index, len(chunk.Data), maxStateChunkSize)
+				}
+			}
+
+			got, err := dbState.NetworkState(chunks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, state) {
+				t.Errorf("decoded state differs from the stored state")
+			}
+		})
+	}
+}
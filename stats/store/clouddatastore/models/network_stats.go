@@ -0,0 +1,114 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/api/apitypes"
+)
+
+type DBNetworkStats struct {
+	Date time.Time
+
+	GatewaysOnboarded  int `datastore:",noindex"`
+	GatewaysOffboarded int `datastore:",noindex"`
+	GatewaysMoved      int `datastore:",noindex"`
+	Gateways           int `datastore:",noindex"`
+	ActiveGateways     int `datastore:",noindex"`
+	// GatewaysByFrequencyPlan and GatewaysByRegion are JSON objects, maps
+	// can't be stored as entity properties.
+	GatewaysByFrequencyPlan string `datastore:",noindex"`
+	GatewaysByRegion        string `datastore:",noindex"`
+
+	MappersRegistered int `datastore:",noindex"`
+	MappersRemoved    int `datastore:",noindex"`
+	Mappers           int `datastore:",noindex"`
+	ActiveMappers     int `datastore:",noindex"`
+
+	RoutersRegistered int `datastore:",noindex"`
+	RoutersRemoved    int `datastore:",noindex"`
+	Routers           int `datastore:",noindex"`
+}
+
+func (e *DBNetworkStats) Entity() string {
+	return "NetworkStats"
+}
+
+func (e *DBNetworkStats) Key() string {
+	return e.Date.Format(time.DateOnly)
+}
+
+func (e *DBNetworkStats) NetworkStats() (*NetworkStats, error) {
+	stats := &NetworkStats{
+		Date:               e.Date.UTC(),
+		GatewaysOnboarded:  e.GatewaysOnboarded,
+		GatewaysOffboarded: e.GatewaysOffboarded,
+		GatewaysMoved:      e.GatewaysMoved,
+		Gateways:           e.Gateways,
+		ActiveGateways:     e.ActiveGateways,
+		MappersRegistered:  e.MappersRegistered,
+		MappersRemoved:     e.MappersRemoved,
+		Mappers:            e.Mappers,
+		ActiveMappers:      e.ActiveMappers,
+		RoutersRegistered:  e.RoutersRegistered,
+		RoutersRemoved:     e.RoutersRemoved,
+		Routers:            e.Routers,
+	}
+
+	if err := json.Unmarshal([]byte(e.GatewaysByFrequencyPlan), &stats.GatewaysByFrequencyPlan); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(e.GatewaysByRegion), &stats.GatewaysByRegion); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func NewDBNetworkStats(stats *NetworkStats) (*DBNetworkStats, error) {
+	byFrequencyPlan, err := json.Marshal(stats.GatewaysByFrequencyPlan)
+	if err != nil {
+		return nil, err
+	}
+	byRegion, err := json.Marshal(stats.GatewaysByRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBNetworkStats{
+		Date:                    stats.Date,
+		GatewaysOnboarded:       stats.GatewaysOnboarded,
+		GatewaysOffboarded:      stats.GatewaysOffboarded,
+		GatewaysMoved:           stats.GatewaysMoved,
+		Gateways:                stats.Gateways,
+		ActiveGateways:          stats.ActiveGateways,
+		GatewaysByFrequencyPlan: string(byFrequencyPlan),
+		GatewaysByRegion:        string(byRegion),
+		MappersRegistered:       stats.MappersRegistered,
+		MappersRemoved:          stats.MappersRemoved,
+		Mappers:                 stats.Mappers,
+		ActiveMappers:           stats.ActiveMappers,
+		RoutersRegistered:       stats.RoutersRegistered,
+		RoutersRemoved:          stats.RoutersRemoved,
+		Routers:                 stats.Routers,
+	}, nil
+}
+
+// NetworkStats are the network KPIs of a period as the API returns them.
+type NetworkStats = apitypes.NetworkStats
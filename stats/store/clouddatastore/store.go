@@ -0,0 +1,219 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	daclouddatastore "github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	"github.com/sirupsen/logrus"
+)
+
+// maxPutMultiEntities is the number of entities Cloud DataStore accepts in a
+// single PutMulti call.
+const maxPutMultiEntities = 500
+
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Store struct {
+	client *datastore.Client
+	log    logrus.FieldLogger
+}
+
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Client: client,
+	}), nil
+}
+
+// StoreDailyStats implements store.Store
+func (s *Store) StoreDailyStats(ctx context.Context, stats []*models.NetworkStats) error {
+	for start := 0; start < len(stats); start += maxPutMultiEntities {
+		end := start + maxPutMultiEntities
+		if end > len(stats) {
+			end = len(stats)
+		}
+
+		keys := make([]*datastore.Key, 0, end-start)
+		dbStats := make([]*models.DBNetworkStats, 0, end-start)
+		for _, day := range stats[start:end] {
+			dbDay, err := models.NewDBNetworkStats(day)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, daclouddatastore.GetKey(dbDay))
+			dbStats = append(dbStats, dbDay)
+		}
+
+		_, err := s.client.PutMulti(ctx, keys, dbStats)
+		if err != nil {
+			s.log.WithError(err).Errorf("error while storing network statistics from %s in Cloud DataStore", stats[start].Date.Format(time.DateOnly))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LatestDate implements store.Store
+func (s *Store) LatestDate(ctx context.Context) (time.Time, error) {
+	q := datastore.NewQuery((&models.DBNetworkStats{}).Entity()).Order("-Date").Limit(1)
+
+	var dbStats []*models.DBNetworkStats
+	_, err := s.client.GetAll(ctx, q, &dbStats)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(dbStats) == 0 {
+		return time.Time{}, nil
+	}
+
+	return dbStats[0].Date.UTC(), nil
+}
+
+// StoreState implements store.Store
+func (s *Store) StoreState(ctx context.Context, state *models.NetworkState) error {
+	dbState, chunks, err := models.NewDBNetworkState(state)
+	if err != nil {
+		return err
+	}
+
+	var previous models.DBNetworkState
+	err = s.client.Get(ctx, daclouddatastore.GetKey(&previous), &previous)
+	if err != nil && !errors.Is(err, datastore.ErrNoSuchEntity) {
+		return err
+	}
+
+	// the chunks are stored before the state that points to them
+	for start := 0; start < len(chunks); start += maxPutMultiEntities {
+		end := start + maxPutMultiEntities
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+
+		keys := make([]*datastore.Key, 0, end-start)
+		for _, chunk := range chunks[start:end] {
+			keys = append(keys, daclouddatastore.GetKey(chunk))
+		}
+
+		if _, err := s.client.PutMulti(ctx, keys, chunks[start:end]); err != nil {
+			s.log.WithError(err).Errorf("error while storing network state chunks of %s in Cloud DataStore", state.Date.Format(time.DateOnly))
+			return err
+		}
+	}
+
+	_, err = s.client.Put(ctx, daclouddatastore.GetKey(dbState), dbState)
+	if err != nil {
+		s.log.WithError(err).Errorf("error while storing network state of %s in Cloud DataStore", state.Date.Format(time.DateOnly))
+		return err
+	}
+
+	// the chunks of the previous state that weren't replaced are unused
+	var unused []*datastore.Key
+	for i := 0; i < previous.Chunks; i++ {
+		if previous.Date.Equal(dbState.Date) && i < dbState.Chunks {
+			continue
+		}
+		unused = append(unused, daclouddatastore.GetKey(&models.DBNetworkStateChunk{Date: previous.Date, Index: i}))
+	}
+	if len(unused) > 0 {
+		if err := s.client.DeleteMulti(ctx, unused); err != nil {
+			s.log.WithError(err).Warn("unable to delete chunks of previous network state")
+		}
+	}
+
+	return nil
+}
+
+// LatestState implements store.Store
+func (s *Store) LatestState(ctx context.Context) (*models.NetworkState, error) {
+	var dbState models.DBNetworkState
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbState), &dbState)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		keys   = make([]*datastore.Key, dbState.Chunks)
+		chunks = make([]*models.DBNetworkStateChunk, dbState.Chunks)
+	)
+	for i := range chunks {
+		keys[i] = daclouddatastore.GetKey(&models.DBNetworkStateChunk{Date: dbState.Date, Index: i})
+		chunks[i] = &models.DBNetworkStateChunk{}
+	}
+	if err := s.client.GetMulti(ctx, keys, chunks); err != nil {
+		return nil, err
+	}
+
+	return dbState.NetworkState(chunks)
+}
+
+// GetDailyStatsBetween implements store.Store
+func (s *Store) GetDailyStatsBetween(ctx context.Context, start, end time.Time) ([]*models.NetworkStats, error) {
+	q := datastore.NewQuery((&models.DBNetworkStats{}).Entity()).
+		FilterField("Date", ">=", start).
+		FilterField("Date", "<=", end).
+		Order("Date")
+
+	var dbStats []*models.DBNetworkStats
+	_, err := s.client.GetAll(ctx, q, &dbStats)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*models.NetworkStats, len(dbStats))
+	for i, dbDay := range dbStats {
+		day, err := dbDay.NetworkStats()
+		if err != nil {
+			return nil, err
+		}
+		stats[i] = day
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/stats/store/clouddatastore/models"
+	"github.com/spf13/viper"
+)
+
+type Store interface {
+	// StoreDailyStats stores the statistics of days, statistics that were
+	// stored before for the same day are replaced.
+	StoreDailyStats(ctx context.Context, stats []*models.NetworkStats) error
+	// LatestDate returns the last day statistics are stored for, or the zero
+	// time when none are stored yet.
+	LatestDate(ctx context.Context) (time.Time, error)
+	// StoreState stores the state of the registries at the end of its date,
+	// it replaces the stored state.
+	StoreState(ctx context.Context, state *models.NetworkState) error
+	// LatestState returns the stored state of the registries, or nil when
+	// none is stored yet.
+	LatestState(ctx context.Context) (*models.NetworkState, error)
+	// GetDailyStatsBetween returns the statistics of the days from start up
+	// to and including end, oldest first.
+	GetDailyStatsBetween(ctx context.Context, start, end time.Time) ([]*models.NetworkStats, error)
+}
+
+func NewStore() (Store, error) {
+	store := viper.GetString(config.CONFIG_STATS_STORE)
+	if store == "clouddatastore" {
+		return clouddatastore.NewStore(context.Background())
+	} else {
+		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_STATS_STORE))
+	}
+}
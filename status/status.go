@@ -0,0 +1,88 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package status records when the ingestors, aggregators and cachers last
+// polled successfully, so the API can report how far behind they are even
+// when they run in another process.
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/status/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure Polls.
+type Options struct {
+	Store store.Store
+	// Process is the name of the process that polls, e.g. GatewayIngestor.
+	Process string
+	// Contract is the registry the process polls for.
+	Contract common.Address
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Polls records the successful polls of a process.
+type Polls struct {
+	store    store.Store
+	process  string
+	contract common.Address
+	log      logrus.FieldLogger
+}
+
+// New creates Polls with the given options.
+func New(opts Options) *Polls {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Polls{
+		store:    opts.Store,
+		process:  opts.Process,
+		contract: opts.Contract,
+		log:      opts.Logger,
+	}
+}
+
+// NewPolls creates Polls for the process on the contract from the config.
+func NewPolls(process string, contract common.Address) (*Polls, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Store:    store,
+		Process:  process,
+		Contract: contract,
+	}), nil
+}
+
+// Succeeded records a successful poll. Failures are only logged so they never
+// stall the process, recording on nil Polls is a no-op.
+func (p *Polls) Succeeded(ctx context.Context) {
+	if p == nil {
+		return
+	}
+
+	if err := p.store.StorePoll(ctx, p.process, p.contract, time.Now()); err != nil {
+		p.log.WithError(err).WithField("process", p.process).Warn("unable to record poll")
+	}
+}
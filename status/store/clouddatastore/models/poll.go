@@ -14,26 +14,25 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package api
+package models
 
 import (
-	h3light "github.com/ThingsIXFoundation/h3-light"
-	"github.com/ThingsIXFoundation/types"
+	"fmt"
+	"time"
 )
 
-type AssumedCoverageHexContainer struct {
-	Hexes []h3light.Cell `json:"hexes,omitempty"`
+// DBPoll is the last successful poll of a process, e.g. an ingestor polling
+// the RPC node or a cacher refreshing the cache.
+type DBPoll struct {
+	Process         string
+	ContractAddress string
+	At              time.Time `datastore:",noindex"`
 }
 
-type CoverageHexContainer struct {
-	Hexes []*types.CoverageHistory `json:"hexes,omitempty"`
+func (e *DBPoll) Entity() string {
+	return "Poll"
 }
 
-type UnverifiedCoverageHexContainer struct {
-	Hexes []*types.UnverifiedMappingRecord `json:"hexes,omitempty"`
-}
-
-type MinMaxCoverageDates struct {
-	Min string `json:"min"`
-	Max string `json:"max"`
+func (e *DBPoll) Key() string {
+	return fmt.Sprintf("%s.%s", e.Process, e.ContractAddress)
 }
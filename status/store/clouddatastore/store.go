@@ -0,0 +1,101 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	daclouddatastore "github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/status/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Store struct {
+	client *datastore.Client
+	log    logrus.FieldLogger
+}
+
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Client: client,
+	}), nil
+}
+
+// StorePoll implements store.Store
+func (s *Store) StorePoll(ctx context.Context, process string, contract common.Address, at time.Time) error {
+	poll := models.DBPoll{
+		Process:         process,
+		ContractAddress: utils.AddressToString(contract),
+		At:              at,
+	}
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(&poll), &poll)
+	if err != nil {
+		s.log.WithError(err).Errorf("error while storing poll of %s for contract %s in Cloud DataStore", process, contract)
+		return err
+	}
+
+	return nil
+}
+
+// LastPoll implements store.Store
+func (s *Store) LastPoll(ctx context.Context, process string, contract common.Address) (time.Time, error) {
+	poll := models.DBPoll{
+		Process:         process,
+		ContractAddress: utils.AddressToString(contract),
+	}
+
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&poll), &poll)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return poll.At, nil
+}
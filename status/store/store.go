@@ -0,0 +1,43 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/status/store/clouddatastore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+type Store interface {
+	StorePoll(ctx context.Context, process string, contract common.Address, at time.Time) error
+	// LastPoll returns the zero time when the process never polled.
+	LastPoll(ctx context.Context, process string, contract common.Address) (time.Time, error)
+}
+
+func NewStore() (Store, error) {
+	store := viper.GetString(config.CONFIG_STATUS_STORE)
+	if store == "clouddatastore" {
+		return clouddatastore.NewStore(context.Background())
+	} else {
+		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_STATUS_STORE))
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/spf13/viper"
+)
+
+type State string
+
+const (
+	// StateStarting is the state of a component that was started but didn't
+	// run long enough yet to be considered stable.
+	StateStarting State = "starting"
+	// StateRunning is the state of a component that runs stable.
+	StateRunning State = "running"
+	// StateDegraded is the state of a component that failed and is being
+	// restarted.
+	StateDegraded State = "degraded"
+	// StateFailed is the state of a component that failed more often than its
+	// restart policy allows, it won't be restarted anymore.
+	StateFailed State = "failed"
+	// StateStopped is the state of a component that stopped because the
+	// supervisor was shut down.
+	StateStopped State = "stopped"
+)
+
+type Role string
+
+const (
+	RoleIngestor   Role = "ingestor"
+	RoleAggregator Role = "aggregator"
+	RoleCacher     Role = "cacher"
+	RoleAPI        Role = "api"
+)
+
+// RestartPolicy determines how a component is restarted after it failed.
+type RestartPolicy struct {
+	// InitialBackoff is the time to wait before the first restart, it is
+	// doubled for every consecutive failure up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxRestarts is the number of consecutive restarts after which the
+	// component is marked as failed, 0 restarts the component forever.
+	MaxRestarts int
+	// StableAfter is the time a component must run before it is considered
+	// running and the consecutive failure count is reset.
+	StableAfter time.Duration
+}
+
+// DefaultRestartPolicy returns the restart policy from the config.
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialBackoff: viper.GetDuration(config.CONFIG_SUPERVISOR_INITIAL_BACKOFF),
+		MaxBackoff:     viper.GetDuration(config.CONFIG_SUPERVISOR_MAX_BACKOFF),
+		MaxRestarts:    viper.GetInt(config.CONFIG_SUPERVISOR_MAX_RESTARTS),
+		StableAfter:    viper.GetDuration(config.CONFIG_SUPERVISOR_STABLE_AFTER),
+	}
+}
+
+// Component is a long running part of the service that is supervised.
+type Component struct {
+	// Name uniquely identifies the component, e.g. gateway-ingestor.
+	Name     string
+	Registry string
+	Role     Role
+	// Run must block until ctx is cancelled or the component fails.
+	Run    func(ctx context.Context) error
+	Policy RestartPolicy
+	// Critical components stop the supervisor when they failed, the process
+	// can't do its job without them and must exit so it gets restarted.
+	Critical bool
+}
+
+// Status is the state of a component at some point in time.
+type Status struct {
+	Name      string    `json:"name"`
+	Registry  string    `json:"registry"`
+	Role      Role      `json:"role"`
+	State     State     `json:"state"`
+	Restarts  int       `json:"restarts"`
+	LastError string    `json:"lastError,omitempty"`
+	Since     time.Time `json:"since"`
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrAllComponentsFailed     = errors.New("all components failed")
+	ErrCriticalComponentFailed = errors.New("critical component failed")
+)
+
+type supervised struct {
+	component Component
+	status    Status
+	// attempt is incremented every time the component stops, it prevents a
+	// late stable timer from marking a stopped attempt as running.
+	attempt int
+}
+
+// Supervisor runs components and restarts them according to their restart
+// policy when they fail. A failing component doesn't affect other components.
+type Supervisor struct {
+	mu         sync.RWMutex
+	components []*supervised
+}
+
+func NewSupervisor(components ...Component) *Supervisor {
+	s := &Supervisor{}
+	s.Add(components...)
+	return s
+}
+
+// Add adds components to the supervisor, it must be called before Run.
+func (s *Supervisor) Add(components ...Component) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range components {
+		s.components = append(s.components, &supervised{
+			component: c,
+			status: Status{
+				Name:     c.Name,
+				Registry: c.Registry,
+				Role:     c.Role,
+				State:    StateStarting,
+				Since:    time.Now(),
+			},
+		})
+	}
+}
+
+// Status returns the status of all supervised components.
+func (s *Supervisor) Status() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, len(s.components))
+	for i, c := range s.components {
+		statuses[i] = c.status
+	}
+	return statuses
+}
+
+// ComponentStatus returns the status of the component with the given name.
+func (s *Supervisor) ComponentStatus(name string) (Status, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.components {
+		if c.component.Name == name {
+			return c.status, true
+		}
+	}
+	return Status{}, false
+}
+
+// Run runs all components until ctx is cancelled. It returns
+// ErrAllComponentsFailed when every component failed and
+// ErrCriticalComponentFailed when a critical component failed, in which case
+// the other components are stopped.
+func (s *Supervisor) Run(ctx context.Context) error {
+	var (
+		wg          sync.WaitGroup
+		failed      = make(chan struct{}, len(s.components))
+		criticalErr = make(chan error, len(s.components))
+	)
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	for _, c := range s.components {
+		wg.Add(1)
+		go func(c *supervised) {
+			defer wg.Done()
+			if s.supervise(runCtx, c) != StateFailed {
+				return
+			}
+			failed <- struct{}{}
+			if c.component.Critical {
+				criticalErr <- fmt.Errorf("%w: %s", ErrCriticalComponentFailed, c.component.Name)
+				stop()
+			}
+		}(c)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if len(criticalErr) > 0 {
+		return <-criticalErr
+	}
+	if len(failed) == len(s.components) && len(s.components) > 0 {
+		return ErrAllComponentsFailed
+	}
+	return nil
+}
+
+// supervise runs the component and restarts it until ctx is cancelled or the
+// restart policy gives up. It returns the final state of the component.
+func (s *Supervisor) supervise(ctx context.Context, c *supervised) State {
+	var (
+		policy   = c.component.Policy
+		backoff  = policy.InitialBackoff
+		failures = 0
+	)
+
+	for {
+		s.mu.RLock()
+		attempt := c.attempt
+		s.mu.RUnlock()
+
+		started := time.Now()
+		stable := time.AfterFunc(policy.StableAfter, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if c.attempt == attempt {
+				s.setStateLocked(c, StateRunning, nil)
+			}
+		})
+
+		err := run(ctx, c.component)
+		stable.Stop()
+
+		s.mu.Lock()
+		c.attempt++
+		s.mu.Unlock()
+
+		if ctx.Err() != nil {
+			s.setState(c, StateStopped, nil)
+			return StateStopped
+		}
+
+		if err == nil {
+			err = fmt.Errorf("component stopped unexpectedly")
+		}
+
+		// A component that ran stable before failing starts with a clean slate
+		if time.Since(started) >= policy.StableAfter {
+			failures = 0
+			backoff = policy.InitialBackoff
+		}
+		failures++
+
+		if policy.MaxRestarts > 0 && failures > policy.MaxRestarts {
+			logrus.WithError(err).Errorf("component %s failed %d times, giving up", c.component.Name, failures)
+			s.setState(c, StateFailed, err)
+			return StateFailed
+		}
+
+		logrus.WithError(err).Warnf("component %s failed, restarting in %s", c.component.Name, backoff)
+		s.setState(c, StateDegraded, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			s.setState(c, StateStopped, nil)
+			return StateStopped
+		}
+
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+
+		// the component is back up, it is starting again until it ran stable
+		s.mu.Lock()
+		c.status.Restarts++
+		s.setStateLocked(c, StateStarting, nil)
+		s.mu.Unlock()
+	}
+}
+
+func (s *Supervisor) setState(c *supervised, state State, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setStateLocked(c, state, err)
+}
+
+func (s *Supervisor) setStateLocked(c *supervised, state State, err error) {
+	if c.status.State != state {
+		c.status.State = state
+		c.status.Since = time.Now()
+	}
+	if err != nil {
+		c.status.LastError = err.Error()
+	}
+}
+
+// run runs the component and turns a panic into an error so it can be
+// restarted like any other failure. The metrics the component records are
+// labelled with its registry and role.
+func run(ctx context.Context, c Component) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return c.Run(metrics.WithLabels(ctx, c.Registry, string(c.Role)))
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitForState waits until the component with the given name is in state.
+func waitForState(t *testing.T, s *Supervisor, name string, state State) Status {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, ok := s.ComponentStatus(name); ok && status.State == state {
+			return status
+		}
+		time.Sleep(time.Millisecond)
+	}
+	status, _ := s.ComponentStatus(name)
+	t.Fatalf("component %s is %s, want %s", name, status.State, state)
+	return status
+}
+
+func TestRestartAfterFailure(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		fail        = make(chan struct{})
+		restarted   = make(chan struct{})
+		attempts    = 0
+	)
+	defer cancel()
+
+	s := NewSupervisor(Component{
+		Name: "flaky",
+		Run: func(ctx context.Context) error {
+			attempts++
+			if attempts == 1 {
+				<-fail
+				return errors.New("boom")
+			}
+			close(restarted)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Policy: RestartPolicy{
+			InitialBackoff: 50 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			StableAfter:    time.Hour,
+		},
+	})
+
+	if status := s.Status(); len(status) != 1 || status[0].State != StateStarting {
+		t.Fatalf("initial status %+v, want a single starting component", status)
+	}
+
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	close(fail)
+	status := waitForState(t, s, "flaky", StateDegraded)
+	if status.LastError != "boom" || status.Restarts != 0 {
+		t.Errorf("failed status %+v, want error boom and no restarts", status)
+	}
+
+	<-restarted
+	status = waitForState(t, s, "flaky", StateStarting)
+	if status.LastError != "boom" || status.Restarts != 1 {
+		t.Errorf("restarted status %+v, want error boom and 1 restart", status)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("run returned %v", err)
+	}
+	waitForState(t, s, "flaky", StateStopped)
+}
+
+func TestGiveUpAfterMaxRestarts(t *testing.T) {
+	s := NewSupervisor(Component{
+		Name: "broken",
+		Run: func(ctx context.Context) error {
+			return errors.New("boom")
+		},
+		Policy: RestartPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			MaxRestarts:    2,
+			StableAfter:    time.Hour,
+		},
+	})
+
+	if err := s.Run(context.Background()); !errors.Is(err, ErrAllComponentsFailed) {
+		t.Fatalf("run returned %v, want %v", err, ErrAllComponentsFailed)
+	}
+
+	status, _ := s.ComponentStatus("broken")
+	if status.State != StateFailed || status.Restarts != 2 || status.LastError != "boom" {
+		t.Errorf("status %+v, want failed after 2 restarts", status)
+	}
+}
+
+func TestCriticalFailureStopsSupervisor(t *testing.T) {
+	policy := RestartPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		MaxRestarts:    1,
+		StableAfter:    time.Hour,
+	}
+
+	s := NewSupervisor(Component{
+		Name: "api",
+		Run: func(ctx context.Context) error {
+			return errors.New("boom")
+		},
+		Policy:   policy,
+		Critical: true,
+	}, Component{
+		Name: "ingestor",
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Policy: policy,
+	})
+
+	done := make(chan error)
+	go func() { done <- s.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrCriticalComponentFailed) {
+			t.Fatalf("run returned %v, want %v", err, ErrCriticalComponentFailed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("supervisor kept running after a critical component failed")
+	}
+
+	if status, _ := s.ComponentStatus("api"); status.State != StateFailed {
+		t.Errorf("api is %s, want %s", status.State, StateFailed)
+	}
+	if status, _ := s.ComponentStatus("ingestor"); status.State != StateStopped {
+		t.Errorf("ingestor is %s, want %s", status.State, StateStopped)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ThingsIXFoundation/types"
+)
+
+// MaxBatchSize is the maximum number of ids a batch lookup accepts.
+const MaxBatchSize = 100
+
+var idPattern = regexp.MustCompile(`^(0[xX])?[0-9a-fA-F]{64}$`)
+
+// BatchRequest is a lookup of gateways, mappers or routers by id.
+type BatchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// UniqueIDs returns the ids of the request without duplicates in the order
+// they were given. It fails when an id is invalid or when the request holds
+// no ids or more than MaxBatchSize, duplicates included.
+func (req *BatchRequest) UniqueIDs() ([]types.ID, error) {
+	if len(req.IDs) == 0 {
+		return nil, errors.New("no ids given")
+	}
+	if len(req.IDs) > MaxBatchSize {
+		return nil, fmt.Errorf("at most %d ids can be looked up at once", MaxBatchSize)
+	}
+
+	var (
+		ids  = make([]types.ID, 0, len(req.IDs))
+		seen = make(map[types.ID]bool, len(req.IDs))
+	)
+	for _, s := range req.IDs {
+		if !idPattern.MatchString(s) {
+			return nil, fmt.Errorf("invalid id %q", s)
+		}
+		id := types.IDFromString(s)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// GetMultiFunc returns the items with the ids in the same order, items that
+// don't exist are nil.
+type GetMultiFunc[T any] func(ctx context.Context, ids []types.ID) ([]*T, error)
+
+// ReplyBatch replies the items with the ids in the BatchRequest of r, reply
+// makes the response from the items that exist and the ids of the items that
+// don't. The name of the items is used in the log.
+func ReplyBatch[T any](w http.ResponseWriter, r *http.Request, name string, getMulti GetMultiFunc[T], reply func(found []*T, missing []types.ID) interface{}) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		req         BatchRequest
+	)
+	defer cancel()
+
+	if err := encoding.DecodeHTTPJSONBody(w, r, &req); err != nil {
+		log.WithError(err).Debug("unable to decode batch request")
+		http.Error(w, err.Msg, err.Status)
+		return
+	}
+
+	ids, err := req.UniqueIDs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := getMulti(ctx, ids)
+	if err != nil {
+		log.WithError(err).Errorf("error while getting %s", name)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	var (
+		found   = make([]*T, 0, len(ids))
+		missing = make([]types.ID, 0)
+	)
+	for i, item := range items {
+		if item == nil {
+			missing = append(missing, ids[i])
+		} else {
+			found = append(found, item)
+		}
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, reply(found, missing))
+}
+
+// GetMultiCached looks the ids up in the cache and the ones it doesn't hold
+// in the store. The cache is refreshed periodically, items registered since
+// aren't in it. The store is used for all ids when the cache is nil or fails.
+func GetMultiCached[T any](ctx context.Context, ids []types.ID, cache, store GetMultiFunc[T]) ([]*T, error) {
+	items := make([]*T, len(ids))
+	if cache != nil {
+		cached, err := cache(ctx, ids)
+		if err != nil {
+			logging.WithContext(ctx).WithError(err).Warn("unable to get items from cache, falling back to the store")
+		} else {
+			items = cached
+		}
+	}
+
+	var (
+		uncached []types.ID
+		indices  []int
+	)
+	for i, item := range items {
+		if item == nil {
+			uncached = append(uncached, ids[i])
+			indices = append(indices, i)
+		}
+	}
+	if len(uncached) == 0 {
+		return items, nil
+	}
+
+	stored, err := store(ctx, uncached)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range stored {
+		items[indices[i]] = item
+	}
+
+	return items, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Revalidate is the cache control for replies that caches may store but must
+// revalidate with their ETag before reuse.
+const Revalidate = "no-cache"
+
+// ETag is a weak entity tag for a reply.
+type ETag string
+
+// NewETag derives an ETag from the versions of the data the reply is built
+// from, such as the block an aggregator synced to, the request URL and the
+// requested format. The release is included so a change in the encoding
+// invalidates cached replies.
+func NewETag(r *http.Request, versions ...interface{}) ETag {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s", Version(), r.URL.RequestURI(), FormatFromRequest(r))
+	for _, v := range versions {
+		fmt.Fprintf(h, "|%v", v)
+	}
+	return ETag(`W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`)
+}
+
+// NotModified replies 304 when the copy the client has, as identified by the
+// If-None-Match header, is still current. The cache control must be the same
+// as the one of the full reply because a 304 updates the cached headers.
+func (e ETag) NotModified(w http.ResponseWriter, r *http.Request, cacheControl string) bool {
+	if !e.matches(r.Header.Get("If-None-Match")) {
+		return false
+	}
+
+	e.Set(w, cacheControl)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// Set sets the ETag and cache control of a full reply. The reply varies with
+// the Accept header that can select the format.
+func (e ETag) Set(w http.ResponseWriter, cacheControl string) {
+	w.Header().Set("ETag", string(e))
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Add("Vary", "Accept")
+}
+
+// matches uses the weak comparison of If-None-Match.
+func (e ETag) matches(ifNoneMatch string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(string(e), "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ParseEUI parses a 64-bit EUI, such as the EUI of a LoRa gateway, written as
+// 16 hex digits with an optional 0x prefix and optional : or - separators. It
+// returns the EUI as 16 lower case hex digits.
+func ParseEUI(s string) (string, error) {
+	eui := strings.ToLower(strings.TrimSpace(s))
+	eui = strings.TrimPrefix(eui, "0x")
+	eui = strings.NewReplacer(":", "", "-", "").Replace(eui)
+	if len(eui) != 16 {
+		return "", fmt.Errorf("invalid eui: %s", s)
+	}
+	if _, err := hex.DecodeString(eui); err != nil {
+		return "", fmt.Errorf("invalid eui: %s", s)
+	}
+	return eui, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Format is the representation a reply is encoded in.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatGeoJSON Format = "geojson"
+)
+
+// FormatFromRequest returns the format the client asked for with the format
+// query parameter, or else with the Accept header. It defaults to JSON.
+func FormatFromRequest(r *http.Request) Format {
+	switch r.URL.Query().Get("format") {
+	case string(FormatGeoJSON):
+		return FormatGeoJSON
+	case string(FormatJSON):
+		return FormatJSON
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == "application/geo+json" {
+			return FormatGeoJSON
+		}
+	}
+
+	return FormatJSON
+}
@@ -19,11 +19,33 @@ package utils
 import (
 	"encoding/hex"
 	"net/http"
+	"strconv"
 
 	"github.com/ThingsIXFoundation/types"
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	// DefaultPageSize is the page size of paged endpoints when the request
+	// doesn't set one.
+	DefaultPageSize = 15
+	// MaxPageSize is the largest page size paged endpoints return.
+	MaxPageSize = 100
+)
+
+// PageSizeFromRequest returns the pageSize query parameter bounded to
+// MaxPageSize, or DefaultPageSize when it isn't set.
+func PageSizeFromRequest(r *http.Request) int {
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize <= 0 {
+		return DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		return MaxPageSize
+	}
+	return pageSize
+}
+
 func IDFromRequest(r *http.Request, key string) types.ID {
 	val := chi.URLParam(r, key)
 	if val[0] == '0' && (val[1] == 'x' || val[1] == 'X') {
@@ -0,0 +1,94 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxWebhooksPerOwner is the maximum number of webhooks an owner can register.
+const maxWebhooksPerOwner = 10
+
+// Options configure a WebhookAPI.
+type Options struct {
+	Store store.Store
+}
+
+type WebhookAPI struct {
+	store store.Store
+}
+
+// New creates a WebhookAPI with the given options.
+func New(opts Options) *WebhookAPI {
+	return &WebhookAPI{
+		store: opts.Store,
+	}
+}
+
+// NewWebhookAPI creates a WebhookAPI from the config.
+func NewWebhookAPI() (*WebhookAPI, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Store: store,
+	}), nil
+}
+
+func (wapi *WebhookAPI) Bind(root *chi.Mux) error {
+	root.Route("/webhooks", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Post("/", wapi.RegisterWebhook)
+			r.Get("/owner/{owner:(?i)(0x)?[0-9a-f]{40}}", wapi.WebhooksByOwner)
+			r.Get("/{id:[0-9a-f]{32}}", wapi.WebhookDetails)
+			r.Delete("/{id:[0-9a-f]{32}}", wapi.DeleteWebhook)
+			r.Get("/{id:[0-9a-f]{32}}/deliveries", wapi.WebhookDeliveries)
+		})
+	})
+
+	return nil
+}
+
+var (
+	emptyWebhooksSlice   = make([]*models.Webhook, 0)
+	emptyDeliveriesSlice = make([]*models.Delivery, 0)
+)
+
+func webhooksOrEmptySlice(webhooks []*models.Webhook) []*models.Webhook {
+	if webhooks == nil {
+		return emptyWebhooksSlice
+	}
+	return webhooks
+}
+
+func deliveriesOrEmptySlice(deliveries []*models.Delivery) []*models.Delivery {
+	if deliveries == nil {
+		return emptyDeliveriesSlice
+	}
+	return deliveries
+}
+
+// withoutSecret returns a copy of the webhook without its secret.
+func withoutSecret(webhook *models.Webhook) *models.Webhook {
+	w := *webhook
+	w.Secret = ""
+	return &w
+}
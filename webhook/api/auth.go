@@ -0,0 +1,190 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signatureValidity is the time a signed webhook request is valid before and
+// after its timestamp, a signature is only accepted once.
+const signatureValidity = 10 * time.Minute
+
+// TimestampHeader and SignatureHeader carry the timestamp and owner signature
+// of requests without a body.
+const (
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// authorize verifies that the request is signed by owner and that the
+// signature wasn't used before. It replies and returns false when the request
+// isn't authorized.
+func (wapi *WebhookAPI) authorize(ctx context.Context, w http.ResponseWriter, owner common.Address, action, subject string, timestamp int64, signature string) bool {
+	log := logging.WithContext(ctx).WithField("owner", owner)
+
+	hash, err := verifyOwner(owner, action, subject, timestamp, signature)
+	if err != nil {
+		log.WithError(err).Warnf("webhook %s not signed by owner", action)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return false
+	}
+
+	// after the validity window the timestamp check rejects the signature
+	first, err := wapi.store.UseSignature(ctx, hex.EncodeToString(hash), time.Unix(timestamp, 0).Add(signatureValidity))
+	if err != nil {
+		log.WithError(err).Error("unable to record webhook signature")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return false
+	}
+	if !first {
+		log.Warnf("webhook %s signature replayed", action)
+		http.Error(w, "signature already used", http.StatusUnauthorized)
+		return false
+	}
+
+	return true
+}
+
+// signatureFromHeaders returns the timestamp and signature from the
+// TimestampHeader and SignatureHeader of r. It replies and returns false when
+// they are missing.
+func signatureFromHeaders(w http.ResponseWriter, r *http.Request) (int64, string, bool) {
+	timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+	if err != nil || r.Header.Get(SignatureHeader) == "" {
+		http.Error(w, "signature missing", http.StatusUnauthorized)
+		return 0, "", false
+	}
+	return timestamp, r.Header.Get(SignatureHeader), true
+}
+
+// verifyOwner checks that signature is the wallet signature of owner over the
+// action on subject at timestamp, subject is the registerSubject when
+// registering and the webhook id otherwise. It returns the hash of the signed
+// message.
+func verifyOwner(owner common.Address, action, subject string, timestamp int64, signature string) ([]byte, error) {
+	if d := time.Since(time.Unix(timestamp, 0)); d > signatureValidity || d < -signatureValidity {
+		return nil, errors.New("signature expired")
+	}
+
+	hash, err := webhookHash(owner, action, subject, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := common.FromHex(signature)
+	if len(sig) != crypto.SignatureLength {
+		return nil, errInvalidSignature
+	}
+	if sig[len(sig)-1] >= 27 {
+		sig[len(sig)-1] = sig[len(sig)-1] - 27
+	}
+
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, errInvalidSignature
+	}
+
+	if signer := crypto.PubkeyToAddress(*pub); signer != owner {
+		return nil, errInvalidSignature
+	}
+	return hash, nil
+}
+
+func webhookHash(owner common.Address, action, subject string, timestamp int64) ([]byte, error) {
+	stringTy, _ := abi.NewType("string", "", nil)
+	addressTy, _ := abi.NewType("address", "", nil)
+
+	args := abi.Arguments{
+		{Type: stringTy},
+		{Type: stringTy},
+		{Type: stringTy},
+		{Type: stringTy},
+		{Type: addressTy},
+		{Type: stringTy},
+		{Type: stringTy},
+		{Type: stringTy},
+		{Type: stringTy},
+	}
+
+	packed, err := args.Pack("WEBHOOK", "|", action, "|", owner, "|", subject, "|", strconv.FormatInt(timestamp, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	// same signing routine as the mapping auth, wallets sign the hex encoded
+	// form of packed
+	return accounts.TextHash([]byte("0x" + hex.EncodeToString(packed))), nil
+}
+
+// registerSubject returns the subject signed when registering a webhook, the
+// URL and the filter hash separated by "|", so whoever submits the signed
+// request can't change which events the webhook receives.
+func registerSubject(url string, filter *models.WebhookFilter) string {
+	return url + "|" + filterHash(filter)
+}
+
+// filterHash returns the hex encoded keccak256 hash of the canonical form of
+// the filter:
+//
+//	registries=<registries>;ids=<ids>;types=<types>;pending=<pending>;rewards=<rewards>
+//
+// The lists are sorted, deduplicated and comma separated, the ids are 0x
+// prefixed lower case hex and pending and rewards are true or false.
+func filterHash(filter *models.WebhookFilter) string {
+	ids := make([]string, len(filter.IDs))
+	for i, id := range filter.IDs {
+		ids[i] = strings.ToLower(id.String())
+	}
+
+	canonical := fmt.Sprintf("registries=%s;ids=%s;types=%s;pending=%t;rewards=%t",
+		canonicalList(filter.Registries), canonicalList(ids), canonicalList(filter.Types), filter.Pending, filter.Rewards)
+
+	return hex.EncodeToString(crypto.Keccak256([]byte(canonical)))
+}
+
+// canonicalList returns the sorted and deduplicated values separated by
+// commas.
+func canonicalList(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	var unique []string
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return strings.Join(unique, ",")
+}
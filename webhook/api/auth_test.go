@@ -0,0 +1,172 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sign returns the signature of key over the action on subject at timestamp
+// with the recovery id v as 0 or 1, or as 27 or 28 when legacy is set.
+func sign(t *testing.T, key *ecdsa.PrivateKey, action, subject string, timestamp int64, legacy bool) string {
+	t.Helper()
+
+	hash, err := webhookHash(crypto.PubkeyToAddress(key.PublicKey), action, subject, timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy {
+		sig[len(sig)-1] += 27
+	}
+	return hexutil.Encode(sig)
+}
+
+func TestVerifyOwner(t *testing.T) {
+	owner, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ownerAddr = crypto.PubkeyToAddress(owner.PublicKey)
+		now       = time.Now().Unix()
+		filter    = &models.WebhookFilter{Registries: []string{"gateway"}}
+		subject   = registerSubject("https://example.com/hook", filter)
+	)
+
+	for _, tc := range []struct {
+		name      string
+		action    string
+		subject   string
+		timestamp int64
+		signature string
+		valid     bool
+	}{
+		{"valid", "register", subject, now, sign(t, owner, "register", subject, now, false), true},
+		{"valid with v of 27 or 28", "register", subject, now, sign(t, owner, "register", subject, now, true), true},
+		{"signed by someone else", "register", subject, now, sign(t, other, "register", subject, now, false), false},
+		{"other action", "delete", subject, now, sign(t, owner, "register", subject, now, false), false},
+		{"other filter", "register", registerSubject("https://example.com/hook", &models.WebhookFilter{Pending: true}), now, sign(t, owner, "register", subject, now, false), false},
+		{"expired", "register", subject, now - 3600, sign(t, owner, "register", subject, now-3600, false), false},
+		{"from the future", "register", subject, now + 3600, sign(t, owner, "register", subject, now+3600, false), false},
+		{"truncated", "register", subject, now, sign(t, owner, "register", subject, now, false)[:100], false},
+		{"missing", "register", subject, now, "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := verifyOwner(ownerAddr, tc.action, tc.subject, tc.timestamp, tc.signature)
+			if tc.valid && (err != nil || len(hash) == 0) {
+				t.Errorf("signature rejected: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("signature accepted")
+			}
+		})
+	}
+}
+
+func TestFilterHash(t *testing.T) {
+	base := &models.WebhookFilter{Registries: []string{"gateway", "mapper"}, Types: []string{"onboard", "transfer"}, Pending: true}
+
+	for _, tc := range []struct {
+		name   string
+		filter *models.WebhookFilter
+		same   bool
+	}{
+		{"identical", &models.WebhookFilter{Registries: []string{"gateway", "mapper"}, Types: []string{"onboard", "transfer"}, Pending: true}, true},
+		{"reordered", &models.WebhookFilter{Registries: []string{"mapper", "gateway"}, Types: []string{"transfer", "onboard"}, Pending: true}, true},
+		{"duplicates", &models.WebhookFilter{Registries: []string{"gateway", "mapper", "gateway"}, Types: []string{"onboard", "transfer"}, Pending: true}, true},
+		{"other registries", &models.WebhookFilter{Registries: []string{"gateway"}, Types: []string{"onboard", "transfer"}, Pending: true}, false},
+		{"types as registries", &models.WebhookFilter{Registries: []string{"onboard", "transfer"}, Types: []string{"gateway", "mapper"}, Pending: true}, false},
+		{"without pending", &models.WebhookFilter{Registries: []string{"gateway", "mapper"}, Types: []string{"onboard", "transfer"}}, false},
+		{"with rewards", &models.WebhookFilter{Registries: []string{"gateway", "mapper"}, Types: []string{"onboard", "transfer"}, Pending: true, Rewards: true}, false},
+		{"empty", &models.WebhookFilter{}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if same := filterHash(tc.filter) == filterHash(base); same != tc.same {
+				t.Errorf("same hash %v, want %v", same, tc.same)
+			}
+		})
+	}
+}
+
+// signatureStore records the used signatures in memory.
+type signatureStore struct {
+	store.Store
+	used map[string]bool
+}
+
+func (s *signatureStore) UseSignature(ctx context.Context, hash string, expires time.Time) (bool, error) {
+	if s.used[hash] {
+		return false, nil
+	}
+	s.used[hash] = true
+	return true, nil
+}
+
+func TestAuthorizeAcceptsSignatureOnce(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wapi      = New(Options{Store: &signatureStore{used: make(map[string]bool)}})
+		owner     = crypto.PubkeyToAddress(key.PublicKey)
+		now       = time.Now().Unix()
+		signature = sign(t, key, "delete", "0123456789abcdef0123456789abcdef", now, false)
+	)
+
+	for _, tc := range []struct {
+		name       string
+		owner      common.Address
+		authorized bool
+		status     int
+	}{
+		{"first use", owner, true, http.StatusOK},
+		{"replayed", owner, false, http.StatusUnauthorized},
+		{"other owner", common.HexToAddress("0x1"), false, http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if authorized := wapi.authorize(context.Background(), w, tc.owner, "delete", "0123456789abcdef0123456789abcdef", now, signature); authorized != tc.authorized {
+				t.Errorf("authorized %v, want %v", authorized, tc.authorized)
+			}
+			if w.Code != tc.status {
+				t.Errorf("status %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
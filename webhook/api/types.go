@@ -0,0 +1,31 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+
+// WebhooksResponse are the webhooks of an owner, without their secrets.
+type WebhooksResponse struct {
+	Webhooks []*models.Webhook `json:"webhooks"`
+}
+
+// DeliveriesResponse is a page of deliveries of a webhook, the cursor is only
+// set when there are more deliveries.
+type DeliveriesResponse struct {
+	Cursor     string             `json:"cursor,omitempty"`
+	Deliveries []*models.Delivery `json:"deliveries"`
+}
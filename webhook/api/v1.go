@@ -0,0 +1,286 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	"github.com/ThingsIXFoundation/data-aggregator/logging"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/http-utils/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+)
+
+type RegisterWebhookRequest struct {
+	Owner  common.Address       `json:"owner"`
+	URL    string               `json:"url"`
+	Filter models.WebhookFilter `json:"filter"`
+	// Timestamp is the unix time the request was signed at.
+	Timestamp int64 `json:"timestamp"`
+	// Signature is the wallet signature of the owner over the URL and the
+	// filter that proves ownership.
+	Signature string `json:"signature"`
+}
+
+type DeleteWebhookRequest struct {
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+func (wapi *WebhookAPI) RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		req         = RegisterWebhookRequest{}
+	)
+	defer cancel()
+
+	if err := encoding.DecodeHTTPJSONBody(w, r, &req); err != nil {
+		log.WithError(err).Warn("invalid webhook registration")
+		http.Error(w, err.Msg, err.Status)
+		return
+	}
+
+	if err := validateRegistration(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !wapi.authorize(ctx, w, req.Owner, "register", registerSubject(req.URL, &req.Filter), req.Timestamp, req.Signature) {
+		return
+	}
+
+	existing, err := wapi.store.GetWebhooksByOwner(ctx, req.Owner)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhooks")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if len(existing) >= maxWebhooksPerOwner {
+		http.Error(w, fmt.Sprintf("owner already has %d webhooks", maxWebhooksPerOwner), http.StatusConflict)
+		return
+	}
+
+	hook, err := webhook.NewWebhook(req.Owner, req.URL, req.Filter)
+	if err != nil {
+		log.WithError(err).Error("unable to create webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := wapi.store.StoreWebhook(ctx, hook); err != nil {
+		log.WithError(err).Error("unable to store webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// the secret is only revealed once
+	encoding.ReplyJSON(w, r, http.StatusCreated, hook)
+}
+
+func validateRegistration(req *RegisterWebhookRequest) error {
+	if req.Owner == (common.Address{}) {
+		return errors.New("owner missing")
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("url must be an absolute https URL")
+	}
+
+	for _, registry := range req.Filter.Registries {
+		switch registry {
+		case eventbus.RegistryGateway, eventbus.RegistryMapper, eventbus.RegistryRouter:
+		default:
+			return fmt.Errorf("invalid registry %q", registry)
+		}
+	}
+
+	return nil
+}
+
+// WebhooksByOwner returns the webhooks of an owner. Webhooks reveal their URL,
+// the request must carry a fresh owner signature over the list action and the
+// lower case owner address in the TimestampHeader and SignatureHeader.
+func (wapi *WebhookAPI) WebhooksByOwner(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		owner       = common.HexToAddress(chi.URLParam(r, "owner"))
+	)
+	defer cancel()
+
+	timestamp, signature, ok := signatureFromHeaders(w, r)
+	if !ok {
+		return
+	}
+
+	if !wapi.authorize(ctx, w, owner, "list", strings.ToLower(owner.Hex()), timestamp, signature) {
+		return
+	}
+
+	webhooks, err := wapi.store.GetWebhooksByOwner(ctx, owner)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhooks")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	for i, webhook := range webhooks {
+		webhooks[i] = withoutSecret(webhook)
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, WebhooksResponse{
+		Webhooks: webhooksOrEmptySlice(webhooks),
+	})
+}
+
+// WebhookDetails returns a webhook. The webhook reveals its URL, the request
+// must carry a fresh owner signature over the details action and the webhook
+// id in the TimestampHeader and SignatureHeader.
+func (wapi *WebhookAPI) WebhookDetails(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		id          = chi.URLParam(r, "id")
+	)
+	defer cancel()
+
+	timestamp, signature, ok := signatureFromHeaders(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := wapi.store.GetWebhook(ctx, id)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if !wapi.authorize(ctx, w, webhook.Owner, "details", webhook.ID, timestamp, signature) {
+		return
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, withoutSecret(webhook))
+}
+
+func (wapi *WebhookAPI) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		id          = chi.URLParam(r, "id")
+		req         = DeleteWebhookRequest{}
+	)
+	defer cancel()
+
+	if err := encoding.DecodeHTTPJSONBody(w, r, &req); err != nil {
+		log.WithError(err).Warn("invalid webhook deletion")
+		http.Error(w, err.Msg, err.Status)
+		return
+	}
+
+	webhook, err := wapi.store.GetWebhook(ctx, id)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if !wapi.authorize(ctx, w, webhook.Owner, "delete", webhook.ID, req.Timestamp, req.Signature) {
+		return
+	}
+
+	if err := wapi.store.DeleteWebhook(ctx, id); err != nil {
+		log.WithError(err).Error("unable to delete webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebhookDeliveries returns the deliveries of a webhook. Deliveries can reveal
+// the webhook URL, the request must carry a fresh owner signature over the
+// deliveries action in the TimestampHeader and SignatureHeader for every page.
+func (wapi *WebhookAPI) WebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var (
+		log         = logging.WithContext(r.Context())
+		ctx, cancel = context.WithTimeout(r.Context(), 15*time.Second)
+		id          = chi.URLParam(r, "id")
+		cursor      = r.URL.Query().Get("cursor")
+		pageSize    = utils.PageSizeFromRequest(r)
+	)
+	defer cancel()
+
+	timestamp, signature, ok := signatureFromHeaders(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := wapi.store.GetWebhook(ctx, id)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhook")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if !wapi.authorize(ctx, w, webhook.Owner, "deliveries", webhook.ID, timestamp, signature) {
+		return
+	}
+
+	deliveries, cursor, err := wapi.store.GetDeliveries(ctx, id, pageSize, cursor)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve webhook deliveries")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(deliveries) <= pageSize {
+		cursor = ""
+	} else {
+		deliveries = deliveries[:pageSize]
+	}
+
+	encoding.ReplyJSON(w, r, http.StatusOK, DeliveriesResponse{
+		Cursor:     cursor,
+		Deliveries: deliveriesOrEmptySlice(deliveries),
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chi/chi/v5"
+)
+
+// webhookStore serves a single webhook and records the used signatures in
+// memory.
+type webhookStore struct {
+	signatureStore
+	webhook *models.Webhook
+}
+
+func (s *webhookStore) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	if id != s.webhook.ID {
+		return nil, datastore.ErrNoSuchEntity
+	}
+	return s.webhook, nil
+}
+
+func (s *webhookStore) GetWebhooksByOwner(ctx context.Context, owner common.Address) ([]*models.Webhook, error) {
+	if owner != s.webhook.Owner {
+		return nil, nil
+	}
+	return []*models.Webhook{s.webhook}, nil
+}
+
+func TestWebhookRequiresOwnerSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		owner   = crypto.PubkeyToAddress(key.PublicKey)
+		ownerID = strings.ToLower(owner.Hex())
+		id      = "0123456789abcdef0123456789abcdef"
+		now     = time.Now().Unix()
+		root    = chi.NewRouter()
+		wapi    = New(Options{Store: &webhookStore{
+			signatureStore: signatureStore{used: make(map[string]bool)},
+			webhook:        &models.Webhook{ID: id, Owner: owner, URL: "https://example.com/hook", Secret: "secret"},
+		}})
+	)
+	if err := wapi.Bind(root); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		path      string
+		signature string
+		status    int
+	}{
+		{"list unsigned", "/webhooks/v1/owner/" + ownerID, "", http.StatusUnauthorized},
+		{"list signed by someone else", "/webhooks/v1/owner/" + ownerID, sign(t, other, "list", ownerID, now, false), http.StatusUnauthorized},
+		{"list with details signature", "/webhooks/v1/owner/" + ownerID, sign(t, key, "details", ownerID, now, false), http.StatusUnauthorized},
+		{"list signed", "/webhooks/v1/owner/" + ownerID, sign(t, key, "list", ownerID, now, false), http.StatusOK},
+		{"list replayed", "/webhooks/v1/owner/" + ownerID, sign(t, key, "list", ownerID, now, false), http.StatusUnauthorized},
+		{"details unsigned", "/webhooks/v1/" + id, "", http.StatusUnauthorized},
+		{"details signed by someone else", "/webhooks/v1/" + id, sign(t, other, "details", id, now, false), http.StatusUnauthorized},
+		{"details with deliveries signature", "/webhooks/v1/" + id, sign(t, key, "deliveries", id, now, false), http.StatusUnauthorized},
+		{"details signed", "/webhooks/v1/" + id, sign(t, key, "details", id, now, false), http.StatusOK},
+		{"details replayed", "/webhooks/v1/" + id, sign(t, key, "details", id, now, false), http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.signature != "" {
+				r.Header.Set(TimestampHeader, strconv.FormatInt(now, 10))
+				r.Header.Set(SignatureHeader, tc.signature)
+			}
+
+			w := httptest.NewRecorder()
+			root.ServeHTTP(w, r)
+			if w.Code != tc.status {
+				t.Errorf("status %d, want %d", w.Code, tc.status)
+			}
+			if w.Code != http.StatusOK && strings.Contains(w.Body.String(), "example.com") {
+				t.Errorf("unauthorized response reveals the URL: %s", w.Body.String())
+			}
+			if strings.Contains(w.Body.String(), "secret") {
+				t.Errorf("response reveals the secret: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookRequestBodyIsBounded(t *testing.T) {
+	var (
+		root = chi.NewRouter()
+		wapi = New(Options{Store: &webhookStore{
+			signatureStore: signatureStore{used: make(map[string]bool)},
+			webhook:        &models.Webhook{ID: "0123456789abcdef0123456789abcdef"},
+		}})
+		large = `{"signature":"` + strings.Repeat("a", 2<<20) + `"}`
+	)
+	if err := wapi.Bind(root); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"register too large", http.MethodPost, "/webhooks/v1/", large, http.StatusRequestEntityTooLarge},
+		{"register malformed", http.MethodPost, "/webhooks/v1/", `{"url":`, http.StatusBadRequest},
+		{"delete too large", http.MethodDelete, "/webhooks/v1/0123456789abcdef0123456789abcdef", large, http.StatusRequestEntityTooLarge},
+		{"delete malformed", http.MethodDelete, "/webhooks/v1/0123456789abcdef0123456789abcdef", `{"timestamp":`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			root.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+			if w.Code != tc.status {
+				t.Errorf("status %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
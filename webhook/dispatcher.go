@@ -0,0 +1,528 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	rewardStore "github.com/ThingsIXFoundation/data-aggregator/rewards/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// dispatchBatchSize is the maximum number of deliveries attempted per
+	// poll.
+	dispatchBatchSize = 100
+	// dispatchConcurrency is the number of deliveries attempted in parallel.
+	dispatchConcurrency = 10
+	// maxErrorLength is the maximum length of the error stored with a
+	// failed attempt.
+	maxErrorLength = 512
+	// publishMaxBlockRange is the maximum number of blocks of stored events
+	// turned into deliveries at once.
+	publishMaxBlockRange = 10_000
+)
+
+// Options configure a Dispatcher.
+type Options struct {
+	Store       store.Store
+	RewardStore rewardStore.Store
+	// The deliveries of confirmed events are created from the events stored
+	// in the registry stores, registries without a store are skipped.
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+	// Client posts the deliveries, it defaults to a client with Timeout that
+	// only connects to public addresses.
+	Client *http.Client
+	// Timeout is the time a webhook has to accept a delivery.
+	Timeout time.Duration
+	// PollInterval is the interval to check for due deliveries and new
+	// rewards in.
+	PollInterval time.Duration
+	// MaxAttempts is the number of attempts after which a delivery fails.
+	MaxAttempts int
+	// InitialBackoff is the time to wait before the first retry, it doubles
+	// for every following retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Elector runs the dispatcher under a lease, when nil the dispatcher
+	// always runs.
+	Elector *leader.Elector
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Dispatcher posts the pending deliveries to their webhooks. It creates the
+// deliveries of the confirmed events the ingestors stored and the rewards
+// deliveries when a new reward date appears.
+type Dispatcher struct {
+	store          store.Store
+	rewardStore    rewardStore.Store
+	publisher      *Publisher
+	registries     []registryEvents
+	client         *http.Client
+	pollInterval   time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	elector        *leader.Elector
+	log            logrus.FieldLogger
+}
+
+// New creates a Dispatcher with the given options.
+func New(opts Options) *Dispatcher {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+	if opts.Client == nil {
+		opts.Client = newClient(opts.Timeout)
+	}
+
+	return &Dispatcher{
+		store:       opts.Store,
+		rewardStore: opts.RewardStore,
+		publisher: NewPublisher(PublisherOptions{
+			Store:        opts.Store,
+			GatewayStore: opts.GatewayStore,
+			MapperStore:  opts.MapperStore,
+			RouterStore:  opts.RouterStore,
+			Logger:       opts.Logger,
+		}),
+		registries:     newRegistryEvents(opts.GatewayStore, opts.MapperStore, opts.RouterStore),
+		client:         opts.Client,
+		pollInterval:   opts.PollInterval,
+		maxAttempts:    opts.MaxAttempts,
+		initialBackoff: opts.InitialBackoff,
+		maxBackoff:     opts.MaxBackoff,
+		elector:        opts.Elector,
+		log:            opts.Logger,
+	}
+}
+
+// NewDispatcher creates a Dispatcher from the config that runs under the lease
+// of the elector.
+func NewDispatcher(elector *leader.Elector) (*Dispatcher, error) {
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	rewardStore, err := rewardStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	return New(Options{
+		Store:          store,
+		RewardStore:    rewardStore,
+		GatewayStore:   gatewayStore,
+		MapperStore:    mapperStore,
+		RouterStore:    routerStore,
+		Timeout:        viper.GetDuration(config.CONFIG_WEBHOOK_DISPATCHER_TIMEOUT),
+		PollInterval:   viper.GetDuration(config.CONFIG_WEBHOOK_DISPATCHER_POLL_INTERVAL),
+		MaxAttempts:    viper.GetInt(config.CONFIG_WEBHOOK_DISPATCHER_MAX_ATTEMPTS),
+		InitialBackoff: viper.GetDuration(config.CONFIG_WEBHOOK_DISPATCHER_INITIAL_BACKOFF),
+		MaxBackoff:     viper.GetDuration(config.CONFIG_WEBHOOK_DISPATCHER_MAX_BACKOFF),
+		Elector:        elector,
+	}), nil
+}
+
+// Run dispatches deliveries while this replica holds the dispatcher lease.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	// the dispatcher serves all registries, its lease isn't bound to a contract
+	return d.elector.Run(ctx, "WebhookDispatcher", common.Address{}, d.dispatch)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	for {
+		if err := d.createEventDeliveries(ctx); err != nil {
+			d.log.WithError(err).Error("unable to create webhook event deliveries")
+		}
+		if err := d.createRewardsDeliveries(ctx); err != nil {
+			d.log.WithError(err).Error("unable to create webhook rewards deliveries")
+		}
+		if err := d.deliverDue(ctx); err != nil {
+			d.log.WithError(err).Error("unable to deliver webhooks")
+		}
+		if err := d.store.PurgeExpiredSignatures(ctx, time.Now()); err != nil {
+			d.log.WithError(err).Error("unable to purge expired webhook signatures")
+		}
+
+		select {
+		case <-time.After(d.pollInterval):
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// createRewardsDeliveries creates a delivery for every webhook that selects
+// rewards of an owner that received rewards on the latest reward date, once
+// per reward date.
+func (d *Dispatcher) createRewardsDeliveries(ctx context.Context) error {
+	latest, err := d.rewardStore.GetLatestRewardsDate(ctx)
+	if err != nil {
+		return err
+	}
+	notified, err := d.store.LatestRewardsDate(ctx)
+	if err != nil {
+		return err
+	}
+	if !latest.After(notified) {
+		return nil
+	}
+
+	// don't deliver the rewards of the past when webhooks are enabled
+	if notified.IsZero() {
+		return d.store.StoreLatestRewardsDate(ctx, latest)
+	}
+
+	rewards, err := d.rewardStore.GetAllAccountRewardsAt(ctx, latest)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("rewards|%s", latest.Format(time.DateOnly))
+	for _, reward := range rewards {
+		webhooks, err := d.store.GetWebhooksByOwner(ctx, reward.Account)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range webhooks {
+			if !webhook.Filter.Rewards {
+				continue
+			}
+
+			delivery, err := newDelivery(webhook, key, &Payload{Kind: models.DeliveryKindRewards, Rewards: reward})
+			if err != nil {
+				return err
+			}
+			if err := d.store.CreateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+		}
+	}
+
+	return d.store.StoreLatestRewardsDate(ctx, latest)
+}
+
+// registryEvents reads the confirmed events an ingestor stored for a
+// registry.
+type registryEvents struct {
+	registry     string
+	process      string
+	currentBlock func(ctx context.Context, process string) (uint64, error)
+	eventsFromTo func(ctx context.Context, from, to uint64) ([]*eventbus.Event, error)
+}
+
+func newRegistryEvents(gatewayStore gatewayStore.Store, mapperStore mapperStore.Store, routerStore routerStore.Store) []registryEvents {
+	var registries []registryEvents
+	if gatewayStore != nil {
+		registries = append(registries, registryEvents{
+			registry:     eventbus.RegistryGateway,
+			process:      "GatewayIngestor",
+			currentBlock: gatewayStore.CurrentBlock,
+			eventsFromTo: func(ctx context.Context, from, to uint64) ([]*eventbus.Event, error) {
+				events, err := gatewayStore.EventsFromTo(ctx, from, to)
+				if err != nil {
+					return nil, err
+				}
+				wrapped := make([]*eventbus.Event, len(events))
+				for i, event := range events {
+					wrapped[i] = eventbus.FromGatewayEvent(event, false)
+				}
+				return wrapped, nil
+			},
+		})
+	}
+	if mapperStore != nil {
+		registries = append(registries, registryEvents{
+			registry:     eventbus.RegistryMapper,
+			process:      "MapperIngestor",
+			currentBlock: mapperStore.CurrentBlock,
+			eventsFromTo: func(ctx context.Context, from, to uint64) ([]*eventbus.Event, error) {
+				events, err := mapperStore.EventsFromTo(ctx, from, to)
+				if err != nil {
+					return nil, err
+				}
+				wrapped := make([]*eventbus.Event, len(events))
+				for i, event := range events {
+					wrapped[i] = eventbus.FromMapperEvent(event, false)
+				}
+				return wrapped, nil
+			},
+		})
+	}
+	if routerStore != nil {
+		registries = append(registries, registryEvents{
+			registry:     eventbus.RegistryRouter,
+			process:      "RouterIngestor",
+			currentBlock: routerStore.CurrentBlock,
+			eventsFromTo: func(ctx context.Context, from, to uint64) ([]*eventbus.Event, error) {
+				events, err := routerStore.EventsFromTo(ctx, from, to)
+				if err != nil {
+					return nil, err
+				}
+				wrapped := make([]*eventbus.Event, len(events))
+				for i, event := range events {
+					wrapped[i] = eventbus.FromRouterEvent(event, false)
+				}
+				return wrapped, nil
+			},
+		})
+	}
+	return registries
+}
+
+// createEventDeliveries creates the deliveries of the confirmed events the
+// ingestors stored since the last poll. The block up to which the events of
+// a registry are turned into deliveries is stored after the deliveries, an
+// error leaves it in place so the events are retried on the next poll.
+func (d *Dispatcher) createEventDeliveries(ctx context.Context) error {
+	for _, r := range d.registries {
+		if err := d.createRegistryEventDeliveries(ctx, r); err != nil {
+			return fmt.Errorf("%s: %w", r.registry, err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) createRegistryEventDeliveries(ctx context.Context, r registryEvents) error {
+	// all events up to and including the current block are stored
+	current, err := r.currentBlock(ctx, r.process)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return nil
+	}
+
+	next, err := d.store.PublishedBlock(ctx, r.registry)
+	if err != nil {
+		return err
+	}
+
+	// don't deliver the events of the past when webhooks are enabled
+	if next == 0 {
+		return d.store.StorePublishedBlock(ctx, r.registry, current+1)
+	}
+
+	for next <= current {
+		to := next + publishMaxBlockRange
+		if to > current+1 {
+			to = current + 1
+		}
+
+		events, err := r.eventsFromTo(ctx, next, to)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := d.publisher.createDeliveries(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if err := d.store.StorePublishedBlock(ctx, r.registry, to); err != nil {
+			return err
+		}
+		next = to
+	}
+
+	return nil
+}
+
+// deliverDue attempts the deliveries that are due.
+func (d *Dispatcher) deliverDue(ctx context.Context) error {
+	deliveries, err := d.store.GetDueDeliveries(ctx, time.Now(), dispatchBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, dispatchConcurrency)
+	)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *models.Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := d.attempt(ctx, delivery); err != nil {
+				d.log.WithError(err).WithField("delivery", delivery.ID).Error("unable to update webhook delivery")
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// attempt posts the delivery to its webhook and records the outcome.
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.Delivery) error {
+	webhook, err := d.store.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.LastError = "webhook deleted"
+		return d.store.UpdateDelivery(ctx, delivery)
+	}
+	if err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	statusCode, err := d.post(ctx, webhook, delivery)
+	delivery.LastStatusCode = statusCode
+
+	log := d.log.WithFields(logrus.Fields{
+		"webhook":  webhook.ID,
+		"delivery": delivery.ID,
+		"attempt":  delivery.Attempts,
+	})
+
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.DeliveredAt = time.Now()
+		delivery.LastError = ""
+		log.Debug("delivered webhook")
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.LastError = truncate(err.Error(), maxErrorLength)
+		log.WithError(err).Warn("webhook delivery failed, giving up")
+	default:
+		delivery.NextAttempt = time.Now().Add(d.backoff(delivery.Attempts))
+		delivery.LastError = truncate(err.Error(), maxErrorLength)
+		log.WithError(err).Info("webhook delivery failed, retrying")
+	}
+
+	return d.store.UpdateDelivery(ctx, delivery)
+}
+
+// post sends the signed delivery payload, any response other than 2xx is an
+// error.
+func (d *Dispatcher) post(ctx context.Context, webhook *models.Webhook, delivery *models.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ThingsIX-Webhook/"+utils.Version())
+	req.Header.Set("X-ThingsIX-Webhook", webhook.ID)
+	req.Header.Set("X-ThingsIX-Delivery", delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// newClient returns a client that refuses to connect to loopback, private and
+// link-local addresses so webhooks can't reach internal services, and that
+// doesn't follow redirects.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+				return fmt.Errorf("webhook address %s not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// backoff returns the time to wait after the given number of attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.initialBackoff
+	for i := 1; i < attempts && backoff < d.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > d.maxBackoff {
+		backoff = d.maxBackoff
+	}
+	return backoff
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/datastore"
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	gatewayStore "github.com/ThingsIXFoundation/data-aggregator/gateway/store"
+	mapperStore "github.com/ThingsIXFoundation/data-aggregator/mapper/store"
+	routerStore "github.com/ThingsIXFoundation/data-aggregator/router/store"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// PublisherOptions configure a Publisher.
+type PublisherOptions struct {
+	Store store.Store
+
+	// The registry stores are used to find the owner of events that don't
+	// carry one, e.g. a gateway update. They are optional.
+	GatewayStore gatewayStore.Store
+	MapperStore  mapperStore.Store
+	RouterStore  routerStore.Store
+
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+// Publisher creates the deliveries for the webhooks that match the pending
+// events published by the ingestors. The deliveries of confirmed events are
+// created by the Dispatcher from the stored events, so none are lost when
+// publishing fails.
+type Publisher struct {
+	store        store.Store
+	gatewayStore gatewayStore.Store
+	mapperStore  mapperStore.Store
+	routerStore  routerStore.Store
+	log          logrus.FieldLogger
+}
+
+var _ eventbus.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a Publisher with the given options.
+func NewPublisher(opts PublisherOptions) *Publisher {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Publisher{
+		store:        opts.Store,
+		gatewayStore: opts.GatewayStore,
+		mapperStore:  opts.MapperStore,
+		routerStore:  opts.RouterStore,
+		log:          opts.Logger,
+	}
+}
+
+// NewEventPublisher returns a Publisher from the config, or nil when webhooks
+// aren't enabled.
+func NewEventPublisher() (eventbus.Publisher, error) {
+	if !viper.GetBool(config.CONFIG_WEBHOOK_ENABLED) {
+		return nil, nil
+	}
+
+	store, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	gatewayStore, err := gatewayStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	mapperStore, err := mapperStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	routerStore, err := routerStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPublisher(PublisherOptions{
+		Store:        store,
+		GatewayStore: gatewayStore,
+		MapperStore:  mapperStore,
+		RouterStore:  routerStore,
+	}), nil
+}
+
+// Publish creates a delivery for every webhook of the owners involved in the
+// pending event that selects it, confirmed events are skipped.
+func (p *Publisher) Publish(ctx context.Context, event *eventbus.Event) error {
+	if !event.Pending {
+		return nil
+	}
+	return p.createDeliveries(ctx, event)
+}
+
+// createDeliveries creates a delivery for every webhook of the owners
+// involved in the event that selects it. Deliveries that already exist
+// aren't created again, so an event can be replayed.
+func (p *Publisher) createDeliveries(ctx context.Context, event *eventbus.Event) error {
+	owners := event.Owners()
+	if len(owners) == 0 {
+		owner, err := p.currentOwner(ctx, event)
+		if err != nil {
+			return err
+		}
+		if owner == nil {
+			return nil
+		}
+		owners = append(owners, *owner)
+	}
+
+	for _, owner := range owners {
+		webhooks, err := p.store.GetWebhooksByOwner(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range webhooks {
+			if !matchEvent(&webhook.Filter, event) {
+				continue
+			}
+
+			delivery, err := newDelivery(webhook, eventKey(event), &Payload{Kind: models.DeliveryKindEvent, Event: event})
+			if err != nil {
+				return err
+			}
+			if err := p.store.CreateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// currentOwner returns the owner of the gateway, mapper or router the event
+// is about according to the registry store, or nil when it is unknown.
+func (p *Publisher) currentOwner(ctx context.Context, event *eventbus.Event) (*common.Address, error) {
+	switch {
+	case event.Registry == eventbus.RegistryGateway && p.gatewayStore != nil:
+		gateway, err := p.gatewayStore.Get(ctx, event.ID())
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		return &gateway.Owner, nil
+	case event.Registry == eventbus.RegistryMapper && p.mapperStore != nil:
+		mapper, err := p.mapperStore.Get(ctx, event.ID())
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		return mapper.Owner, nil
+	case event.Registry == eventbus.RegistryRouter && p.routerStore != nil:
+		router, err := p.routerStore.Get(ctx, event.ID())
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		return &router.Owner, nil
+	}
+	return nil, nil
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/leader"
+	"github.com/ThingsIXFoundation/data-aggregator/supervisor"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Components returns the enabled webhook components.
+func Components(elector *leader.Elector) []supervisor.Component {
+	var components []supervisor.Component
+
+	if viper.GetBool(config.CONFIG_WEBHOOK_ENABLED) {
+		components = append(components, supervisor.Component{
+			Name:     "webhook-dispatcher",
+			Registry: "webhook",
+			Role:     supervisor.RoleAPI,
+			Run:      func(ctx context.Context) error { return Run(ctx, elector) },
+			Policy:   supervisor.DefaultRestartPolicy(),
+		})
+	}
+
+	return components
+}
+
+// Run runs the webhook dispatcher.
+func Run(ctx context.Context, elector *leader.Elector) error {
+	d, err := NewDispatcher(elector)
+	if err != nil {
+		logrus.WithError(err).Error("error while creating webhook dispatcher")
+		return err
+	}
+
+	return d.Run(ctx)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The kinds of deliveries.
+const (
+	DeliveryKindEvent   = "event"
+	DeliveryKindRewards = "rewards"
+)
+
+// The states of a delivery.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+type DBDelivery struct {
+	ID             string
+	WebhookID      string
+	Kind           string `datastore:",noindex"`
+	Payload        string `datastore:",noindex"`
+	Status         string
+	Attempts       int `datastore:",noindex"`
+	NextAttempt    time.Time
+	LastStatusCode int    `datastore:",noindex"`
+	LastError      string `datastore:",noindex"`
+	CreatedAt      time.Time
+	DeliveredAt    time.Time `datastore:",noindex"`
+}
+
+func (e *DBDelivery) Entity() string {
+	return "WebhookDelivery"
+}
+
+func (e *DBDelivery) Key() string {
+	return e.ID
+}
+
+func (e *DBDelivery) Delivery() *Delivery {
+	return &Delivery{
+		ID:             e.ID,
+		WebhookID:      e.WebhookID,
+		Kind:           e.Kind,
+		Payload:        json.RawMessage(e.Payload),
+		Status:         e.Status,
+		Attempts:       e.Attempts,
+		NextAttempt:    e.NextAttempt,
+		LastStatusCode: e.LastStatusCode,
+		LastError:      e.LastError,
+		CreatedAt:      e.CreatedAt,
+		DeliveredAt:    e.DeliveredAt,
+	}
+}
+
+func NewDBDelivery(delivery *Delivery) *DBDelivery {
+	return &DBDelivery{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Kind:           delivery.Kind,
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttempt:    delivery.NextAttempt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+// Delivery tracks the delivery of a payload to a webhook.
+type Delivery struct {
+	ID        string `json:"id"`
+	WebhookID string `json:"webhookId"`
+	Kind      string `json:"kind"`
+	// Payload is the JSON body posted to the webhook URL.
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttempt    time.Time       `json:"nextAttempt"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    time.Time       `json:"deliveredAt,omitempty"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// DBWebhookPublishedBlock is the first block of a registry of which the
+// stored events aren't turned into deliveries yet.
+type DBWebhookPublishedBlock struct {
+	Registry    string
+	BlockNumber int `datastore:",noindex"`
+}
+
+func (e *DBWebhookPublishedBlock) Entity() string {
+	return "WebhookPublishedBlock"
+}
+
+func (e *DBWebhookPublishedBlock) Key() string {
+	return e.Registry
+}
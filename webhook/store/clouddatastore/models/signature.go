@@ -0,0 +1,37 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// DBWebhookSignature is an owner signature that was used, signatures are only
+// accepted once.
+type DBWebhookSignature struct {
+	// Hash is the hex encoded hash of the signed message.
+	Hash string
+	// Expires is the time after which the signature is rejected anyway and
+	// the record can be purged.
+	Expires time.Time
+}
+
+func (e *DBWebhookSignature) Entity() string {
+	return "WebhookSignature"
+}
+
+func (e *DBWebhookSignature) Key() string {
+	return e.Hash
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DBWebhook struct {
+	ID     string
+	Owner  string
+	URL    string `datastore:",noindex"`
+	Secret string `datastore:",noindex"`
+	// Filter is the JSON encoded WebhookFilter
+	Filter    string `datastore:",noindex"`
+	CreatedAt time.Time
+}
+
+func (e *DBWebhook) Entity() string {
+	return "Webhook"
+}
+
+func (e *DBWebhook) Key() string {
+	return e.ID
+}
+
+func (e *DBWebhook) Webhook() (*Webhook, error) {
+	var filter WebhookFilter
+	if err := json.Unmarshal([]byte(e.Filter), &filter); err != nil {
+		return nil, err
+	}
+
+	return &Webhook{
+		ID:        e.ID,
+		Owner:     common.HexToAddress(e.Owner),
+		URL:       e.URL,
+		Secret:    e.Secret,
+		Filter:    filter,
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
+
+func NewDBWebhook(webhook *Webhook) (*DBWebhook, error) {
+	filter, err := json.Marshal(webhook.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBWebhook{
+		ID:        webhook.ID,
+		Owner:     utils.AddressToString(webhook.Owner),
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Filter:    string(filter),
+		CreatedAt: webhook.CreatedAt,
+	}, nil
+}
+
+// Webhook is a URL events are delivered to. A webhook only receives events
+// about gateways, mappers and routers of its owner.
+type Webhook struct {
+	ID    string         `json:"id"`
+	Owner common.Address `json:"owner"`
+	URL   string         `json:"url"`
+	// Secret is the key deliveries are signed with, it is only returned when
+	// the webhook is registered.
+	Secret    string        `json:"secret,omitempty"`
+	Filter    WebhookFilter `json:"filter"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// WebhookFilter selects the deliveries of a webhook. Every criterion that is
+// set must match, within a criterion one of the values must match.
+type WebhookFilter struct {
+	// Registries are gateway, mapper or router.
+	Registries []string `json:"registries,omitempty"`
+	// IDs are gateway, mapper or router ids.
+	IDs []types.ID `json:"ids,omitempty"`
+	// Types are event types, e.g. onboard or deactivate.
+	Types []string `json:"types,omitempty"`
+	// Pending selects pending events too, otherwise only confirmed events
+	// are delivered.
+	Pending bool `json:"pending"`
+	// Rewards selects the rewards of the owner when a new reward date
+	// appears.
+	Rewards bool `json:"rewards"`
+}
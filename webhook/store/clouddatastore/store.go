@@ -0,0 +1,308 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clouddatastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	daclouddatastore "github.com/ThingsIXFoundation/data-aggregator/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/utils"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+)
+
+// Options configure a Store.
+type Options struct {
+	Client *datastore.Client
+	// Logger defaults to the standard logrus logger.
+	Logger logrus.FieldLogger
+}
+
+type Store struct {
+	client *datastore.Client
+	log    logrus.FieldLogger
+}
+
+// New creates a Store with the given options.
+func New(opts Options) *Store {
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+
+	return &Store{
+		client: opts.Client,
+		log:    opts.Logger,
+	}
+}
+
+// NewStore creates a Store from the config.
+func NewStore(ctx context.Context) (*Store, error) {
+	client, err := daclouddatastore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Client: client,
+	}), nil
+}
+
+// StoreWebhook implements store.Store
+func (s *Store) StoreWebhook(ctx context.Context, webhook *models.Webhook) error {
+	dbWebhook, err := models.NewDBWebhook(webhook)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Put(ctx, daclouddatastore.GetKey(dbWebhook), dbWebhook)
+	if err != nil {
+		s.log.WithError(err).Errorf("error while storing webhook %s in Cloud DataStore", webhook.ID)
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhook implements store.Store
+func (s *Store) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	dbWebhook := models.DBWebhook{ID: id}
+
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&dbWebhook), &dbWebhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbWebhook.Webhook()
+}
+
+// GetWebhooksByOwner implements store.Store
+func (s *Store) GetWebhooksByOwner(ctx context.Context, owner common.Address) ([]*models.Webhook, error) {
+	q := datastore.NewQuery((&models.DBWebhook{}).Entity()).FilterField("Owner", "=", utils.AddressToString(owner))
+
+	var dbWebhooks []*models.DBWebhook
+	_, err := s.client.GetAll(ctx, q, &dbWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*models.Webhook, len(dbWebhooks))
+	for i, dbWebhook := range dbWebhooks {
+		webhook, err := dbWebhook.Webhook()
+		if err != nil {
+			return nil, err
+		}
+		webhooks[i] = webhook
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook implements store.Store
+func (s *Store) DeleteWebhook(ctx context.Context, id string) error {
+	return s.client.Delete(ctx, daclouddatastore.GetKey(&models.DBWebhook{ID: id}))
+}
+
+// CreateDelivery implements store.Store
+func (s *Store) CreateDelivery(ctx context.Context, delivery *models.Delivery) error {
+	dbDelivery := models.NewDBDelivery(delivery)
+	key := daclouddatastore.GetKey(dbDelivery)
+
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		var existing models.DBDelivery
+		err := tx.Get(key, &existing)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, datastore.ErrNoSuchEntity) {
+			return err
+		}
+
+		_, err = tx.Put(key, dbDelivery)
+		return err
+	})
+	if err != nil {
+		s.log.WithError(err).Errorf("error while creating webhook delivery %s in Cloud DataStore", delivery.ID)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDelivery implements store.Store
+func (s *Store) UpdateDelivery(ctx context.Context, delivery *models.Delivery) error {
+	dbDelivery := models.NewDBDelivery(delivery)
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(dbDelivery), dbDelivery)
+	if err != nil {
+		s.log.WithError(err).Errorf("error while updating webhook delivery %s in Cloud DataStore", delivery.ID)
+		return err
+	}
+
+	return nil
+}
+
+// GetDueDeliveries implements store.Store
+func (s *Store) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.Delivery, error) {
+	q := datastore.NewQuery((&models.DBDelivery{}).Entity()).
+		FilterField("Status", "=", models.DeliveryStatusPending).
+		FilterField("NextAttempt", "<=", now).
+		Order("NextAttempt").
+		Limit(limit)
+
+	var dbDeliveries []*models.DBDelivery
+	_, err := s.client.GetAll(ctx, q, &dbDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*models.Delivery, len(dbDeliveries))
+	for i, dbDelivery := range dbDeliveries {
+		deliveries[i] = dbDelivery.Delivery()
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveries implements store.Store
+func (s *Store) GetDeliveries(ctx context.Context, webhookID string, limit int, cursor string) ([]*models.Delivery, string, error) {
+	q := datastore.NewQuery((&models.DBDelivery{}).Entity()).FilterField("WebhookID", "=", webhookID).Limit(limit + 1).Order("-CreatedAt")
+
+	if cursor != "" {
+		cursorObj, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		q = q.Start(cursorObj)
+	}
+
+	var deliveries []*models.Delivery
+	var dbDelivery models.DBDelivery
+
+	count := 0
+	var cursorObj datastore.Cursor
+	it := s.client.Run(ctx, q)
+	_, err := it.Next(&dbDelivery)
+	for err == nil {
+		deliveries = append(deliveries, dbDelivery.Delivery())
+
+		// Count the number of returned objects and when we hit the provided limit
+		// get the cursor
+		count++
+		if count == limit {
+			cursorObj, err = it.Cursor()
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		_, err = it.Next(&dbDelivery)
+	}
+	if err != iterator.Done {
+		return nil, "", err
+	}
+
+	return deliveries, cursorObj.String(), nil
+}
+
+// UseSignature implements store.Store
+func (s *Store) UseSignature(ctx context.Context, hash string, expires time.Time) (bool, error) {
+	dbSignature := &models.DBWebhookSignature{Hash: hash, Expires: expires}
+	key := daclouddatastore.GetKey(dbSignature)
+
+	first := false
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		var existing models.DBWebhookSignature
+		err := tx.Get(key, &existing)
+		if err == nil {
+			first = false
+			return nil
+		}
+		if !errors.Is(err, datastore.ErrNoSuchEntity) {
+			return err
+		}
+
+		first = true
+		_, err = tx.Put(key, dbSignature)
+		return err
+	})
+
+	return first, err
+}
+
+// PurgeExpiredSignatures implements store.Store
+func (s *Store) PurgeExpiredSignatures(ctx context.Context, before time.Time) error {
+	q := datastore.NewQuery((&models.DBWebhookSignature{}).Entity()).KeysOnly().FilterField("Expires", "<", before)
+
+	expiredKeys, err := s.client.GetAll(ctx, q, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.DeleteMulti(ctx, expiredKeys)
+}
+
+// LatestRewardsDate implements store.Store
+func (s *Store) LatestRewardsDate(ctx context.Context) (time.Time, error) {
+	var latest models.DBWebhookRewardsDate
+
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&latest), &latest)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return latest.Date, nil
+}
+
+// StoreLatestRewardsDate implements store.Store
+func (s *Store) StoreLatestRewardsDate(ctx context.Context, date time.Time) error {
+	latest := models.DBWebhookRewardsDate{Date: date}
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(&latest), &latest)
+	return err
+}
+
+// PublishedBlock implements store.Store
+func (s *Store) PublishedBlock(ctx context.Context, registry string) (uint64, error) {
+	published := models.DBWebhookPublishedBlock{Registry: registry}
+
+	err := s.client.Get(ctx, daclouddatastore.GetKey(&published), &published)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(published.BlockNumber), nil
+}
+
+// StorePublishedBlock implements store.Store
+func (s *Store) StorePublishedBlock(ctx context.Context, registry string, block uint64) error {
+	published := models.DBWebhookPublishedBlock{Registry: registry, BlockNumber: int(block)}
+
+	_, err := s.client.Put(ctx, daclouddatastore.GetKey(&published), &published)
+	return err
+}
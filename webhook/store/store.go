@@ -0,0 +1,69 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/config"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+type Store interface {
+	StoreWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	GetWebhooksByOwner(ctx context.Context, owner common.Address) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// CreateDelivery stores the delivery unless a delivery with the same id
+	// already exists, so triggers can be replayed without delivering twice.
+	CreateDelivery(ctx context.Context, delivery *models.Delivery) error
+	UpdateDelivery(ctx context.Context, delivery *models.Delivery) error
+	// GetDueDeliveries returns pending deliveries that are due at now, the
+	// longest waiting first.
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.Delivery, error)
+	GetDeliveries(ctx context.Context, webhookID string, limit int, cursor string) ([]*models.Delivery, string, error)
+
+	// UseSignature records that the signed message with the given hash was
+	// used, it returns false when it was already used before.
+	UseSignature(ctx context.Context, hash string, expires time.Time) (bool, error)
+	// PurgeExpiredSignatures removes the used signatures that expired before
+	// the given time.
+	PurgeExpiredSignatures(ctx context.Context, before time.Time) error
+
+	LatestRewardsDate(ctx context.Context) (time.Time, error)
+	StoreLatestRewardsDate(ctx context.Context, date time.Time) error
+
+	// PublishedBlock returns the first block of which the stored events of
+	// the registry aren't turned into deliveries yet, 0 when none were.
+	PublishedBlock(ctx context.Context, registry string) (uint64, error)
+	StorePublishedBlock(ctx context.Context, registry string, block uint64) error
+}
+
+func NewStore() (Store, error) {
+	store := viper.GetString(config.CONFIG_WEBHOOK_STORE)
+	if store == "clouddatastore" {
+		return clouddatastore.NewStore(context.Background())
+	} else {
+		return nil, fmt.Errorf("invalid store type: %s", viper.GetString(config.CONFIG_WEBHOOK_STORE))
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Stichting ThingsIX Foundation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook delivers gateway, mapper and router events and new rewards
+// to URLs registered by owners. Deliveries are stored first and posted with
+// an HMAC signature by the dispatcher, which retries failed deliveries with
+// exponential backoff.
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ThingsIXFoundation/data-aggregator/eventbus"
+	"github.com/ThingsIXFoundation/data-aggregator/webhook/store/clouddatastore/models"
+	"github.com/ThingsIXFoundation/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NewWebhook creates a webhook for owner with a random id and secret.
+func NewWebhook(owner common.Address, url string, filter models.WebhookFilter) (*models.Webhook, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Webhook{
+		ID:        id,
+		Owner:     owner,
+		URL:       url,
+		Secret:    secret,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Payload is the JSON body posted to a webhook, Event is set for event
+// deliveries and Rewards for rewards deliveries.
+type Payload struct {
+	// ID is the id of the delivery, it is the same for all attempts.
+	ID        string                      `json:"id"`
+	WebhookID string                      `json:"webhookId"`
+	Kind      string                      `json:"kind"`
+	Event     *eventbus.Event             `json:"event,omitempty"`
+	Rewards   *types.AccountRewardHistory `json:"rewards,omitempty"`
+	CreatedAt time.Time                   `json:"createdAt"`
+}
+
+// newDelivery creates a pending delivery of the payload to the webhook. The
+// delivery id is derived from the webhook and the key of what is delivered,
+// so a trigger that fires again for the same event doesn't deliver twice.
+func newDelivery(webhook *models.Webhook, key string, payload *Payload) (*models.Delivery, error) {
+	id := sha256.Sum256([]byte(webhook.ID + "|" + key))
+
+	now := time.Now()
+	payload.ID = hex.EncodeToString(id[:16])
+	payload.WebhookID = webhook.ID
+	payload.CreatedAt = now
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Delivery{
+		ID:          payload.ID,
+		WebhookID:   webhook.ID,
+		Kind:        payload.Kind,
+		Payload:     body,
+		Status:      models.DeliveryStatusPending,
+		NextAttempt: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+// eventKey identifies an event, the pending and confirmed version of an event
+// are delivered separately.
+func eventKey(event *eventbus.Event) string {
+	return fmt.Sprintf("%s|%t|%s", event.Registry, event.Pending, event.Position())
+}
+
+// matchEvent returns true if the filter selects the event, the event must be
+// about a gateway, mapper or router of the webhook owner.
+func matchEvent(filter *models.WebhookFilter, event *eventbus.Event) bool {
+	if event.Pending && !filter.Pending {
+		return false
+	}
+	if len(filter.Registries) > 0 && !contains(filter.Registries, event.Registry) {
+		return false
+	}
+	if len(filter.IDs) > 0 && !contains(filter.IDs, event.ID()) {
+		return false
+	}
+	if len(filter.Types) > 0 && !contains(filter.Types, event.Type()) {
+		return false
+	}
+	return true
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}